@@ -1,64 +1,120 @@
 package main
 
 import (
-	"log"
+	"context"
+	"log/slog"
 	"net/http"
-	"net/http/httputil"
-	"net/url"
 	"os"
 	"strings"
 	"time"
 
+	"jobfair-api-gateway/internal/auth"
+	"jobfair-api-gateway/internal/bff"
+	"jobfair-api-gateway/internal/config"
+	"jobfair-api-gateway/internal/handlers"
+	"jobfair-api-gateway/internal/middleware"
+	"jobfair-api-gateway/internal/ratelimit"
+	"jobfair-api-gateway/internal/routes"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/jobfair/shared/health"
+	"github.com/jobfair/shared/httpclient"
+	"github.com/jobfair/shared/logging"
+	"github.com/jobfair/shared/metrics"
+	"github.com/jobfair/shared/tracing"
 )
 
-type ServiceConfig struct {
-	Name string
-	URL  string
-	Path string
-}
-
 func main() {
-	// Load environment variables
-	port := getEnv("PORT", "8000")
-	authServiceURL := getEnv("AUTH_SERVICE_URL", "http://localhost:8080")
-	companyServiceURL := getEnv("COMPANY_SERVICE_URL", "http://localhost:8081")
-	jobServiceURL := getEnv("JOB_SERVICE_URL", "http://localhost:8082")
-	userProfileServiceURL := getEnv("USER_PROFILE_SERVICE_URL", "http://localhost:8083")
+	// JSON logs with request and trace IDs; LOG_LEVEL and LOG_FORMAT tune them
+	logging.Setup(logging.ConfigFromEnv("api-gateway"))
+
+	cfg, err := config.Load()
+	if err != nil {
+		logging.Fatal("Failed to load gateway config", "error", err)
+	}
+
+	// Initialize tracing; OTEL_TRACES_EXPORTER selects where spans go
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.ConfigFromEnv("api-gateway"))
+	if err != nil {
+		logging.Fatal("Failed to initialize tracing", "error", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	// Rate limit buckets
+	var store ratelimit.Store = ratelimit.NewMemoryStore()
+	checker := health.NewChecker("api-gateway")
+	if cfg.RateLimit.Backend == config.RateLimitRedis {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		redisStore, err := ratelimit.NewRedisStoreFromURL(ctx, cfg.RateLimit.RedisURL)
+		cancel()
+		if err != nil {
+			logging.Fatal("Failed to initialize rate limit store", "error", err)
+		}
+		store = redisStore
+		// Requests are allowed while Redis is down, so it is optional
+		checker.AddOptional("redis", redisStore.Ping)
+	}
+	limiter := ratelimit.NewLimiter(store)
+
+	// Pooled upstream connections with retries and a circuit breaker per upstream
+	transport := httpclient.NewTransport(cfg.UpstreamClient.HTTPClient())
+	upstreamTransport := tracing.Transport(transport)
+	verifier := auth.NewVerifier(cfg.JWTSecret)
+
+	// Load the route table; the gateway doesn't start with an invalid one
+	router, err := routes.NewRouter(cfg.RoutesFile, routes.Options{
+		Verifier:       verifier,
+		IdentitySecret: cfg.IdentitySecret,
+		Limiter:        limiter,
+		APIKeys:        cfg.RateLimit.APIKeys,
+		Transport:      upstreamTransport,
+	})
+	if err != nil {
+		logging.Fatal("Failed to load routes", "error", err)
+	}
+	if cfg.ReloadInterval > 0 {
+		go router.Watch(context.Background(), cfg.ReloadInterval)
+	}
 
-	// Initialize Gin router
-	router := gin.Default()
+	// Initialize Gin router; requests are logged by logging.Middleware
+	engine := gin.New()
+	engine.Use(gin.Recovery())
 
 	// Disable automatic trailing slash redirect to prevent 301 loops
-	router.RedirectTrailingSlash = false
+	engine.RedirectTrailingSlash = false
+
+	// Client IPs (used by IP rate limits) only come from X-Forwarded-For
+	// when sent by a trusted proxy
+	if err := engine.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		logging.Fatal("Invalid trusted proxies", "error", err)
+	}
 
 	// Set max multipart memory for file uploads (50MB)
-	router.MaxMultipartMemory = 50 << 20
+	engine.MaxMultipartMemory = 50 << 20
+
+	// Request IDs and trace spans; services continue both from the
+	// X-Request-ID and traceparent headers sent upstream
+	engine.Use(tracing.Middleware("api-gateway")...)
+
+	// Request rate, errors and latency per route table entry
+	engine.Use(metrics.Middleware())
 
 	// CORS middleware
-	router.Use(corsMiddleware())
+	engine.Use(corsMiddleware())
 
-	// Logging middleware
-	router.Use(loggingMiddleware())
+	// Access log
+	engine.Use(logging.Middleware())
 
-	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":    "healthy",
-			"service":   "api-gateway",
-			"timestamp": time.Now().Format(time.RFC3339),
-			"services": gin.H{
-				"auth":    authServiceURL,
-				"company": companyServiceURL,
-				"job":     jobServiceURL,
-				"profile": userProfileServiceURL,
-			},
-		})
-	})
+	// Liveness (/livez), readiness of the gateway itself (/readyz) and
+	// readiness of every upstream (/health)
+	healthHandler := handlers.NewHealthHandler(router, transport, checker)
+	engine.GET("/livez", health.Live)
+	engine.GET("/readyz", checker.Ready)
+	engine.GET("/health", healthHandler.Health)
 
 	// Service status endpoint
-	router.GET("/status", func(c *gin.Context) {
+	engine.GET("/status", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"gateway": "running",
 			"version": "1.0.0",
@@ -66,158 +122,60 @@ func main() {
 		})
 	})
 
-	// Create reverse proxies
-	authProxy := createReverseProxy(authServiceURL, "auth-service")
-	companyProxy := createReverseProxy(companyServiceURL, "company-service")
-	jobProxy := createReverseProxy(jobServiceURL, "job-service")
-	profileProxy := createReverseProxy(userProfileServiceURL, "user-profile-service")
-
-	// ==================== AUTH SERVICE ROUTES ====================
-	// Login & Token routes
-	router.Any("/api/v1/login", proxyHandler(authProxy, "/api/v1/login"))
-	router.Any("/api/v1/refresh", proxyHandler(authProxy, "/api/v1/refresh"))
-
-	// Get current user (protected)
-	router.Any("/api/v1/me", proxyHandler(authProxy, "/api/v1/me"))
-
-	// Registration routes (multi-step)
-	router.Any("/api/v1/register/*proxyPath", proxyHandler(authProxy, "/api/v1/register"))
-	router.Any("/api/v1/register", proxyHandler(authProxy, "/api/v1/register"))
-
-	// Other auth routes
-	router.Any("/api/v1/auth/*proxyPath", proxyHandler(authProxy, "/api/v1/auth"))
-
-	// ==================== STATIC FILES - AUTH SERVICE ====================
-	// Profile photos and company logos
-	router.Any("/uploads/profiles/*filepath", proxyHandler(authProxy, "/uploads/profiles"))
-	router.Any("/uploads/companies/*filepath", proxyHandler(companyProxy, "/uploads/companies"))
-
-	// ==================== COMPANY SERVICE ROUTES ====================
-	// Company CRUD routes
-	router.Any("/api/v1/companies/*proxyPath", proxyHandler(companyProxy, "/api/v1/companies"))
-	router.Any("/api/v1/companies", proxyHandler(companyProxy, "/api/v1/companies"))
-
-	// My company route
-	router.Any("/api/v1/my-company", proxyHandler(companyProxy, "/api/v1/my-company"))
-
-	// ==================== JOB SERVICE ROUTES ====================
-	router.Any("/api/v1/jobs/*proxyPath", proxyHandler(jobProxy, "/api/v1/jobs"))
-	router.Any("/api/v1/jobs", proxyHandler(jobProxy, "/api/v1/jobs"))
-	router.Any("/api/v1/applications/*proxyPath", proxyHandler(jobProxy, "/api/v1/applications"))
-	router.Any("/api/v1/applications", proxyHandler(jobProxy, "/api/v1/applications"))
-
-	// ==================== USER PROFILE SERVICE ROUTES ====================
-	// Profile routes
-	router.Any("/api/v1/profiles/*proxyPath", proxyHandler(profileProxy, "/api/v1/profiles"))
-	router.Any("/api/v1/profiles", proxyHandler(profileProxy, "/api/v1/profiles"))
-
-	// Work Experience routes
-	router.Any("/api/v1/work-experiences/*proxyPath", proxyHandler(profileProxy, "/api/v1/work-experiences"))
-	router.Any("/api/v1/work-experiences", proxyHandler(profileProxy, "/api/v1/work-experiences"))
-
-	// Education routes
-	router.Any("/api/v1/educations/*proxyPath", proxyHandler(profileProxy, "/api/v1/educations"))
-	router.Any("/api/v1/educations", proxyHandler(profileProxy, "/api/v1/educations"))
-
-	// Certifications routes
-	router.Any("/api/v1/certifications/*proxyPath", proxyHandler(profileProxy, "/api/v1/certifications"))
-	router.Any("/api/v1/certifications", proxyHandler(profileProxy, "/api/v1/certifications"))
-
-	// Skills routes
-	router.Any("/api/v1/skills/*proxyPath", proxyHandler(profileProxy, "/api/v1/skills"))
-	router.Any("/api/v1/skills", proxyHandler(profileProxy, "/api/v1/skills"))
-
-	// Career Preference routes
-	router.Any("/api/v1/career-preference/*proxyPath", proxyHandler(profileProxy, "/api/v1/career-preference"))
-	router.Any("/api/v1/career-preference", proxyHandler(profileProxy, "/api/v1/career-preference"))
-
-	// Position Preferences routes
-	router.Any("/api/v1/position-preferences/*proxyPath", proxyHandler(profileProxy, "/api/v1/position-preferences"))
-	router.Any("/api/v1/position-preferences", proxyHandler(profileProxy, "/api/v1/position-preferences"))
-
-	// CV routes
-	router.Any("/api/v1/cv/*proxyPath", proxyHandler(profileProxy, "/api/v1/cv"))
-	router.Any("/api/v1/cv", proxyHandler(profileProxy, "/api/v1/cv"))
-
-	// Banner routes
-	router.Any("/api/v1/banner/*proxyPath", proxyHandler(profileProxy, "/api/v1/banner"))
-	router.Any("/api/v1/banner", proxyHandler(profileProxy, "/api/v1/banner"))
+	// Composite endpoints for the apps: sections fetched concurrently from
+	// the services, partial data when one of them is down
+	bffClient := bff.NewClient(router, upstreamTransport, cfg.IdentitySecret, cfg.BFF.Timeout)
+	bffHandler := handlers.NewBFFHandler(bffClient, verifier, router, cfg.BFF.RateLimit)
+	engine.GET("/api/v1/bff/home", bffHandler.Home)
+	engine.GET("/api/v1/bff/job/:id", bffHandler.Job)
+
+	// Gateway admin endpoints
+	adminHandler := handlers.NewAdminHandler(router, limiter, cfg.RateLimit.Backend, cfg.BFF.RateLimit)
+	admin := engine.Group("/admin")
+	admin.Use(middleware.AdminToken(cfg.AdminToken))
+	{
+		admin.GET("/routes", adminHandler.ListRoutes)
+		admin.POST("/routes/reload", adminHandler.ReloadRoutes)
+		admin.GET("/rate-limits", adminHandler.ListRateLimits)
+	}
 
-	// Badges routes
-	router.Any("/api/v1/badges/*proxyPath", proxyHandler(profileProxy, "/api/v1/badges"))
-	router.Any("/api/v1/badges", proxyHandler(profileProxy, "/api/v1/badges"))
+	// Prometheus metrics, including rate limit outcomes and circuit breaker states
+	metrics.MustRegister(limiter.Metrics(), transport)
+	if cfg.MetricsPort == "" {
+		metrics.Register(engine)
+	} else {
+		go serveMetrics(cfg.MetricsPort)
+	}
 
-	// ==================== STATIC FILES - USER PROFILE SERVICE ====================
-	// CV files
-	router.Any("/uploads/cv/*filepath", proxyHandler(profileProxy, "/uploads/cv"))
-	// Banner images
-	router.Any("/uploads/banners/*filepath", proxyHandler(profileProxy, "/uploads/banners"))
+	// Everything else is proxied through the route table (configs/routes.yaml)
+	engine.NoRoute(router.Handle)
 
-	// 404 handler
-	router.NoRoute(func(c *gin.Context) {
-		c.JSON(http.StatusNotFound, gin.H{
-			"success": false,
-			"message": "Route not found",
-			"path":    c.Request.URL.Path,
-		})
-	})
+	if cfg.IdentitySecret == "" {
+		slog.Warn("GATEWAY_IDENTITY_SECRET is not set: identity headers are sent unsigned, services must only trust them from the gateway's network")
+	}
 
 	// Start server
-	log.Printf("🚀 API Gateway starting on port %s", port)
-	log.Printf("📡 Proxying to services:")
-	log.Printf("   - Auth Service: %s", authServiceURL)
-	log.Printf("   - Company Service: %s", companyServiceURL)
-	log.Printf("   - Job Service: %s", jobServiceURL)
-	log.Printf("   - User Profile Service: %s", userProfileServiceURL)
-
-	if err := router.Run(":" + port); err != nil {
-		log.Fatalf("❌ Failed to start API Gateway: %v", err)
+	table := router.Table()
+	slog.Info("API Gateway starting", "port", cfg.Port, "rate_limit_backend", cfg.RateLimit.Backend,
+		"routes", len(table.Routes()), "routes_file", table.Source)
+	for _, entry := range table.Routes() {
+		slog.Debug("Route loaded", "prefix", entry.Prefix, "upstream", entry.Upstream, "target", entry.Target.String())
 	}
-}
-
-// proxyHandler creates a Gin handler that proxies requests
-func proxyHandler(proxy *httputil.ReverseProxy, pathPrefix string) gin.HandlerFunc {
-	return gin.WrapF(func(w http.ResponseWriter, r *http.Request) {
-		// Store original path
-		originalPath := r.URL.Path
-
-		// Keep the original path without modification
-		// The target service will handle the full path including prefix
 
-		// Log the proxy
-		log.Printf("🔄 Proxying: %s", originalPath)
-
-		// Serve the request with original path
-		proxy.ServeHTTP(w, r)
-	})
-}
-
-// createReverseProxy creates a reverse proxy for a service
-func createReverseProxy(targetURL string, serviceName string) *httputil.ReverseProxy {
-	target, err := url.Parse(targetURL)
-	if err != nil {
-		log.Fatalf("Failed to parse URL for %s: %v", serviceName, err)
+	if err := engine.Run(":" + cfg.Port); err != nil {
+		logging.Fatal("Failed to start API Gateway", "error", err)
 	}
+}
 
-	proxy := httputil.NewSingleHostReverseProxy(target)
-
-	// Custom director to modify the request
-	originalDirector := proxy.Director
-	proxy.Director = func(req *http.Request) {
-		originalDirector(req)
-		req.Header.Set("X-Forwarded-Host", req.Header.Get("Host"))
-		req.Header.Set("X-Origin-Host", target.Host)
-		req.Host = target.Host
-	}
+// serveMetrics serves /metrics on its own port
+func serveMetrics(port string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
 
-	// Error handler
-	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
-		log.Printf("❌ Proxy error for %s: %v", serviceName, err)
-		w.WriteHeader(http.StatusBadGateway)
-		w.Write([]byte(`{"success":false,"message":"Service temporarily unavailable","error":"` + err.Error() + `"}`))
+	slog.Info("Serving metrics", "port", port)
+	if err := http.ListenAndServe(":"+port, mux); err != nil {
+		logging.Fatal("Failed to serve metrics", "error", err)
 	}
-
-	return proxy
 }
 
 // corsMiddleware sets up CORS
@@ -225,8 +183,8 @@ func corsMiddleware() gin.HandlerFunc {
 	config := cors.Config{
 		AllowOrigins:     strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "*"), ","),
 		AllowMethods:     strings.Split(getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,DELETE,OPTIONS,PATCH"), ","),
-		AllowHeaders:     strings.Split(getEnv("CORS_ALLOWED_HEADERS", "Content-Type,Authorization,Accept,Origin,User-Agent,Cache-Control,Keep-Alive,X-Requested-With"), ","),
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     strings.Split(getEnv("CORS_ALLOWED_HEADERS", "Content-Type,Authorization,Accept,Origin,User-Agent,Cache-Control,Keep-Alive,X-Requested-With,X-Request-ID"), ","),
+		ExposeHeaders:    []string{"Content-Length", "X-Request-ID", "RateLimit-Policy", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"},
 		AllowCredentials: true,
 		AllowFiles:       true,
 		MaxAge:           12 * time.Hour,
@@ -235,28 +193,6 @@ func corsMiddleware() gin.HandlerFunc {
 	return cors.New(config)
 }
 
-// loggingMiddleware logs each request
-func loggingMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		start := time.Now()
-		path := c.Request.URL.Path
-		method := c.Request.Method
-
-		c.Next()
-
-		latency := time.Since(start)
-		statusCode := c.Writer.Status()
-
-		log.Printf("[%s] %s %s - %d (%v)",
-			method,
-			path,
-			c.ClientIP(),
-			statusCode,
-			latency,
-		)
-	}
-}
-
 // getEnv gets an environment variable or returns a default value
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrMissingToken = errors.New("authorization header required")
+	ErrInvalidToken = errors.New("invalid or expired token")
+)
+
+// Claims are the access token claims issued by auth-service
+type Claims struct {
+	UserID   uint   `json:"user_id"`
+	UserType string `json:"user_type"`
+	jwt.RegisteredClaims
+}
+
+// Verifier checks access tokens issued by auth-service
+type Verifier struct {
+	secret []byte
+	parser *jwt.Parser
+}
+
+func NewVerifier(secret string) *Verifier {
+	return &Verifier{
+		secret: []byte(secret),
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+			jwt.WithExpirationRequired(),
+		),
+	}
+}
+
+// Verify returns the claims of the request's bearer token. It returns
+// ErrMissingToken without an Authorization header and ErrInvalidToken for
+// malformed, forged or expired tokens, and for refresh tokens.
+func (v *Verifier) Verify(r *http.Request) (*Claims, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return nil, ErrMissingToken
+	}
+
+	scheme, tokenString, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(tokenString) == "" {
+		return nil, ErrInvalidToken
+	}
+
+	claims := &Claims{}
+	token, err := v.parser.ParseWithClaims(strings.TrimSpace(tokenString), claims, func(*jwt.Token) (interface{}, error) {
+		return v.secret, nil
+	})
+	if err != nil || !token.Valid || claims.UserID == 0 {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
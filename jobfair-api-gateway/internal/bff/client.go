@@ -0,0 +1,198 @@
+package bff
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"jobfair-api-gateway/internal/routes"
+
+	"github.com/jobfair/shared/httpclient"
+	"github.com/jobfair/shared/identity"
+)
+
+// DefaultTimeout bounds each section's upstream call when none is configured
+const DefaultTimeout = 3 * time.Second
+
+// maxSectionBody bounds the response read from an upstream for one section
+const maxSectionBody = 4 << 20
+
+// Section states
+const (
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// Request is one section of a composite response, fetched with a GET from an
+// upstream of the route table
+type Request struct {
+	Section  string     // Key of the section in the response
+	Upstream string     // Key in the route table's upstreams
+	Path     string     // Path on the upstream, e.g. /api/v1/me
+	Query    url.Values // Optional query parameters
+
+	// SkipAnalytics marks the request as an internal lookup, e.g. a company
+	// shown on a job's screen, so it is not counted as a profile view
+	SkipAnalytics bool
+}
+
+// Caller is the authenticated user sections are fetched for
+type Caller struct {
+	Identity      identity.Identity
+	Authorization string // The client's Authorization header, forwarded like proxied requests
+}
+
+// Section is the data of one upstream response, or why it is missing
+type Section struct {
+	Status string          `json:"status"`
+	Data   json.RawMessage `json:"data,omitempty"`
+	Meta   json.RawMessage `json:"meta,omitempty"`
+	Error  *SectionError   `json:"error,omitempty"`
+}
+
+// SectionError is the HTTP status and message of a failed section
+type SectionError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// OK reports whether the section has data
+func (s *Section) OK() bool {
+	return s.Status == StatusOK
+}
+
+// Code returns the HTTP status of the section's upstream response
+func (s *Section) Code() int {
+	if s.Error != nil {
+		return s.Error.Code
+	}
+	return http.StatusOK
+}
+
+func failed(code int, message string) *Section {
+	return &Section{Status: StatusError, Error: &SectionError{Code: code, Message: message}}
+}
+
+// Client fetches the sections of composite responses from the upstreams of
+// the current route table
+type Client struct {
+	router         *routes.Router
+	client         *http.Client
+	identitySecret string
+	timeout        time.Duration
+}
+
+// NewClient sends section requests through transport, so they share the
+// proxied requests' connection pools, retries and circuit breakers. Each
+// section's call is bounded by timeout.
+func NewClient(router *routes.Router, transport http.RoundTripper, identitySecret string, timeout time.Duration) *Client {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Client{
+		router:         router,
+		client:         &http.Client{Transport: transport},
+		identitySecret: identitySecret,
+		timeout:        timeout,
+	}
+}
+
+// Fetch sends the requests concurrently for caller (nil for anonymous
+// requests) and returns their sections by name. A failed or timed out
+// request only fails its own section.
+func (c *Client) Fetch(ctx context.Context, caller *Caller, requests ...Request) map[string]*Section {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		sections = make(map[string]*Section, len(requests))
+	)
+	for _, request := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			section := c.get(ctx, caller, request)
+			if !section.OK() {
+				slog.WarnContext(ctx, "Composite section failed", "section", request.Section, "upstream", request.Upstream,
+					"path", request.Path, "status", section.Error.Code, "error", section.Error.Message)
+			}
+
+			mu.Lock()
+			sections[request.Section] = section
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return sections
+}
+
+// get fetches one section. Services answer with a {success, message, data,
+// meta} envelope; data and meta are passed on as they are.
+func (c *Client) get(ctx context.Context, caller *Caller, request Request) *Section {
+	target, ok := c.router.Table().Upstreams()[request.Upstream]
+	if !ok {
+		return failed(http.StatusBadGateway, "Unknown upstream "+request.Upstream)
+	}
+	endpoint := target.JoinPath(request.Path)
+	endpoint.RawQuery = request.Query.Encode()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return failed(http.StatusBadGateway, err.Error())
+	}
+	req.Header.Set("Accept", "application/json")
+	if caller != nil {
+		if caller.Authorization != "" {
+			req.Header.Set("Authorization", caller.Authorization)
+		}
+		identity.Set(req, c.identitySecret, caller.Identity, time.Now())
+	}
+	if request.SkipAnalytics {
+		identity.SetSkipAnalytics(req, c.identitySecret, time.Now())
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return failed(http.StatusGatewayTimeout, "Service timed out")
+		case errors.Is(err, httpclient.ErrCircuitOpen):
+			return failed(http.StatusServiceUnavailable, "Service temporarily unavailable")
+		}
+		return failed(http.StatusBadGateway, "Service temporarily unavailable")
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Data    json.RawMessage `json:"data"`
+		Meta    json.RawMessage `json:"meta"`
+		Message string          `json:"message"`
+		Error   json.RawMessage `json:"error"`
+	}
+	decodeErr := json.NewDecoder(io.LimitReader(resp.Body, maxSectionBody)).Decode(&envelope)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message := envelope.Message
+		var text string
+		if message == "" && json.Unmarshal(envelope.Error, &text) == nil {
+			message = text
+		}
+		if message == "" {
+			message = http.StatusText(resp.StatusCode)
+		}
+		return failed(resp.StatusCode, message)
+	}
+	if decodeErr != nil {
+		return failed(http.StatusBadGateway, "Invalid response from service")
+	}
+
+	return &Section{Status: StatusOK, Data: envelope.Data, Meta: envelope.Meta}
+}
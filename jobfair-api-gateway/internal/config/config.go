@@ -0,0 +1,176 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"jobfair-api-gateway/internal/ratelimit"
+
+	"github.com/jobfair/shared/httpclient"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the gateway settings from configs/gateway.yaml
+type Config struct {
+	Port           string        `yaml:"port"`
+	RoutesFile     string        `yaml:"routes_file"`
+	ReloadInterval time.Duration `yaml:"reload_interval"` // How often the routes file is checked for changes; 0 disables reloading
+	AdminToken     string        `yaml:"admin_token"`     // Required by /admin endpoints; empty disables them
+	JWTSecret      string        `yaml:"jwt_secret"`      // Verifies access tokens issued by auth-service
+	IdentitySecret string        `yaml:"identity_secret"` // Signs identity headers sent to services
+	TrustedProxies []string      `yaml:"trusted_proxies"` // Proxies whose X-Forwarded-For is used for the client IP
+	MetricsPort    string        `yaml:"metrics_port"`    // Serves /metrics apart from public traffic; empty serves it on Port
+	RateLimit      RateLimit     `yaml:"rate_limit"`
+	UpstreamClient Resilience    `yaml:"upstream_client"`
+	BFF            BFF           `yaml:"bff"`
+}
+
+// BFF configures the composite endpoints (/api/v1/bff/*)
+type BFF struct {
+	Timeout   time.Duration     `yaml:"timeout"`    // Per section; a section that takes longer fails on its own
+	RateLimit *ratelimit.Policy `yaml:"rate_limit"` // Per endpoint; empty leaves them unlimited
+}
+
+// Resilience configures retries, circuit breakers and connection pooling of
+// upstream requests. Overall timeouts are set per route.
+type Resilience struct {
+	AttemptTimeout   time.Duration `yaml:"attempt_timeout"` // Per try; 0 gives the first try the whole route timeout
+	Retries          int           `yaml:"retries"`         // Extra tries of idempotent requests after connection errors, 502, 503 and 504
+	RetryBaseDelay   time.Duration `yaml:"retry_base_delay"`
+	RetryMaxDelay    time.Duration `yaml:"retry_max_delay"`
+	BreakerThreshold int           `yaml:"breaker_threshold"` // Consecutive failures that open an upstream's breaker
+	BreakerCooldown  time.Duration `yaml:"breaker_cooldown"`  // How long an open breaker fails fast
+	MaxIdleConns     int           `yaml:"max_idle_conns"`    // Idle connections kept per upstream
+}
+
+// HTTPClient converts the settings for the shared HTTP client
+func (r Resilience) HTTPClient() httpclient.Config {
+	return httpclient.Config{
+		Timeout:          r.AttemptTimeout,
+		MaxRetries:       r.Retries,
+		RetryBaseDelay:   r.RetryBaseDelay,
+		RetryMaxDelay:    r.RetryMaxDelay,
+		BreakerThreshold: r.BreakerThreshold,
+		BreakerCooldown:  r.BreakerCooldown,
+		MaxIdleConns:     r.MaxIdleConns,
+		IdleConnTimeout:  90 * time.Second,
+	}
+}
+
+// Rate limit store backends
+const (
+	RateLimitMemory = "memory"
+	RateLimitRedis  = "redis"
+)
+
+// RateLimit selects where route rate limit buckets are kept
+type RateLimit struct {
+	Backend  string   `yaml:"backend"`   // memory (per gateway instance) or redis (shared)
+	RedisURL string   `yaml:"redis_url"` // Used by the redis backend
+	APIKeys  []string `yaml:"api_keys"`  // Keys api_key limits count by; other keys count by IP
+}
+
+// Load reads the gateway settings from the file in GATEWAY_CONFIG (default
+// configs/gateway.yaml). A missing file leaves the defaults in place.
+func Load() (*Config, error) {
+	cfg := &Config{
+		Port:           "8000",
+		RoutesFile:     "configs/routes.yaml",
+		ReloadInterval: 5 * time.Second,
+		JWTSecret:      os.Getenv("JWT_SECRET"),
+		IdentitySecret: os.Getenv("GATEWAY_IDENTITY_SECRET"),
+		RateLimit:      RateLimit{Backend: RateLimitMemory},
+		UpstreamClient: Resilience{
+			Retries:          2,
+			RetryBaseDelay:   100 * time.Millisecond,
+			RetryMaxDelay:    time.Second,
+			BreakerThreshold: 5,
+			BreakerCooldown:  30 * time.Second,
+			MaxIdleConns:     64,
+		},
+		BFF: BFF{Timeout: 3 * time.Second},
+	}
+
+	path := getEnv("GATEWAY_CONFIG", "configs/gateway.yaml")
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := yaml.Unmarshal([]byte(ExpandEnv(string(data))), cfg); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	}
+
+	if cfg.ReloadInterval < 0 {
+		return nil, fmt.Errorf("%s: reload_interval must not be negative", path)
+	}
+	if cfg.JWTSecret == "" {
+		return nil, fmt.Errorf("%s: jwt_secret (JWT_SECRET) is required", path)
+	}
+	switch cfg.RateLimit.Backend {
+	case RateLimitMemory:
+	case RateLimitRedis:
+		if cfg.RateLimit.RedisURL == "" {
+			return nil, fmt.Errorf("%s: rate_limit.redis_url is required by the redis backend", path)
+		}
+	default:
+		return nil, fmt.Errorf("%s: rate_limit.backend must be %s or %s", path, RateLimitMemory, RateLimitRedis)
+	}
+	cfg.TrustedProxies = splitList(cfg.TrustedProxies)
+	cfg.RateLimit.APIKeys = splitList(cfg.RateLimit.APIKeys)
+
+	client := cfg.UpstreamClient
+	if client.AttemptTimeout < 0 || client.Retries < 0 || client.RetryBaseDelay < 0 || client.RetryMaxDelay < 0 ||
+		client.BreakerCooldown < 0 || client.MaxIdleConns < 0 {
+		return nil, fmt.Errorf("%s: upstream_client settings must not be negative", path)
+	}
+	if client.BreakerThreshold < 1 {
+		return nil, fmt.Errorf("%s: upstream_client.breaker_threshold must be at least 1", path)
+	}
+	if cfg.BFF.Timeout <= 0 {
+		return nil, fmt.Errorf("%s: bff.timeout must be positive", path)
+	}
+	if cfg.BFF.RateLimit != nil {
+		if err := cfg.BFF.RateLimit.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: bff.rate_limit: %w", path, err)
+		}
+	}
+
+	return cfg, nil
+}
+
+// ExpandEnv replaces ${VAR} and ${VAR:-default} in s with environment
+// variables. Unset variables without a default expand to an empty string.
+func ExpandEnv(s string) string {
+	return os.Expand(s, func(key string) string {
+		name, fallback, hasDefault := strings.Cut(key, ":-")
+		if value := os.Getenv(name); value != "" || !hasDefault {
+			return value
+		}
+		return fallback
+	})
+}
+
+// splitList splits comma-separated entries, so a list can come from a single
+// environment variable, and drops empty ones
+func splitList(values []string) []string {
+	var list []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
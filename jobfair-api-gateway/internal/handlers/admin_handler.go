@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"jobfair-api-gateway/internal/ratelimit"
+	"jobfair-api-gateway/internal/routes"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	router           *routes.Router
+	limiter          *ratelimit.Limiter
+	rateLimitBackend string
+	bffRateLimit     *ratelimit.Policy
+}
+
+func NewAdminHandler(router *routes.Router, limiter *ratelimit.Limiter, rateLimitBackend string, bffRateLimit *ratelimit.Policy) *AdminHandler {
+	return &AdminHandler{router: router, limiter: limiter, rateLimitBackend: rateLimitBackend, bffRateLimit: bffRateLimit}
+}
+
+type routeView struct {
+	Name      string         `json:"name"`
+	Prefix    string         `json:"prefix"`
+	Methods   []string       `json:"methods"`
+	Upstream  string         `json:"upstream"`
+	Target    string         `json:"target"`
+	Rewrite   string         `json:"rewrite,omitempty"`
+	Auth      routes.Auth    `json:"auth"`
+	Roles     []string       `json:"roles,omitempty"`
+	Timeout   string         `json:"timeout"`
+	RateLimit *rateLimitView `json:"rate_limit,omitempty"`
+}
+
+type rateLimitView struct {
+	Requests int           `json:"requests"`
+	Per      string        `json:"per"`
+	Burst    int           `json:"burst"`
+	Key      ratelimit.Key `json:"key"`
+}
+
+func newRateLimitView(policy *ratelimit.Policy) *rateLimitView {
+	if policy == nil {
+		return nil
+	}
+	return &rateLimitView{
+		Requests: policy.Requests,
+		Per:      policy.Per.String(),
+		Burst:    policy.Burst,
+		Key:      policy.Key,
+	}
+}
+
+// ListRoutes returns the effective route table in match order
+// GET /admin/routes
+func (h *AdminHandler) ListRoutes(c *gin.Context) {
+	table := h.router.Table()
+
+	views := make([]routeView, 0, len(table.Routes()))
+	for _, entry := range table.Routes() {
+		methods := entry.Methods
+		if len(methods) == 0 {
+			methods = []string{"*"}
+		}
+		views = append(views, routeView{
+			Name:      entry.Name,
+			Prefix:    entry.Prefix,
+			Methods:   methods,
+			Upstream:  entry.Upstream,
+			Target:    entry.Target.String(),
+			Rewrite:   entry.Rewrite,
+			Auth:      entry.Auth,
+			Roles:     entry.Roles,
+			Timeout:   entry.Timeout.String(),
+			RateLimit: newRateLimitView(entry.RateLimit),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"source":    table.Source,
+			"loaded_at": table.LoadedAt.Format(time.RFC3339),
+			"routes":    views,
+		},
+	})
+}
+
+// ReloadRoutes reloads the routes file right away
+// POST /admin/routes/reload
+func (h *AdminHandler) ReloadRoutes(c *gin.Context) {
+	if err := h.router.Reload(); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"success": false,
+			"message": "Routes file is invalid, keeping the previous routes",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	h.ListRoutes(c)
+}
+
+type rateLimitStats struct {
+	Route  string         `json:"route"`
+	Policy *rateLimitView `json:"policy,omitempty"` // Empty when the route no longer has a limit
+	ratelimit.Counts
+}
+
+// ListRateLimits returns the rate limited routes, including the composite
+// endpoints, with their allowed and rejected request counts since the
+// gateway started
+// GET /admin/rate-limits
+func (h *AdminHandler) ListRateLimits(c *gin.Context) {
+	counts := h.limiter.Metrics().Snapshot()
+
+	stats := make([]rateLimitStats, 0, len(counts))
+	seen := make(map[string]bool, len(counts))
+	for _, entry := range h.router.Table().Routes() {
+		if entry.RateLimit == nil {
+			continue
+		}
+		seen[entry.Name] = true
+		stats = append(stats, rateLimitStats{
+			Route:  entry.Name,
+			Policy: newRateLimitView(entry.RateLimit),
+			Counts: counts[entry.Name],
+		})
+	}
+	if h.bffRateLimit != nil {
+		for _, name := range bffRoutes {
+			seen[name] = true
+			stats = append(stats, rateLimitStats{
+				Route:  name,
+				Policy: newRateLimitView(h.bffRateLimit),
+				Counts: counts[name],
+			})
+		}
+	}
+	// Routes limited before a reload keep their counts
+	var removed []string
+	for name := range counts {
+		if !seen[name] {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	for _, name := range removed {
+		stats = append(stats, rateLimitStats{Route: name, Counts: counts[name]})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"backend": h.rateLimitBackend,
+			"routes":  stats,
+		},
+	})
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"jobfair-api-gateway/internal/auth"
+	"jobfair-api-gateway/internal/bff"
+	"jobfair-api-gateway/internal/ratelimit"
+	"jobfair-api-gateway/internal/routes"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jobfair/shared/identity"
+	"github.com/jobfair/shared/logging"
+	"github.com/jobfair/shared/metrics"
+)
+
+// List sections return bffDefaultLimit items unless ?limit= (up to
+// bffMaxLimit) asks for more
+const (
+	bffDefaultLimit = 5
+	bffMaxLimit     = 20
+)
+
+// Route names of the composite endpoints in metrics and rate limits
+const (
+	bffHomeRoute = "bff-home"
+	bffJobRoute  = "bff-job"
+)
+
+var bffRoutes = []string{bffHomeRoute, bffJobRoute}
+
+type BFFHandler struct {
+	client    *bff.Client
+	verifier  *auth.Verifier
+	router    *routes.Router
+	rateLimit *ratelimit.Policy
+}
+
+// NewBFFHandler creates the composite endpoint handlers. rateLimit applies to
+// each endpoint through the router's limiter; nil leaves them unlimited.
+func NewBFFHandler(client *bff.Client, verifier *auth.Verifier, router *routes.Router, rateLimit *ratelimit.Policy) *BFFHandler {
+	return &BFFHandler{client: client, verifier: verifier, router: router, rateLimit: rateLimit}
+}
+
+// compositeResponse holds every section of a composite endpoint; partial is
+// set when some of them failed
+type compositeResponse struct {
+	Success bool                    `json:"success"`
+	Partial bool                    `json:"partial"`
+	Data    map[string]*bff.Section `json:"data"`
+}
+
+// Home returns what the app shows on startup for the logged-in user, fetched
+// concurrently: the account (me) and, for job seekers, the profile with its
+// relations, the latest applications and saved jobs; for companies, the
+// company and its latest jobs. Sections whose service is down carry an
+// error; the response is 502 only when every section failed.
+// GET /api/v1/bff/home
+func (h *BFFHandler) Home(c *gin.Context) {
+	c.Set(metrics.ContextKeyRoute, bffHomeRoute)
+
+	caller, ok := h.authenticate(c, bffHomeRoute, true)
+	if !ok {
+		return
+	}
+
+	list := url.Values{"limit": {strconv.Itoa(listLimit(c))}}
+	requests := []bff.Request{
+		{Section: "me", Upstream: "auth", Path: "/api/v1/me"},
+	}
+	switch caller.Identity.UserType {
+	case "job_seeker":
+		requests = append(requests,
+			bff.Request{Section: "profile", Upstream: "profile", Path: "/api/v1/profiles/full"},
+			bff.Request{Section: "applications", Upstream: "job", Path: "/api/v1/applications/my", Query: list},
+			bff.Request{Section: "saved_jobs", Upstream: "job", Path: "/api/v1/jobs/saved", Query: list},
+		)
+	case "company":
+		requests = append(requests,
+			bff.Request{Section: "company", Upstream: "company", Path: "/api/v1/my-company"},
+			bff.Request{Section: "jobs", Upstream: "job", Path: "/api/v1/jobs/my", Query: list},
+		)
+	}
+
+	sections := h.client.Fetch(c.Request.Context(), caller, requests...)
+
+	code := http.StatusOK
+	if failedSections(sections) == len(sections) {
+		code = http.StatusBadGateway
+	}
+	respondComposite(c, code, sections)
+}
+
+// Job returns everything the job detail screen shows: the job (with is_saved
+// and has_applied for logged-in users) and, for job seekers, their profile
+// completion; then, once the job's company is known, the company and its
+// other open jobs. Without the job the response takes the job section's
+// status (e.g. 404); other failed sections only carry an error.
+// GET /api/v1/bff/job/:id
+func (h *BFFHandler) Job(c *gin.Context) {
+	c.Set(metrics.ContextKeyRoute, bffJobRoute)
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"message": "Invalid job ID",
+		})
+		return
+	}
+
+	caller, ok := h.authenticate(c, bffJobRoute, false)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	jobPath := "/api/v1/jobs/" + strconv.FormatUint(id, 10)
+
+	// Visits from a promoted slot count as clicks on the promotion
+	var jobQuery url.Values
+	if promotionID := c.Query("promotion_id"); promotionID != "" {
+		jobQuery = url.Values{"promotion_id": {promotionID}}
+	}
+	requests := []bff.Request{
+		{Section: "job", Upstream: "job", Path: jobPath, Query: jobQuery},
+	}
+	if caller != nil && caller.Identity.UserType == "job_seeker" {
+		requests = append(requests, bff.Request{Section: "profile_completion", Upstream: "profile", Path: "/api/v1/profiles/completion"})
+	}
+	sections := h.client.Fetch(ctx, caller, requests...)
+
+	job := sections["job"]
+	if !job.OK() {
+		respondComposite(c, job.Code(), sections)
+		return
+	}
+
+	var detail struct {
+		Job struct {
+			CompanyID uint `json:"company_id"`
+		} `json:"job"`
+	}
+	if err := json.Unmarshal(job.Data, &detail); err == nil && detail.Job.CompanyID != 0 {
+		limit := listLimit(c)
+		companyID := strconv.FormatUint(uint64(detail.Job.CompanyID), 10)
+		more := h.client.Fetch(ctx, caller,
+			// Shown alongside the job, not a visit to the company's profile
+			bff.Request{Section: "company", Upstream: "company", Path: "/api/v1/companies/" + companyID, SkipAnalytics: true},
+			// One extra, as the job itself is usually among them
+			bff.Request{Section: "company_jobs", Upstream: "job", Path: "/api/v1/jobs", Query: url.Values{
+				"company_id": {companyID},
+				"limit":      {strconv.Itoa(limit + 1)},
+			}},
+		)
+		if jobs := more["company_jobs"]; jobs.OK() {
+			jobs.Data = withoutJob(jobs.Data, uint(id), limit)
+		}
+		for name, section := range more {
+			sections[name] = section
+		}
+	}
+
+	respondComposite(c, http.StatusOK, sections)
+}
+
+// authenticate verifies the access token like the route table's required
+// (or optional) auth policy, then applies the endpoint's rate limit. It
+// returns the caller (nil for anonymous requests to optional endpoints) and
+// writes a 401 or 429 and returns false when the request is rejected.
+func (h *BFFHandler) authenticate(c *gin.Context, route string, required bool) (*bff.Caller, bool) {
+	claims, err := h.verifier.Verify(c.Request)
+	if errors.Is(err, auth.ErrMissingToken) && !required {
+		return nil, h.router.Limit(c, route, h.rateLimit, nil)
+	}
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return nil, false
+	}
+
+	// Log records of the request carry the user ID
+	c.Set("user_id", claims.UserID)
+	c.Request = c.Request.WithContext(logging.WithUserID(c.Request.Context(), strconv.FormatUint(uint64(claims.UserID), 10)))
+
+	if !h.router.Limit(c, route, h.rateLimit, claims) {
+		return nil, false
+	}
+
+	return &bff.Caller{
+		Identity:      identity.Identity{UserID: claims.UserID, UserType: claims.UserType},
+		Authorization: c.GetHeader("Authorization"),
+	}, true
+}
+
+func respondComposite(c *gin.Context, code int, sections map[string]*bff.Section) {
+	c.JSON(code, compositeResponse{
+		Success: code >= 200 && code <= 299,
+		Partial: failedSections(sections) > 0,
+		Data:    sections,
+	})
+}
+
+func failedSections(sections map[string]*bff.Section) int {
+	n := 0
+	for _, section := range sections {
+		if !section.OK() {
+			n++
+		}
+	}
+	return n
+}
+
+// listLimit is the number of items list sections return
+func listLimit(c *gin.Context) int {
+	limit, err := strconv.Atoi(c.Query("limit"))
+	switch {
+	case err != nil || limit < 1:
+		return bffDefaultLimit
+	case limit > bffMaxLimit:
+		return bffMaxLimit
+	}
+	return limit
+}
+
+// withoutJob drops the job with the ID from a job list and keeps at most
+// limit jobs. Lists it can't parse are returned as they are.
+func withoutJob(data json.RawMessage, id uint, limit int) json.RawMessage {
+	var jobs []json.RawMessage
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return data
+	}
+
+	kept := make([]json.RawMessage, 0, limit)
+	for _, raw := range jobs {
+		var job struct {
+			ID uint `json:"id"`
+		}
+		if json.Unmarshal(raw, &job) == nil && job.ID == id {
+			continue
+		}
+		if len(kept) < limit {
+			kept = append(kept, raw)
+		}
+	}
+
+	out, err := json.Marshal(kept)
+	if err != nil {
+		return data
+	}
+	return out
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"jobfair-api-gateway/internal/routes"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jobfair/shared/health"
+	"github.com/jobfair/shared/httpclient"
+)
+
+// readinessPath is polled on every upstream
+const readinessPath = "/readyz"
+
+// maxReadinessBody bounds the readiness report read from an upstream
+const maxReadinessBody = 64 << 10
+
+type HealthHandler struct {
+	router    *routes.Router
+	transport *httpclient.Transport
+	checker   *health.Checker
+	client    *http.Client
+}
+
+// NewHealthHandler reports on the gateway's own checks and on the upstreams
+// of router. Upstreams are polled directly, not through transport, so health
+// checks don't trip circuit breakers; transport only provides their states.
+func NewHealthHandler(router *routes.Router, transport *httpclient.Transport, checker *health.Checker) *HealthHandler {
+	return &HealthHandler{
+		router:    router,
+		transport: transport,
+		checker:   checker,
+		client:    &http.Client{},
+	}
+}
+
+// upstreamHealth is an upstream's readiness as polled by the gateway
+type upstreamHealth struct {
+	Status    string                   `json:"status"`
+	URL       string                   `json:"url"`
+	Circuit   httpclient.State         `json:"circuit"`
+	LatencyMS float64                  `json:"latency_ms"`
+	Error     string                   `json:"error,omitempty"`
+	Checks    map[string]health.Result `json:"checks,omitempty"`
+}
+
+// Health polls the readiness of every upstream concurrently and reports
+// each one's status, latency and dependency checks next to the gateway's own
+// checks. The gateway is down (503) when its own checks fail or every
+// upstream is down, degraded while some upstream isn't up.
+// GET /health
+func (h *HealthHandler) Health(c *gin.Context) {
+	ctx := c.Request.Context()
+	upstreams := h.router.Table().Upstreams()
+	states := h.transport.States()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		services = make(map[string]upstreamHealth, len(upstreams))
+	)
+	for name, target := range upstreams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := h.poll(ctx, target)
+			result.Circuit = httpclient.StateClosed
+			if state, ok := states[target.Host]; ok {
+				result.Circuit = state
+			}
+
+			mu.Lock()
+			services[name] = result
+			mu.Unlock()
+		}()
+	}
+	own := h.checker.Run(ctx)
+	wg.Wait()
+
+	reachable := 0
+	status := own.Status
+	for _, service := range services {
+		if service.Status != health.StatusDown {
+			reachable++
+		}
+		if service.Status != health.StatusUp && status == health.StatusUp {
+			status = health.StatusDegraded
+		}
+	}
+	if reachable == 0 && len(services) > 0 {
+		status = health.StatusDown
+	}
+
+	code := http.StatusOK
+	if status == health.StatusDown {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, gin.H{
+		"status":    status,
+		"service":   own.Service,
+		"timestamp": own.Timestamp,
+		"checks":    own.Checks,
+		"services":  services,
+	})
+}
+
+// poll fetches an upstream's readiness report
+func (h *HealthHandler) poll(ctx context.Context, target *url.URL) upstreamHealth {
+	endpoint := target.JoinPath(readinessPath).String()
+	result := upstreamHealth{Status: health.StatusDown, URL: endpoint}
+
+	ctx, cancel := context.WithTimeout(ctx, health.DefaultTimeout)
+	defer cancel()
+
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	resp, err := h.client.Do(req)
+	result.LatencyMS = health.Milliseconds(time.Since(start))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+
+	var report health.Report
+	decodeErr := json.NewDecoder(io.LimitReader(resp.Body, maxReadinessBody)).Decode(&report)
+	switch {
+	case decodeErr == nil && report.Status != "":
+		result.Status = report.Status
+		result.Checks = report.Checks
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		result.Status = health.StatusUp
+	default:
+		result.Error = fmt.Sprintf("GET %s: %s", readinessPath, resp.Status)
+	}
+	return result
+}
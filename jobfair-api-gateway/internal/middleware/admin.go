@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminToken guards gateway admin endpoints with the X-Gateway-Admin-Token
+// header. With an empty token the endpoints are disabled.
+func AdminToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"message": "Route not found",
+				"path":    c.Request.URL.Path,
+			})
+			return
+		}
+
+		provided := c.GetHeader("X-Gateway-Admin-Token")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"message": "Invalid admin token",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
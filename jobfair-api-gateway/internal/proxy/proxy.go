@@ -0,0 +1,55 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"github.com/jobfair/shared/httpclient"
+)
+
+// New creates a reverse proxy to an upstream service sending requests through
+// transport. Requests keep their path; the Host header is set to the
+// upstream's.
+func New(serviceName string, target *url.URL, transport http.RoundTripper) *httputil.ReverseProxy {
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.Transport = transport
+
+	// Custom director to modify the request
+	originalDirector := proxy.Director
+	proxy.Director = func(req *http.Request) {
+		originalDirector(req)
+		req.Header.Set("X-Forwarded-Host", req.Header.Get("Host"))
+		req.Header.Set("X-Origin-Host", target.Host)
+		req.Host = target.Host
+	}
+
+	// Error handler. The error is only logged (with the request's ID, which
+	// the client gets in X-Request-ID); clients get a generic message.
+	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		slog.ErrorContext(r.Context(), "Proxy error", "upstream", serviceName, "error", err)
+
+		status, message := http.StatusBadGateway, "Service temporarily unavailable"
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			status, message = http.StatusGatewayTimeout, "Service timed out"
+		case errors.Is(err, httpclient.ErrCircuitOpen):
+			// Failing fast while the upstream recovers
+			status = http.StatusServiceUnavailable
+			w.Header().Set("Retry-After", "5")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"message": message,
+		})
+	}
+
+	return proxy
+}
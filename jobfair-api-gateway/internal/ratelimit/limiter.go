@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Key is what requests are counted by
+type Key string
+
+const (
+	KeyIP     Key = "ip"      // Client IP
+	KeyUser   Key = "user"    // Authenticated user; anonymous requests fall back to the IP
+	KeyAPIKey Key = "api_key" // X-API-Key header; requests without a configured key fall back to the IP
+)
+
+// APIKeyHeader carries the API key requests are counted by with KeyAPIKey
+const APIKeyHeader = "X-API-Key"
+
+// Policy is a route's token bucket: Requests tokens are added every Per, up
+// to Burst, and every request takes one
+type Policy struct {
+	Requests int           `yaml:"requests"`
+	Per      time.Duration `yaml:"per"`
+	Burst    int           `yaml:"burst"` // Defaults to Requests
+	Key      Key           `yaml:"key"`   // Defaults to ip
+}
+
+// Validate checks the policy and fills in defaults
+func (p *Policy) Validate() error {
+	if p.Burst == 0 {
+		p.Burst = p.Requests
+	}
+	if p.Key == "" {
+		p.Key = KeyIP
+	}
+
+	var errs []error
+	if p.Requests < 1 {
+		errs = append(errs, errors.New("requests must be at least 1"))
+	}
+	if p.Per <= 0 {
+		errs = append(errs, errors.New("per must be positive"))
+	}
+	if p.Burst < 1 {
+		errs = append(errs, errors.New("burst must be at least 1"))
+	}
+	switch p.Key {
+	case KeyIP, KeyUser, KeyAPIKey:
+	default:
+		errs = append(errs, fmt.Errorf("key must be %s, %s or %s", KeyIP, KeyUser, KeyAPIKey))
+	}
+	return errors.Join(errs...)
+}
+
+// Rate returns the tokens added per second
+func (p *Policy) Rate() float64 {
+	return float64(p.Requests) / p.Per.Seconds()
+}
+
+// String describes the policy in the RateLimit-Policy header format
+func (p *Policy) String() string {
+	return fmt.Sprintf("%d;w=%d;burst=%d", p.Requests, int(math.Ceil(p.Per.Seconds())), p.Burst)
+}
+
+// Result is the outcome of taking a token
+type Result struct {
+	Allowed    bool
+	Limit      int           // Bucket size
+	Remaining  int           // Whole tokens left
+	Reset      time.Duration // Until the bucket is full again
+	RetryAfter time.Duration // Until the next token, when not allowed
+}
+
+// Store keeps token buckets
+type Store interface {
+	// Take takes a token from the bucket under key at time now
+	Take(ctx context.Context, key string, policy *Policy, now time.Time) (Result, error)
+}
+
+// result builds the outcome for a bucket left with tokens
+func result(policy *Policy, tokens float64, allowed bool) Result {
+	rate := policy.Rate()
+	res := Result{
+		Allowed:   allowed,
+		Limit:     policy.Burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(policy.Burst) - tokens) / rate),
+	}
+	if !allowed {
+		res.RetryAfter = seconds((1 - tokens) / rate)
+	}
+	return res
+}
+
+func seconds(s float64) time.Duration {
+	if s <= 0 {
+		return 0
+	}
+	return time.Duration(s * float64(time.Second))
+}
+
+// Limiter applies route policies using a store and records their outcomes
+type Limiter struct {
+	store   Store
+	metrics *Metrics
+}
+
+// NewLimiter creates a limiter backed by store
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{store: store, metrics: NewMetrics()}
+}
+
+// Metrics returns the limiter's counters
+func (l *Limiter) Metrics() *Metrics {
+	return l.metrics
+}
+
+// Allow takes a token for the client identified by key on the route. When the
+// store fails the request is allowed and the error returned.
+func (l *Limiter) Allow(ctx context.Context, route string, policy *Policy, key string) (Result, error) {
+	res, err := l.store.Take(ctx, route+":"+key, policy, time.Now())
+	if err != nil {
+		l.metrics.record(route, outcomeError)
+		return Result{Allowed: true, Limit: policy.Burst}, err
+	}
+
+	if res.Allowed {
+		l.metrics.record(route, outcomeAllowed)
+	} else {
+		l.metrics.record(route, outcomeRejected)
+	}
+	return res, nil
+}
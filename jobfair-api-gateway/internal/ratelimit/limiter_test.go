@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// testStore runs the token bucket behaviour every Store must share
+func testStore(t *testing.T, store Store) {
+	ctx := context.Background()
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := &Policy{Requests: 2, Per: time.Second, Burst: 3, Key: KeyIP}
+
+	t.Run("Burst", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			res, err := store.Take(ctx, "burst", policy, start)
+			if err != nil {
+				t.Fatalf("Take: %v", err)
+			}
+			if !res.Allowed || res.Remaining != 2-i {
+				t.Errorf("take %d = %+v, want allowed with %d remaining", i+1, res, 2-i)
+			}
+		}
+
+		res, err := store.Take(ctx, "burst", policy, start)
+		if err != nil {
+			t.Fatalf("Take: %v", err)
+		}
+		if res.Allowed {
+			t.Fatal("take beyond the burst was allowed")
+		}
+		if res.Limit != 3 || res.Remaining != 0 {
+			t.Errorf("rejected result = %+v, want limit 3 and nothing remaining", res)
+		}
+		if res.RetryAfter != 500*time.Millisecond {
+			t.Errorf("RetryAfter = %v, want 500ms", res.RetryAfter)
+		}
+		if res.Reset != 1500*time.Millisecond {
+			t.Errorf("Reset = %v, want 1.5s", res.Reset)
+		}
+	})
+
+	t.Run("Refill", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			store.Take(ctx, "refill", policy, start)
+		}
+
+		if res, _ := store.Take(ctx, "refill", policy, start.Add(250*time.Millisecond)); res.Allowed {
+			t.Error("take before a token was earned was allowed")
+		}
+		if res, _ := store.Take(ctx, "refill", policy, start.Add(500*time.Millisecond)); !res.Allowed {
+			t.Error("take after a token was earned was rejected")
+		}
+
+		res, err := store.Take(ctx, "refill", policy, start.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("Take: %v", err)
+		}
+		if !res.Allowed || res.Remaining != 2 {
+			t.Errorf("take after an idle hour = %+v, want the bucket capped at the burst", res)
+		}
+	})
+
+	t.Run("SeparateKeys", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			store.Take(ctx, "client-a", policy, start)
+		}
+		if res, _ := store.Take(ctx, "client-a", policy, start); res.Allowed {
+			t.Error("client-a was allowed beyond its burst")
+		}
+		if res, _ := store.Take(ctx, "client-b", policy, start); !res.Allowed || res.Remaining != 2 {
+			t.Errorf("client-b = %+v, want its own full bucket", res)
+		}
+	})
+}
+
+// failingStore fails every Take
+type failingStore struct{}
+
+var errStoreDown = errors.New("store down")
+
+func (failingStore) Take(ctx context.Context, key string, policy *Policy, now time.Time) (Result, error) {
+	return Result{}, errStoreDown
+}
+
+func TestLimiterCountsOutcomes(t *testing.T) {
+	limiter := NewLimiter(NewMemoryStore())
+	policy := &Policy{Requests: 1, Per: time.Minute, Burst: 1, Key: KeyIP}
+
+	for i := 0; i < 3; i++ {
+		if _, err := limiter.Allow(context.Background(), "jobs", policy, "10.0.0.1"); err != nil {
+			t.Fatalf("Allow: %v", err)
+		}
+	}
+	// Routes have their own buckets for the same client
+	if res, _ := limiter.Allow(context.Background(), "companies", policy, "10.0.0.1"); !res.Allowed {
+		t.Error("another route shared the client's bucket")
+	}
+
+	counts := limiter.Metrics().Snapshot()
+	if got := counts["jobs"]; got.Allowed != 1 || got.Rejected != 2 {
+		t.Errorf("jobs counts = %+v, want 1 allowed and 2 rejected", got)
+	}
+	if got := counts["companies"]; got.Allowed != 1 {
+		t.Errorf("companies counts = %+v, want 1 allowed", got)
+	}
+}
+
+func TestLimiterAllowsWhenStoreFails(t *testing.T) {
+	limiter := NewLimiter(failingStore{})
+	policy := &Policy{Requests: 1, Per: time.Minute, Burst: 5, Key: KeyIP}
+
+	res, err := limiter.Allow(context.Background(), "jobs", policy, "10.0.0.1")
+	if !errors.Is(err, errStoreDown) {
+		t.Errorf("err = %v, want the store's error", err)
+	}
+	if !res.Allowed || res.Limit != 5 {
+		t.Errorf("result = %+v, want allowed with the policy's limit", res)
+	}
+	if got := limiter.Metrics().Snapshot()["jobs"]; got.Errors != 1 {
+		t.Errorf("counts = %+v, want 1 error", got)
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	policy := &Policy{Requests: 10, Per: time.Minute}
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if policy.Burst != 10 || policy.Key != KeyIP {
+		t.Errorf("defaults = burst %d key %q, want burst 10 key ip", policy.Burst, policy.Key)
+	}
+	if got := policy.String(); got != "10;w=60;burst=10" {
+		t.Errorf("String = %q", got)
+	}
+
+	invalid := &Policy{Requests: 0, Per: 0, Key: "header"}
+	if err := invalid.Validate(); err == nil {
+		t.Error("Validate accepted a policy without requests, period or a known key")
+	}
+}
@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestMemoryStoreResetsOnPolicyChange(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Now()
+	strict := &Policy{Requests: 1, Per: time.Minute, Burst: 1, Key: KeyIP}
+	relaxed := &Policy{Requests: 10, Per: time.Minute, Burst: 10, Key: KeyIP}
+
+	store.Take(context.Background(), "client", strict, now)
+	if res, _ := store.Take(context.Background(), "client", strict, now); res.Allowed {
+		t.Fatal("take beyond the strict burst was allowed")
+	}
+
+	res, _ := store.Take(context.Background(), "client", relaxed, now)
+	if !res.Allowed || res.Remaining != 9 {
+		t.Errorf("take after a reload = %+v, want a fresh bucket of the new policy", res)
+	}
+}
+
+func TestMemoryStoreSweepsFullBuckets(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Now()
+	policy := &Policy{Requests: 1, Per: time.Second, Burst: 1, Key: KeyIP}
+
+	store.Take(context.Background(), "idle", policy, now)
+	store.Take(context.Background(), "busy", policy, now.Add(sweepInterval))
+	if _, ok := store.buckets["idle"]; ok {
+		t.Error("refilled bucket was kept after the sweep")
+	}
+	if _, ok := store.buckets["busy"]; !ok {
+		t.Error("bucket in use was swept")
+	}
+}
@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/jobfair/shared/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type outcome int
+
+const (
+	outcomeAllowed outcome = iota
+	outcomeRejected
+	outcomeError
+)
+
+// Counts are the outcomes of a route's rate limit checks
+type Counts struct {
+	Allowed  uint64 `json:"allowed"`
+	Rejected uint64 `json:"rejected"`
+	Errors   uint64 `json:"errors"` // Store failures; the requests were allowed
+}
+
+type counters struct {
+	allowed  atomic.Uint64
+	rejected atomic.Uint64
+	errors   atomic.Uint64
+}
+
+// Metrics counts rate limit outcomes per route since the gateway started.
+// It is a prometheus.Collector exporting the counts as
+// jobfair_gateway_rate_limit_checks_total.
+type Metrics struct {
+	routes sync.Map // route name -> *counters
+}
+
+var checksDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(metrics.Namespace, "gateway", "rate_limit_checks_total"),
+	"Rate limit checks by route and result (allowed, rejected, error).",
+	[]string{"route", "result"}, nil,
+)
+
+// NewMetrics creates empty counters
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+func (m *Metrics) record(route string, o outcome) {
+	value, ok := m.routes.Load(route)
+	if !ok {
+		value, _ = m.routes.LoadOrStore(route, &counters{})
+	}
+	c := value.(*counters)
+
+	switch o {
+	case outcomeAllowed:
+		c.allowed.Add(1)
+	case outcomeRejected:
+		c.rejected.Add(1)
+	case outcomeError:
+		c.errors.Add(1)
+	}
+}
+
+// Snapshot returns the current counts by route name
+func (m *Metrics) Snapshot() map[string]Counts {
+	snapshot := make(map[string]Counts)
+	m.routes.Range(func(key, value any) bool {
+		c := value.(*counters)
+		snapshot[key.(string)] = Counts{
+			Allowed:  c.allowed.Load(),
+			Rejected: c.rejected.Load(),
+			Errors:   c.errors.Load(),
+		}
+		return true
+	})
+	return snapshot
+}
+
+// Describe implements prometheus.Collector
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- checksDesc
+}
+
+// Collect implements prometheus.Collector
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	for route, counts := range m.Snapshot() {
+		ch <- prometheus.MustNewConstMetric(checksDesc, prometheus.CounterValue, float64(counts.Allowed), route, "allowed")
+		ch <- prometheus.MustNewConstMetric(checksDesc, prometheus.CounterValue, float64(counts.Rejected), route, "rejected")
+		ch <- prometheus.MustNewConstMetric(checksDesc, prometheus.CounterValue, float64(counts.Errors), route, "error")
+	}
+}
@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix namespaces the gateway's buckets in Redis
+const redisKeyPrefix = "gateway:ratelimit:"
+
+// takeScript refills and takes from a bucket atomically. A bucket is a hash
+// of its tokens and the time they were counted (ms); it expires once full.
+//
+// KEYS[1] bucket, ARGV[1] tokens per ms, ARGV[2] burst, ARGV[3] now (ms)
+// Returns {allowed, tokens left}
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = burst
+  ts = now
+end
+if now > ts then
+  tokens = tokens + (now - ts) * rate
+  ts = now
+end
+tokens = math.min(burst, tokens)
+
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(ts))
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore keeps buckets in Redis so every gateway replica shares them
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore creates a store using client
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// NewRedisStoreFromURL connects to the Redis server at url
+// (redis://[:password@]host:port/db) and checks it responds
+func NewRedisStoreFromURL(ctx context.Context, url string) (*RedisStore, error) {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("parse redis url: %w", err)
+	}
+	// Every rate limited request waits on Redis, so fail fast when it's down
+	// unless the URL sets its own timeouts
+	if options.DialTimeout == 0 {
+		options.DialTimeout = time.Second
+	}
+	if options.ReadTimeout == 0 {
+		options.ReadTimeout = 250 * time.Millisecond
+	}
+	if options.WriteTimeout == 0 {
+		options.WriteTimeout = 250 * time.Millisecond
+	}
+	client := redis.NewClient(options)
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("connect to redis: %w", err)
+	}
+	return NewRedisStore(client), nil
+}
+
+// Take takes a token from the bucket under key
+func (s *RedisStore) Take(ctx context.Context, key string, policy *Policy, now time.Time) (Result, error) {
+	ratePerMs := policy.Rate() / 1000
+	values, err := takeScript.Run(ctx, s.client, []string{redisKeyPrefix + key},
+		strconv.FormatFloat(ratePerMs, 'g', -1, 64), policy.Burst, now.UnixMilli()).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(values) != 2 {
+		return Result{}, fmt.Errorf("unexpected rate limit script reply %v", values)
+	}
+
+	allowed, _ := values[0].(int64)
+	left, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(left, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("unexpected rate limit script reply %v", values)
+	}
+	return result(policy, tokens, allowed == 1), nil
+}
+
+// Ping checks that Redis responds
+func (s *RedisStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
+
+// Close closes the Redis connection
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedisStore(t *testing.T) (*RedisStore, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	store := NewRedisStore(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+	t.Cleanup(func() { store.Close() })
+	return store, server
+}
+
+func TestRedisStore(t *testing.T) {
+	store, _ := newTestRedisStore(t)
+	testStore(t, store)
+}
+
+func TestRedisStoreExpiresBuckets(t *testing.T) {
+	store, server := newTestRedisStore(t)
+	policy := &Policy{Requests: 2, Per: time.Second, Burst: 3, Key: KeyIP}
+
+	if _, err := store.Take(context.Background(), "client", policy, time.Now()); err != nil {
+		t.Fatalf("Take: %v", err)
+	}
+
+	// One token taken refills in 500ms; the bucket lives a second longer
+	key := redisKeyPrefix + "client"
+	if ttl := server.TTL(key); ttl != 1500*time.Millisecond {
+		t.Errorf("bucket TTL = %v, want 1.5s", ttl)
+	}
+	server.FastForward(2 * time.Second)
+	if server.Exists(key) {
+		t.Error("full bucket was not expired")
+	}
+}
+
+func TestRedisStoreFromURL(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	store, err := NewRedisStoreFromURL(context.Background(), "redis://"+server.Addr()+"/0")
+	if err != nil {
+		t.Fatalf("NewRedisStoreFromURL: %v", err)
+	}
+	defer store.Close()
+	if err := store.Ping(context.Background()); err != nil {
+		t.Errorf("Ping: %v", err)
+	}
+
+	if _, err := NewRedisStoreFromURL(context.Background(), "localhost:6379"); err == nil {
+		t.Error("NewRedisStoreFromURL accepted a URL without a scheme")
+	}
+}
+
+func TestRedisStoreDown(t *testing.T) {
+	store, server := newTestRedisStore(t)
+	server.Close()
+
+	policy := &Policy{Requests: 1, Per: time.Second, Burst: 1, Key: KeyIP}
+	if _, err := store.Take(context.Background(), "client", policy, time.Now()); err == nil {
+		t.Error("Take succeeded with Redis down")
+	}
+}
@@ -0,0 +1,183 @@
+package routes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"jobfair-api-gateway/internal/config"
+	"jobfair-api-gateway/internal/ratelimit"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Auth is the authentication a route requires at the gateway
+type Auth string
+
+const (
+	AuthPublic   Auth = "public"   // Token not checked; forwarded as is
+	AuthOptional Auth = "optional" // Token verified when present
+	AuthRequired Auth = "required" // Valid token required
+)
+
+// DefaultTimeout applies to upstreams without a timeout
+const DefaultTimeout = 30 * time.Second
+
+// File is the route table file (configs/routes.yaml)
+type File struct {
+	Upstreams map[string]Upstream `yaml:"upstreams"`
+	Routes    []Route             `yaml:"routes"`
+}
+
+// Upstream is a service requests are proxied to
+type Upstream struct {
+	URL     string        `yaml:"url"`
+	Timeout time.Duration `yaml:"timeout"` // Default timeout of its routes
+}
+
+// Route sends requests whose path starts with Prefix to an upstream
+type Route struct {
+	Name      string            `yaml:"name"`
+	Prefix    string            `yaml:"prefix"`     // Matches the path itself and paths below it
+	Methods   []string          `yaml:"methods"`    // Empty matches every method
+	Upstream  string            `yaml:"upstream"`   // Key in upstreams
+	Rewrite   string            `yaml:"rewrite"`    // Replaces Prefix in the forwarded path; empty keeps the path
+	Auth      Auth              `yaml:"auth"`       // Defaults to public, or required with roles
+	Roles     []string          `yaml:"roles"`      // User types allowed; empty allows all
+	Timeout   time.Duration     `yaml:"timeout"`    // Overrides the upstream timeout
+	RateLimit *ratelimit.Policy `yaml:"rate_limit"` // Empty leaves the route unlimited
+}
+
+// Load reads and validates a route table file. ${VAR} and ${VAR:-default}
+// are replaced with environment variables; unknown keys are rejected.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(config.ExpandEnv(string(data)))))
+	decoder.KnownFields(true)
+
+	var file File
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if err := file.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+
+	return &file, nil
+}
+
+// Validate checks the file and fills in defaults. All problems are reported
+// together.
+func (f *File) Validate() error {
+	var errs []error
+
+	if len(f.Upstreams) == 0 {
+		errs = append(errs, errors.New("no upstreams defined"))
+	}
+	for name, upstream := range f.Upstreams {
+		target, err := url.Parse(upstream.URL)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			errs = append(errs, fmt.Errorf("upstream %q: url %q must be an absolute http(s) URL", name, upstream.URL))
+		} else if target.Path != "" && target.Path != "/" {
+			// Identity signatures cover the request path, which must reach the upstream unchanged
+			errs = append(errs, fmt.Errorf("upstream %q: url %q must not have a path, use rewrite on its routes", name, upstream.URL))
+		}
+		if upstream.Timeout < 0 {
+			errs = append(errs, fmt.Errorf("upstream %q: timeout must not be negative", name))
+		}
+	}
+
+	if len(f.Routes) == 0 {
+		errs = append(errs, errors.New("no routes defined"))
+	}
+	names := make(map[string]bool, len(f.Routes))
+	matches := make(map[string]string, len(f.Routes))
+	for i := range f.Routes {
+		route := &f.Routes[i]
+		if route.Name == "" {
+			route.Name = route.Prefix
+		}
+		if route.Auth == "" {
+			route.Auth = AuthPublic
+			if len(route.Roles) > 0 {
+				route.Auth = AuthRequired
+			}
+		}
+		label := fmt.Sprintf("route %d (%s)", i+1, route.Name)
+
+		if names[route.Name] {
+			errs = append(errs, fmt.Errorf("%s: duplicate name", label))
+		}
+		names[route.Name] = true
+
+		if !strings.HasPrefix(route.Prefix, "/") {
+			errs = append(errs, fmt.Errorf("%s: prefix %q must start with /", label, route.Prefix))
+		} else if route.Prefix != "/" {
+			route.Prefix = strings.TrimSuffix(route.Prefix, "/")
+		}
+		if route.Rewrite != "" && !strings.HasPrefix(route.Rewrite, "/") {
+			errs = append(errs, fmt.Errorf("%s: rewrite %q must start with /", label, route.Rewrite))
+		}
+		if _, ok := f.Upstreams[route.Upstream]; !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown upstream %q", label, route.Upstream))
+		}
+		switch route.Auth {
+		case AuthPublic, AuthOptional, AuthRequired:
+		default:
+			errs = append(errs, fmt.Errorf("%s: auth must be %s, %s or %s", label, AuthPublic, AuthOptional, AuthRequired))
+		}
+		if len(route.Roles) > 0 && route.Auth != AuthRequired {
+			errs = append(errs, fmt.Errorf("%s: roles need auth %s", label, AuthRequired))
+		}
+		if route.Timeout < 0 {
+			errs = append(errs, fmt.Errorf("%s: timeout must not be negative", label))
+		}
+		if route.RateLimit != nil {
+			if err := route.RateLimit.Validate(); err != nil {
+				errs = append(errs, fmt.Errorf("%s: rate_limit: %w", label, err))
+			} else if route.RateLimit.Key == ratelimit.KeyUser && route.Auth == AuthPublic {
+				errs = append(errs, fmt.Errorf("%s: rate_limit: key %s needs auth %s or %s", label, ratelimit.KeyUser, AuthOptional, AuthRequired))
+			}
+		}
+
+		methods := route.Methods
+		if len(methods) == 0 {
+			methods = []string{"*"}
+		}
+		for j, method := range methods {
+			method = strings.ToUpper(method)
+			if method != "*" && !isMethod(method) {
+				errs = append(errs, fmt.Errorf("%s: unknown method %q", label, method))
+			}
+			if len(route.Methods) > 0 {
+				route.Methods[j] = method
+			}
+
+			key := method + " " + route.Prefix
+			if other, ok := matches[key]; ok {
+				errs = append(errs, fmt.Errorf("%s: %s already routed by %s", label, key, other))
+			}
+			matches[key] = route.Name
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func isMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,258 @@
+package routes
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"jobfair-api-gateway/internal/auth"
+	"jobfair-api-gateway/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jobfair/shared/identity"
+	"github.com/jobfair/shared/logging"
+	"github.com/jobfair/shared/metrics"
+)
+
+// Options configure how the router authenticates, limits and proxies requests
+type Options struct {
+	Verifier       *auth.Verifier     // Checks bearer tokens on non-public routes
+	IdentitySecret string             // Signs identity headers; empty sends them unsigned
+	Limiter        *ratelimit.Limiter // Applies route rate limits; nil disables them
+	APIKeys        []string           // Keys api_key rate limits count by; other keys count by IP
+	Transport      http.RoundTripper  // Upstream connections, retries, circuit breakers and tracing
+}
+
+// Router proxies requests through the current route table and swaps in a
+// new table when the routes file changes
+type Router struct {
+	path    string
+	options Options
+	apiKeys map[string]bool // SHA-256 of the configured API keys, hex encoded
+	table   atomic.Pointer[Table]
+}
+
+// NewRouter loads the routes file. It fails if the file is invalid.
+func NewRouter(path string, options Options) (*Router, error) {
+	r := &Router{path: path, options: options, apiKeys: make(map[string]bool, len(options.APIKeys))}
+	for _, key := range options.APIKeys {
+		r.apiKeys[hashAPIKey(key)] = true
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Table returns the route table in use
+func (r *Router) Table() *Table {
+	return r.table.Load()
+}
+
+// Reload loads the routes file and swaps in the new table. An invalid file
+// leaves the current table in place.
+func (r *Router) Reload() error {
+	file, err := Load(r.path)
+	if err != nil {
+		return err
+	}
+	r.table.Store(Build(r.path, file, r.options.Transport))
+	return nil
+}
+
+// Watch checks the routes file every interval and reloads it when it changes,
+// until ctx is done
+func (r *Router) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := r.stat()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current := r.stat()
+		if current == last {
+			continue
+		}
+		last = current
+
+		if err := r.Reload(); err != nil {
+			slog.Error("Route table reload failed, keeping the previous routes", "file", r.path, "error", err)
+			continue
+		}
+		slog.Info("Route table reloaded", "file", r.path, "routes", len(r.Table().Routes()))
+	}
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func (r *Router) stat() fileState {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{modTime: info.ModTime(), size: info.Size()}
+}
+
+// Handle proxies the request to the upstream of its route. It is used as the
+// gateway's NoRoute handler, so gateway endpoints registered on the engine
+// take precedence.
+func (r *Router) Handle(c *gin.Context) {
+	entry := r.Table().Match(c.Request.Method, c.Request.URL.Path)
+	if entry == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"message": "Route not found",
+			"path":    c.Request.URL.Path,
+		})
+		return
+	}
+
+	// Requests are counted per route table entry rather than per path
+	c.Set(metrics.ContextKeyRoute, entry.Name)
+
+	// Identity headers only ever come from the gateway
+	identity.Strip(c.Request.Header)
+
+	claims, ok := r.authenticate(c, entry)
+	if !ok {
+		return
+	}
+	if claims != nil {
+		// Log records of the request carry the user ID
+		c.Set("user_id", claims.UserID)
+		c.Request = c.Request.WithContext(logging.WithUserID(c.Request.Context(), strconv.FormatUint(uint64(claims.UserID), 10)))
+	}
+	if !r.Limit(c, entry.Name, entry.RateLimit, claims) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), entry.Timeout)
+	defer cancel()
+
+	req := c.Request.WithContext(ctx)
+	if forwarded := entry.ForwardPath(req.URL.Path); forwarded != req.URL.Path {
+		target := *req.URL
+		target.Path = forwarded
+		target.RawPath = ""
+		req.URL = &target
+	}
+	if claims != nil {
+		identity.Set(req, r.options.IdentitySecret, identity.Identity{
+			UserID:   claims.UserID,
+			UserType: claims.UserType,
+		}, time.Now())
+	}
+
+	slog.DebugContext(ctx, "Proxying request", "path", c.Request.URL.Path, "upstream", entry.Upstream, "route", entry.Name)
+	entry.Handler.ServeHTTP(c.Writer, req)
+}
+
+// authenticate applies the route's auth policy. It returns the verified
+// claims (nil for anonymous requests) and writes the error response and
+// returns false when the request is rejected.
+func (r *Router) authenticate(c *gin.Context, entry *Entry) (*auth.Claims, bool) {
+	if entry.Auth == AuthPublic {
+		return nil, true
+	}
+
+	claims, err := r.options.Verifier.Verify(c.Request)
+	if errors.Is(err, auth.ErrMissingToken) && entry.Auth == AuthOptional {
+		return nil, true
+	}
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return nil, false
+	}
+
+	if !entry.AllowsRole(claims.UserType) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"message": "Insufficient permissions",
+		})
+		return nil, false
+	}
+
+	return claims, true
+}
+
+// Limit takes a token from the client's bucket of the route and sets the
+// RateLimit headers; a nil policy leaves the route unlimited. It writes a 429
+// response and returns false when the bucket is empty. Gateway endpoints
+// outside the route table (the BFF) call it with their own route name.
+func (r *Router) Limit(c *gin.Context, route string, policy *ratelimit.Policy, claims *auth.Claims) bool {
+	if policy == nil || r.options.Limiter == nil {
+		return true
+	}
+
+	res, err := r.options.Limiter.Allow(c.Request.Context(), route, policy, r.clientKey(c, policy.Key, claims))
+	if err != nil {
+		// Don't take the API down with the rate limit store
+		slog.WarnContext(c.Request.Context(), "Rate limit check failed, allowing request", "route", route, "error", err)
+		return true
+	}
+
+	header := c.Writer.Header()
+	header.Set("RateLimit-Policy", policy.String())
+	header.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	header.Set("RateLimit-Reset", ceilSeconds(res.Reset))
+	if res.Allowed {
+		return true
+	}
+
+	header.Set("Retry-After", ceilSeconds(res.RetryAfter))
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"success": false,
+		"message": "Too many requests, please try again later",
+	})
+	return false
+}
+
+// clientKey identifies the client a rate limit counts. Anonymous requests on
+// user limited routes and requests without a configured API key on API key
+// limited routes are counted by IP, so clients can't get fresh buckets by
+// sending made-up keys.
+func (r *Router) clientKey(c *gin.Context, key ratelimit.Key, claims *auth.Claims) string {
+	switch key {
+	case ratelimit.KeyUser:
+		if claims != nil {
+			return "user:" + strconv.FormatUint(uint64(claims.UserID), 10)
+		}
+	case ratelimit.KeyAPIKey:
+		if apiKey := c.GetHeader(ratelimit.APIKeyHeader); apiKey != "" {
+			// Keys are hashed so they don't end up in the rate limit store
+			if hash := hashAPIKey(apiKey); r.apiKeys[hash] {
+				return "api_key:" + hash
+			}
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,136 @@
+package routes
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"jobfair-api-gateway/internal/proxy"
+)
+
+// Table is a validated route table ready to serve requests. Tables are
+// immutable; a reload builds a new one.
+type Table struct {
+	Source    string
+	LoadedAt  time.Time
+	routes    []*Entry // Longest prefix first
+	upstreams map[string]*url.URL
+}
+
+// Entry is a route resolved against its upstream
+type Entry struct {
+	Route
+	Target  *url.URL
+	Timeout time.Duration
+	Handler http.Handler
+	methods map[string]bool
+	roles   map[string]bool
+}
+
+// Build resolves a validated file into a table whose proxies send requests
+// through transport
+func Build(source string, file *File, transport http.RoundTripper) *Table {
+	targets := make(map[string]*url.URL, len(file.Upstreams))
+	handlers := make(map[string]http.Handler, len(file.Upstreams))
+	for name, upstream := range file.Upstreams {
+		target, _ := url.Parse(upstream.URL) // Checked by Validate
+		targets[name] = target
+		handlers[name] = proxy.New(name, target, transport)
+	}
+
+	table := &Table{
+		Source:    source,
+		LoadedAt:  time.Now(),
+		routes:    make([]*Entry, 0, len(file.Routes)),
+		upstreams: targets,
+	}
+	for _, route := range file.Routes {
+		timeout := route.Timeout
+		if timeout == 0 {
+			timeout = file.Upstreams[route.Upstream].Timeout
+		}
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+
+		entry := &Entry{
+			Route:   route,
+			Target:  targets[route.Upstream],
+			Timeout: timeout,
+			Handler: handlers[route.Upstream],
+		}
+		if len(route.Methods) > 0 {
+			entry.methods = make(map[string]bool, len(route.Methods))
+			for _, method := range route.Methods {
+				entry.methods[method] = true
+			}
+		}
+		if len(route.Roles) > 0 {
+			entry.roles = make(map[string]bool, len(route.Roles))
+			for _, role := range route.Roles {
+				entry.roles[role] = true
+			}
+		}
+		table.routes = append(table.routes, entry)
+	}
+
+	// Most specific first: longer prefixes, then routes limited to methods
+	sort.SliceStable(table.routes, func(i, j int) bool {
+		a, b := table.routes[i], table.routes[j]
+		if len(a.Prefix) != len(b.Prefix) {
+			return len(a.Prefix) > len(b.Prefix)
+		}
+		return a.methods != nil && b.methods == nil
+	})
+
+	return table
+}
+
+// Match returns the most specific route for the request, or nil
+func (t *Table) Match(method, path string) *Entry {
+	for _, entry := range t.routes {
+		if entry.matchesPath(path) && (entry.methods == nil || entry.methods[method]) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// Routes returns the routes in match order
+func (t *Table) Routes() []*Entry {
+	return t.routes
+}
+
+// Upstreams returns the URL of every upstream by name
+func (t *Table) Upstreams() map[string]*url.URL {
+	return t.upstreams
+}
+
+// AllowsRole reports whether users of the type may use the route
+func (e *Entry) AllowsRole(userType string) bool {
+	return e.roles == nil || e.roles[userType]
+}
+
+// ForwardPath returns the path sent upstream for a matched request path
+func (e *Entry) ForwardPath(path string) string {
+	if e.Rewrite == "" {
+		return path
+	}
+	rest := strings.TrimPrefix(path, e.Prefix)
+	if e.Prefix == "/" {
+		rest = path
+	}
+	if forwarded := strings.TrimSuffix(e.Rewrite, "/") + rest; forwarded != "" {
+		return forwarded
+	}
+	return "/"
+}
+
+func (e *Entry) matchesPath(path string) bool {
+	if e.Prefix == "/" || path == e.Prefix {
+		return true
+	}
+	return strings.HasPrefix(path, e.Prefix) && path[len(e.Prefix)] == '/'
+}
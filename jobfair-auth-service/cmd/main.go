@@ -2,17 +2,27 @@
 package main
 
 import (
-	"log"
+	"context"
+	"log/slog"
 	"os"
+	"path/filepath"
 
 	"jobfair-auth-service/internal/config"
 	"jobfair-auth-service/internal/handlers"
 	"jobfair-auth-service/internal/middleware"
+	"jobfair-auth-service/internal/monitoring"
 	"jobfair-auth-service/internal/repository"
 	"jobfair-auth-service/internal/services"
 	"jobfair-auth-service/pkg/database"
 
 	"github.com/jobfair/shared/events" // Import shared events library
+	"github.com/jobfair/shared/health"
+	"github.com/jobfair/shared/identity"
+	"github.com/jobfair/shared/logging"
+	"github.com/jobfair/shared/metrics"
+	"github.com/jobfair/shared/storage"
+	"github.com/jobfair/shared/tracing"
+	"github.com/jobfair/shared/upload"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,9 +30,19 @@ import (
 func main() {
 	cfg := config.Load()
 
+	// JSON logs with request, trace and user IDs; LOG_LEVEL and LOG_FORMAT tune them
+	logging.Setup(cfg.Logging)
+
+	// Initialize tracing; OTEL_TRACES_EXPORTER selects where spans go
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		logging.Fatal("Failed to initialize tracing", "error", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	db, err := database.Connect(cfg.DatabaseURL)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		logging.Fatal("Failed to connect to database", "error", err)
 	}
 
 	defer func() {
@@ -38,10 +58,10 @@ func main() {
 
 	eventPublisher, err := events.NewPublisher(rabbitmqURL)
 	if err != nil {
-		log.Fatal("❌ Failed to connect to RabbitMQ:", err)
+		logging.Fatal("Failed to connect to RabbitMQ", "error", err)
 	}
 	defer eventPublisher.Close()
-	log.Println("✅ Event publisher initialized")
+	slog.Info("Event publisher initialized")
 
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db)
@@ -60,14 +80,40 @@ func main() {
 	)
 	authService := services.NewAuthService(userRepo, profileRepo, companyProfileRepo, cfg.JWTSecret)
 
+	// Initialize object storage for profile photos and company logos
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		logging.Fatal("Failed to initialize storage", "error", err)
+	}
+	slog.Info("Storage initialized", "backend", cfg.Storage.Backend)
+
+	// Uploads are content-checked, scanned and resized before they are stored
+	scanner, err := upload.NewScanner(cfg.Scanner)
+	if err != nil {
+		logging.Fatal("Failed to initialize upload scanner", "error", err)
+	}
+	uploads := upload.NewPipeline(store, scanner)
+	slog.Info("Upload scanner initialized", "scanner", cfg.Scanner.Scanner)
+
 	// Initialize handlers
-	registrationHandler := handlers.NewRegistrationHandler(registrationService)
+	registrationHandler := handlers.NewRegistrationHandler(registrationService, uploads)
 	authHandler := handlers.NewAuthHandler(authService)
 
 	// Initialize middleware
 	authMiddleware := middleware.JWTAuthMiddleware(cfg.JWTSecret)
 
-	router := gin.Default()
+	// Requests are logged by logging.Middleware
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	// Request IDs and a trace span for every request
+	router.Use(tracing.Middleware(cfg.Tracing.ServiceName)...)
+
+	// Request rate, errors and latency per route
+	router.Use(metrics.Middleware())
+
+	// Access log
+	router.Use(logging.Middleware())
 	
 	// Disable automatic trailing slash redirect to prevent 301 loops
 	router.RedirectTrailingSlash = false
@@ -85,17 +131,31 @@ func main() {
 		c.Next()
 	})
 
-	// Health check
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "healthy",
-			"service": "auth-service",
-			"version": "1.0.0",
-		})
-	})
+	// Trust identity headers set by the API gateway
+	router.Use(identity.Middleware(cfg.Identity))
 
-	// Static file serving for uploads
-	router.Static("/uploads", "./uploads")
+	sqlDB, err := db.DB()
+	if err != nil {
+		logging.Fatal("Failed to get database connection pool", "error", err)
+	}
+
+	// Prometheus metrics: connection pool stats and business gauges
+	metrics.RegisterDB("jobfair_auth", sqlDB)
+	monitoring.RegisterBusinessMetrics(userRepo)
+	metrics.Register(router)
+
+	// Liveness (/livez) and readiness (/readyz, /health) probes
+	health.NewChecker("auth-service").
+		Add("database", health.Database(sqlDB)).
+		Add("rabbitmq", eventPublisher.Check).
+		Add("storage", health.Storage(store)).
+		Register(router)
+
+	// Static file serving for uploads when stored on local disk
+	// (the S3 backend returns bucket URLs instead)
+	if local, ok := store.(*storage.LocalStore); ok {
+		router.Static(cfg.Storage.PublicURL+"/profiles", filepath.Join(local.Dir(), "profiles"))
+	}
 
 	api := router.Group("/api/v1")
 	{
@@ -132,9 +192,8 @@ func main() {
 		port = "8080"
 	}
 
-	log.Printf("🚀 Auth service starting on port %s", port)
-	log.Printf("📊 Health check: http://localhost:%s/health", port)
-	log.Printf("🔑 API endpoint: http://localhost:%s/api/v1", port)
-	log.Printf("📁 Static files: http://localhost:%s/uploads", port)
-	router.Run(":" + port)
+	slog.Info("Auth service starting", "port", port)
+	if err := router.Run(":" + port); err != nil {
+		logging.Fatal("Failed to start auth service", "error", err)
+	}
 }
@@ -3,22 +3,25 @@ package handlers
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
-	"path/filepath"
 
 	"jobfair-auth-service/internal/models"
 	"jobfair-auth-service/internal/services"
 	"jobfair-auth-service/internal/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jobfair/shared/storage"
+	"github.com/jobfair/shared/upload"
 )
 
 type RegistrationHandler struct {
 	registrationService *services.RegistrationService
+	uploads             *upload.Pipeline
 }
 
-func NewRegistrationHandler(registrationService *services.RegistrationService) *RegistrationHandler {
-	return &RegistrationHandler{registrationService: registrationService}
+func NewRegistrationHandler(registrationService *services.RegistrationService, uploads *upload.Pipeline) *RegistrationHandler {
+	return &RegistrationHandler{registrationService: registrationService, uploads: uploads}
 }
 
 func (h *RegistrationHandler) RegisterStep1(c *gin.Context) {
@@ -178,12 +181,6 @@ func (h *RegistrationHandler) UploadProfilePhoto(c *gin.Context) {
 		return
 	}
 
-	// Validate file (max 5MB)
-	if err := utils.ValidateImageFile(file, 5*1024*1024); err != nil {
-		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
-		return
-	}
-
 	// Get user to determine file naming
 	user, err := h.registrationService.GetUserByID(userID)
 	if err != nil {
@@ -192,36 +189,41 @@ func (h *RegistrationHandler) UploadProfilePhoto(c *gin.Context) {
 	}
 
 	// Generate filename
-	filename := utils.GenerateFileName(userID, file.Filename)
-	
-	// Determine directory based on user type
-	var relativeDir, relativePath string
+	filename := utils.GenerateFileName(userID)
+
+	// Determine storage key and upload kind based on user type
+	var key string
+	kind := upload.ProfilePhoto
 	if user.UserType == models.UserTypeCompany {
-		relativeDir = "uploads/companies/logos"
-		relativePath = filepath.Join(relativeDir, filename)
+		key = storage.Key("companies", "logos", filename)
+		kind = upload.CompanyLogo
 	} else {
-		relativeDir = "uploads/profiles"
-		relativePath = filepath.Join(relativeDir, filename)
+		key = storage.Key("profiles", filename)
 	}
 
-	// Save file to disk
-	fmt.Printf("[DEBUG] Saving file to: %s\n", relativePath)
-	if err := utils.SaveUploadedFile(file, relativePath); err != nil {
-		fmt.Printf("[ERROR] Failed to save file: %v\n", err)
+	// Validate (content type, size, malware scan), strip metadata and store with thumbnails
+	ctx := c.Request.Context()
+	slog.DebugContext(ctx, "Storing profile photo", "key", key)
+	result, err := h.uploads.ProcessFile(ctx, file, kind, key)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to store profile photo", "key", key, "error", err)
+		if upload.IsRejected(err) {
+			c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to save file: %v", err),
 		})
 		return
 	}
-	fmt.Printf("[DEBUG] File saved successfully: %s\n", relativePath)
+	slog.InfoContext(ctx, "Profile photo stored", "key", result.Key)
 
-	// Save path to database (dengan prefix /)
-	photoURL := "/" + filepath.ToSlash(relativePath)
-	data, err := h.registrationService.UploadProfilePhoto(userID, photoURL)
+	// Save URL to database
+	data, err := h.registrationService.UploadProfilePhoto(userID, result.URL)
 	if err != nil {
 		// Jika gagal save ke database, hapus file yang sudah diupload
-		utils.DeleteFile(relativePath)
+		h.uploads.Remove(ctx, result.Key, kind)
 		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
 		return
 	}
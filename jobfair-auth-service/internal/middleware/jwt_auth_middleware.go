@@ -6,11 +6,18 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/jobfair/shared/identity"
 )
 
 // JWTAuthMiddleware memverifikasi token JWT dan menyimpan user_id ke context
 func JWTAuthMiddleware(secret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Sudah diautentikasi oleh API gateway
+		if identity.Verified(c) {
+			c.Next()
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 
 		// Pastikan header Authorization ada
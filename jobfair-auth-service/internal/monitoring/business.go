@@ -0,0 +1,30 @@
+package monitoring
+
+import (
+	"time"
+
+	"jobfair-auth-service/internal/repository"
+
+	"github.com/jobfair/shared/metrics"
+)
+
+// refreshInterval bounds how often scrapes query the database
+const refreshInterval = 30 * time.Second
+
+// RegisterBusinessMetrics exports registration progress on /metrics:
+// jobfair_registrations{user_type,step}
+func RegisterBusinessMetrics(userRepo *repository.UserRepository) {
+	metrics.MustRegister(
+		metrics.NewQueryGauge("registrations", "Registered users by user type and the registration step they are at.", []string{"user_type", "step"}, refreshInterval, func() ([]metrics.Sample, error) {
+			counts, err := userRepo.CountByRegistrationStep()
+			if err != nil {
+				return nil, err
+			}
+			samples := make([]metrics.Sample, 0, len(counts))
+			for _, count := range counts {
+				samples = append(samples, metrics.Sample{Labels: []string{string(count.UserType), count.Step}, Value: float64(count.Count)})
+			}
+			return samples, nil
+		}),
+	)
+}
@@ -63,3 +63,36 @@ func (r *UserRepository) GetAll() ([]models.User, error) {
 	}
 	return users, nil
 }
+
+// Registration steps a user can be waiting at
+const (
+	RegistrationStepBasicProfile      = "basic_profile"      // Account created, basic profile not filled in
+	RegistrationStepPhoneVerification = "phone_verification" // Phone number given, not verified
+	RegistrationStepOnboarding        = "onboarding"         // Phone verified, profile not complete
+	RegistrationStepComplete          = "complete"
+)
+
+// RegistrationStepCount is the number of users of a type at a registration step
+type RegistrationStepCount struct {
+	UserType models.UserType
+	Step     string
+	Count    int64
+}
+
+// CountByRegistrationStep counts job seekers and companies by the
+// registration step they reached
+func (r *UserRepository) CountByRegistrationStep() ([]RegistrationStepCount, error) {
+	var results []RegistrationStepCount
+	err := r.db.Model(&models.User{}).
+		Select(`user_type, CASE
+			WHEN is_profile_complete THEN ?
+			WHEN is_phone_verified THEN ?
+			WHEN phone_number IS NOT NULL THEN ?
+			ELSE ? END AS step, COUNT(*) AS count`,
+			RegistrationStepComplete, RegistrationStepOnboarding,
+			RegistrationStepPhoneVerification, RegistrationStepBasicProfile).
+		Where("user_type <> ?", models.UserTypeAdmin).
+		Group("user_type, step").
+		Scan(&results).Error
+	return results, err
+}
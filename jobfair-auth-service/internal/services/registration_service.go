@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"math/rand"
 
 	// "os"
@@ -213,7 +214,7 @@ func (s *RegistrationService) SendPhoneOTP(userID uint, req *models.PhoneVerific
 		return nil, err
 	}
 
-	fmt.Printf("OTP for %s: %s\n", req.PhoneNumber, otpCode)
+	slog.Info("Phone OTP created", "user_id", userID, "phone", req.PhoneNumber, "otp", otpCode, "expires_at", otp.ExpiresAt)
 
 	return &models.OTPSentData{
 		PhoneNumber: req.PhoneNumber,
@@ -241,9 +242,7 @@ func (s *RegistrationService) VerifyPhoneOTP(req *models.VerifyOTPRequest) (*mod
 		// 🚀 Publish user.registered event for job seekers
 		if user.UserType == models.UserTypeJobSeeker {
 			if err := s.publishUserRegisteredEvent(user); err != nil {
-				fmt.Printf("⚠️ Warning: Failed to publish user registered event: %v\n", err)
-			} else {
-				fmt.Printf("✅ User registered event published for user_id: %d\n", user.ID)
+				slog.Warn("Failed to publish user registered event", "user_id", user.ID, "error", err)
 			}
 		}
 
@@ -305,9 +304,7 @@ func (s *RegistrationService) VerifyPhoneOTP(req *models.VerifyOTPRequest) (*mod
 	// 🚀 Publish user.registered event for job seekers
 	if user.UserType == models.UserTypeJobSeeker {
 		if err := s.publishUserRegisteredEvent(user); err != nil {
-			fmt.Printf("⚠️ Warning: Failed to publish user registered event: %v\n", err)
-		} else {
-			fmt.Printf("✅ User registered event published for user_id: %d\n", user.ID)
+			slog.Warn("Failed to publish user registered event", "user_id", user.ID, "error", err)
 		}
 	}
 
@@ -441,18 +438,14 @@ func (s *RegistrationService) UploadProfilePhoto(userID uint, photoURL string) (
 
 			// 🚀 PUBLISH EVENT INSTEAD OF HTTP CALL
 			if err := s.publishCompanyRegisteredEvent(user, companyProfile); err != nil {
-				fmt.Printf("⚠️ Warning: Failed to publish company registered event: %v\n", err)
+				slog.Warn("Failed to publish company registered event", "user_id", userID, "error", err)
 				// Don't fail the request, event will be retried by message broker
-			} else {
-				fmt.Printf("✅ Company registered event published for user_id: %d\n", userID)
 			}
 		}
 	} else if user.UserType == models.UserTypeJobSeeker {
 		// 🚀 Publish/Re-publish user registered event with photo URL for job seekers
 		if err := s.publishUserRegisteredEvent(user); err != nil {
-			fmt.Printf("⚠️ Warning: Failed to publish user profile photo update event: %v\n", err)
-		} else {
-			fmt.Printf("✅ User profile photo updated event published for user_id: %d\n", userID)
+			slog.Warn("Failed to publish user profile photo update event", "user_id", userID, "error", err)
 		}
 	}
 
@@ -2,17 +2,18 @@ package database
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
+	"github.com/jobfair/shared/logging/gormlogging"
+	"github.com/jobfair/shared/tracing/gormtracing"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 func Connect(databaseURL string) (*gorm.DB, error) {
 	config := &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: gormlogging.New(),
 	}
 
 	var db *gorm.DB
@@ -33,15 +34,20 @@ func Connect(databaseURL string) (*gorm.DB, error) {
 
 				// Test connection
 				if err := sqlDB.Ping(); err == nil {
-					log.Println("✅ Database connected successfully")
+					slog.Info("Database connected")
+
+					// Record statements of traced requests as spans
+					if err := db.Use(gormtracing.New()); err != nil {
+						return nil, fmt.Errorf("failed to install tracing plugin: %w", err)
+					}
 					return db, nil
 				}
 			}
 		}
 		
 		waitTime := time.Duration(i+1) * 2 * time.Second
-		log.Printf("⏳ Failed to connect to database (attempt %d/%d). Retrying in %v... Error: %v", 
-			i+1, maxRetries, waitTime, err)
+		slog.Warn("Failed to connect to database, retrying",
+			"attempt", i+1, "max_attempts", maxRetries, "retry_in", waitTime.String(), "error", err)
 		time.Sleep(waitTime)
 	}
 
@@ -1,9 +1,11 @@
 package main
 
 import (
-	"log"
+	"context"
+	"log/slog"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 
 	"jobfair-company-service/internal/config"
@@ -11,28 +13,48 @@ import (
 	"jobfair-company-service/internal/handlers"
 	"jobfair-company-service/internal/middleware"
 	"jobfair-company-service/internal/repository"
+	"jobfair-company-service/internal/scheduler"
 	"jobfair-company-service/internal/services"
 	"jobfair-company-service/pkg/database"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jobfair/shared/events"
+	"github.com/jobfair/shared/health"
+	"github.com/jobfair/shared/httpcache"
+	"github.com/jobfair/shared/identity"
+	"github.com/jobfair/shared/logging"
+	"github.com/jobfair/shared/metrics"
+	"github.com/jobfair/shared/storage"
+	"github.com/jobfair/shared/tracing"
+	"github.com/jobfair/shared/upload"
 )
 
 func main() {
 	cfg := config.Load()
 
+	// JSON logs with request, trace and user IDs; LOG_LEVEL and LOG_FORMAT tune them
+	logging.Setup(cfg.Logging)
+
+	// Initialize tracing; OTEL_TRACES_EXPORTER selects where spans go
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		logging.Fatal("Failed to initialize tracing", "error", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	db, err := database.Connect(cfg.DatabaseURL)
 	if err != nil {
-		log.Fatal("❌ Failed to connect to database:", err)
+		logging.Fatal("Failed to connect to database", "error", err)
 	}
 
 	defer func() {
 		sqlDB, err := db.DB()
 		if err != nil {
-			log.Printf("Error getting underlying SQL DB: %v", err)
+			slog.Error("Failed to get underlying SQL DB", "error", err)
 			return
 		}
 		if err := sqlDB.Close(); err != nil {
-			log.Printf("Error closing database connection: %v", err)
+			slog.Error("Failed to close database connection", "error", err)
 		}
 	}()
 
@@ -43,27 +65,119 @@ func main() {
 	}
 
 	companyRepo := repository.NewCompanyRepository(db)
+	mediaRepo := repository.NewCompanyMediaRepository(db)
+	verificationRepo := repository.NewVerificationRepository(db)
+	analyticsRepo := repository.NewAnalyticsRepository(db)
+	subscriptionRepo := repository.NewSubscriptionRepository(db)
 	
 	eventConsumer, err := consumers.NewCompanyEventConsumer(rabbitmqURL, companyRepo)
 	if err != nil {
-		log.Fatal("❌ Failed to create event consumer:", err)
+		logging.Fatal("Failed to create event consumer", "error", err)
 	}
 	defer eventConsumer.Close()
 
 	// Start consuming events in background
 	if err := eventConsumer.Start(); err != nil {
-		log.Fatal("❌ Failed to start event consumer:", err)
+		logging.Fatal("Failed to start event consumer", "error", err)
+	}
+	slog.Info("Event consumer started")
+
+	// Initialize event publisher (company verification results)
+	eventPublisher, err := events.NewPublisher(rabbitmqURL)
+	if err != nil {
+		slog.Warn("Failed to initialize event publisher; verification and subscription events will not be published", "error", err)
+		eventPublisher = nil
+	} else {
+		defer eventPublisher.Close()
+	}
+
+	// Initialize object storage for company media
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		logging.Fatal("Failed to initialize storage", "error", err)
 	}
-	log.Println("✅ Event consumer started")
+	slog.Info("Storage initialized", "backend", cfg.Storage.Backend)
+
+	// Uploads are content-checked, scanned and resized before they are stored
+	scanner, err := upload.NewScanner(cfg.Scanner)
+	if err != nil {
+		logging.Fatal("Failed to initialize upload scanner", "error", err)
+	}
+	uploads := upload.NewPipeline(store, scanner)
+	slog.Info("Upload scanner initialized", "scanner", cfg.Scanner.Scanner)
 
 	// Initialize services
-	companyService := services.NewCompanyService(companyRepo)
+	companyService := services.NewCompanyService(companyRepo, mediaRepo, uploads, eventPublisher)
+	verificationService := services.NewVerificationService(verificationRepo, companyRepo, store, uploads, eventPublisher)
+	analyticsService := services.NewAnalyticsService(analyticsRepo, companyRepo)
+
+	// Profile views are recorded by a single worker off the request path
+	analyticsService.Start()
+	defer analyticsService.Stop()
+	subscriptionService := services.NewSubscriptionService(subscriptionRepo, companyRepo, eventPublisher)
+
+	// Apply scheduled subscription changes to the companies' tier
+	subscriptionScheduler := scheduler.NewSubscriptionScheduler(subscriptionService, cfg.SubscriptionSyncInterval)
+	subscriptionScheduler.Start()
+	defer subscriptionScheduler.Stop()
+
+	// Analytics events (job views, saves, applies, hires) from job-service
+	analyticsConsumer, err := consumers.NewAnalyticsConsumer(rabbitmqURL, analyticsService)
+	if err != nil {
+		logging.Fatal("Failed to create analytics consumer", "error", err)
+	}
+	defer analyticsConsumer.Close()
+
+	if err := analyticsConsumer.Start(); err != nil {
+		logging.Fatal("Failed to start analytics consumer", "error", err)
+	}
+	slog.Info("Analytics consumer started")
+
+	// Public listings and profiles are kept until a company event invalidates
+	// them; HTTP_CACHE_BACKEND selects memory or Redis
+	responseCache, err := httpcache.New(context.Background(), cfg.HTTPCache)
+	if err != nil {
+		slog.Warn("Failed to initialize response cache; falling back to memory", "backend", cfg.HTTPCache.Backend, "error", err)
+		responseCache = httpcache.NewWithStore(httpcache.NewMemoryStore(cfg.HTTPCache.MaxEntries), cfg.HTTPCache.TTL)
+	}
+	cacheConsumer, err := consumers.NewCacheEventConsumer(rabbitmqURL, responseCache, companyRepo)
+	if err == nil {
+		if err = cacheConsumer.Start(); err != nil {
+			cacheConsumer.Close()
+			cacheConsumer = nil
+		}
+	}
+	if err != nil {
+		// Without invalidations stored responses would go stale, so only
+		// ETags and 304s are kept
+		slog.Warn("Failed to initialize response cache consumer; responses will not be stored", "error", err)
+		responseCache.Close()
+		responseCache = httpcache.NewWithStore(nil, cfg.HTTPCache.TTL)
+	} else {
+		defer cacheConsumer.Close()
+		slog.Info("Response cache consumer started")
+	}
+	defer responseCache.Close()
 
 	// Initialize handlers
-	companyHandler := handlers.NewCompanyHandler(companyService)
+	companyHandler := handlers.NewCompanyHandler(companyService, analyticsService, cfg.Identity)
+	verificationHandler := handlers.NewVerificationHandler(verificationService)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService)
+	subscriptionHandler := handlers.NewSubscriptionHandler(subscriptionService)
 
 	// Setup Gin
-	router := gin.Default()
+	// Requests are logged by logging.Middleware
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	// Request IDs and a trace span for every request
+	router.Use(tracing.Middleware(cfg.Tracing.ServiceName)...)
+
+	// Request rate, errors and latency per route
+	router.Use(metrics.Middleware())
+
+	// Access log
+	router.Use(logging.Middleware())
 	
 	// Disable automatic trailing slash redirect to prevent 301 loops
 	router.RedirectTrailingSlash = false
@@ -83,17 +197,36 @@ func main() {
 		c.Next()
 	})
 
-	// Health check
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "healthy",
-			"service": "company-service",
-			"version": "1.0.0",
-		})
-	})
+	// Trust identity headers set by the API gateway
+	router.Use(identity.Middleware(cfg.Identity))
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		logging.Fatal("Failed to get database connection pool", "error", err)
+	}
 
-	// Static file serving for uploads
-	router.Static("/uploads", "./uploads")
+	// Prometheus metrics: connection pool stats
+	metrics.RegisterDB("jobfair_company", sqlDB)
+	metrics.Register(router)
+
+	// Liveness (/livez) and readiness (/readyz, /health) probes
+	checker := health.NewChecker("company-service").
+		Add("database", health.Database(sqlDB)).
+		Add("rabbitmq_company_events", eventConsumer.Check).
+		Add("rabbitmq_analytics_events", analyticsConsumer.Check).
+		AddOptional("response_cache", responseCache.Check).
+		AddOptional("rabbitmq_publisher", eventPublisher.Check).
+		Add("storage", health.Storage(store))
+	if cacheConsumer != nil {
+		checker.AddOptional("rabbitmq_cache_events", cacheConsumer.Check)
+	}
+	checker.Register(router)
+
+	// Static file serving for uploads when stored on local disk
+	// (the S3 backend returns bucket URLs instead)
+	if local, ok := store.(*storage.LocalStore); ok {
+		router.Static(cfg.Storage.PublicURL+"/companies", filepath.Join(local.Dir(), "companies"))
+	}
 
 	// JWT Secret
 	jwtSecret := os.Getenv("JWT_SECRET")
@@ -109,8 +242,16 @@ func main() {
 		// Public routes
 		public := api.Group("")
 		{
-			public.GET("/companies", companyHandler.ListCompanies)
-			public.GET("/companies/:id", companyHandler.GetCompany)
+			public.GET("/companies", responseCache.Handler(httpcache.Options{
+				Tags: []string{services.CacheTagCompanies},
+			}), companyHandler.ListCompanies)
+			// Profile views are still counted on cached responses
+			public.GET("/companies/:id", responseCache.Handler(httpcache.Options{
+				Tags:  []string{services.CacheTagCompanyProfiles},
+				OnHit: companyHandler.RecordView,
+			}), companyHandler.GetCompany)
+			public.GET("/companies/:id/media", companyHandler.ListMedia)
+			public.GET("/plans", subscriptionHandler.ListPlans)
 		}
 
 		// Protected routes (require authentication)
@@ -128,8 +269,36 @@ func main() {
 			protected.POST("/companies/:id/videos", companyHandler.UploadVideo)
 			protected.POST("/companies/:id/gallery", companyHandler.UploadGallery)
 
+			// Media (videos and gallery images)
+			protected.POST("/companies/:id/media", companyHandler.UploadMedia)
+			protected.PUT("/companies/:id/media/reorder", companyHandler.ReorderMedia)
+			protected.PUT("/companies/:id/media/:media_id", companyHandler.UpdateMedia)
+			protected.DELETE("/companies/:id/media/:media_id", companyHandler.DeleteMedia)
+
+			// Verification
+			protected.POST("/companies/:id/verification", verificationHandler.Submit)
+			protected.GET("/companies/:id/verification", verificationHandler.GetStatus)
+
 			// Analytics
-			protected.GET("/companies/:id/analytics", companyHandler.GetAnalytics)
+			protected.GET("/companies/:id/analytics", analyticsHandler.GetAnalytics)
+
+			// Subscription
+			protected.GET("/companies/:id/subscription", subscriptionHandler.GetSubscription)
+		}
+
+		// Admin routes (admin only)
+		admin := api.Group("/admin")
+		admin.Use(jwtMiddleware)
+		{
+			// Verification review queue
+			admin.GET("/verifications", verificationHandler.ListQueue)
+			admin.GET("/verifications/:id", verificationHandler.GetVerification)
+			admin.GET("/verifications/:id/documents/:document", verificationHandler.DownloadDocument)
+			admin.POST("/verifications/:id/approve", verificationHandler.Approve)
+			admin.POST("/verifications/:id/reject", verificationHandler.Reject)
+
+			// Subscription tiers
+			admin.POST("/companies/:id/subscriptions", subscriptionHandler.ChangeSubscription)
 		}
 	}
 
@@ -143,16 +312,13 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
 	go func() {
-		log.Printf("🚀 Company service starting on port %s", port)
-		log.Printf("📊 Health check: http://localhost:%s/health", port)
-		log.Printf("🔑 API endpoint: http://localhost:%s/api/v1", port)
+		slog.Info("Company service starting", "port", port)
 		if err := router.Run(":" + port); err != nil {
-			log.Fatal("Failed to start server:", err)
+			logging.Fatal("Failed to start company service", "error", err)
 		}
 	}()
 
 	// Wait for interrupt signal
 	<-quit
-	log.Println("🛑 Shutting down server...")
-	log.Println("✅ Server exited")
+	slog.Info("Company service shutting down")
 }
@@ -0,0 +1,68 @@
+// File: internal/consumers/analytics_consumer.go
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"jobfair-company-service/internal/services"
+
+	"github.com/jobfair/shared/events"
+)
+
+// AnalyticsConsumer records analytics events published by job-service
+type AnalyticsConsumer struct {
+	analyticsService *services.AnalyticsService
+	consumer         *events.Consumer
+}
+
+func NewAnalyticsConsumer(
+	rabbitmqURL string,
+	analyticsService *services.AnalyticsService,
+) (*AnalyticsConsumer, error) {
+	consumer, err := events.NewConsumer(rabbitmqURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer: %w", err)
+	}
+
+	return &AnalyticsConsumer{
+		analyticsService: analyticsService,
+		consumer:         consumer,
+	}, nil
+}
+
+// Start begins consuming analytics events
+func (c *AnalyticsConsumer) Start() error {
+	slog.Info("Starting analytics consumer")
+
+	return c.consumer.SubscribeAnalyticsEvents(c.handleEvent)
+}
+
+func (c *AnalyticsConsumer) handleEvent(ctx context.Context, body []byte) error {
+	var event events.AnalyticsEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		// Malformed events would be redelivered forever
+		slog.WarnContext(ctx, "Dropping malformed analytics event", "error", err)
+		return nil
+	}
+
+	if err := c.analyticsService.RecordJobEvent(&event); err != nil {
+		return fmt.Errorf("failed to record %s for company %d: %w", event.EventType, event.Data.CompanyID, err)
+	}
+	return nil
+}
+
+// Check reports whether the consumer is still connected to RabbitMQ
+func (c *AnalyticsConsumer) Check(ctx context.Context) error {
+	return c.consumer.Check(ctx)
+}
+
+// Close closes the consumer
+func (c *AnalyticsConsumer) Close() error {
+	if c.consumer != nil {
+		return c.consumer.Close()
+	}
+	return nil
+}
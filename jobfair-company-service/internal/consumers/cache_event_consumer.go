@@ -0,0 +1,156 @@
+// File: internal/consumers/cache_event_consumer.go
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"jobfair-company-service/internal/repository"
+	"jobfair-company-service/internal/services"
+
+	"github.com/jobfair/shared/events"
+	"github.com/jobfair/shared/httpcache"
+)
+
+// CacheEventConsumer drops cached company listings and profiles when
+// companies change, so updates show up immediately
+type CacheEventConsumer struct {
+	cache       *httpcache.Cache
+	companyRepo *repository.CompanyRepository
+	consumer    *events.Consumer
+}
+
+func NewCacheEventConsumer(
+	rabbitmqURL string,
+	cache *httpcache.Cache,
+	companyRepo *repository.CompanyRepository,
+) (*CacheEventConsumer, error) {
+	consumer, err := events.NewConsumer(rabbitmqURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer: %w", err)
+	}
+
+	return &CacheEventConsumer{
+		cache:       cache,
+		companyRepo: companyRepo,
+		consumer:    consumer,
+	}, nil
+}
+
+// Start begins consuming company events
+func (c *CacheEventConsumer) Start() error {
+	slog.Info("Starting response cache event consumer")
+
+	// A queue of its own, so every event reaches the cache and not only the
+	// company event consumer. Replicas sharing the Redis cache share the queue;
+	// in-memory caches need every replica to receive every event.
+	const queue = "company-service.response-cache"
+	routingKeys := []string{
+		events.EventTypeCompanyRegistered,
+		events.EventTypeCompanyUpdated,
+		events.EventTypeCompanyDeleted,
+		events.EventTypeCompanyVerified,
+		events.EventTypeCompanySubscriptionChanged,
+	}
+	if c.cache.Shared() {
+		return c.consumer.Subscribe(queue, routingKeys, c.handleEvent)
+	}
+	return c.consumer.SubscribeExclusive(queue, routingKeys, c.handleEvent)
+}
+
+// handleEvent invalidates the listings and the profile of the event's company
+func (c *CacheEventConsumer) handleEvent(ctx context.Context, body []byte) error {
+	var baseEvent events.BaseEvent
+	if err := json.Unmarshal(body, &baseEvent); err != nil {
+		return fmt.Errorf("failed to unmarshal base event: %w", err)
+	}
+
+	var tags []string
+	var companyID, userID uint
+	switch baseEvent.EventType {
+	case events.EventTypeCompanyRegistered:
+		var event events.CompanyRegisteredEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return fmt.Errorf("failed to unmarshal company registered event: %w", err)
+		}
+		companyID, userID = event.Data.CompanyID, event.Data.UserID
+		// The registration as published by auth-service comes before the
+		// company is stored, so no profile of it can be cached yet
+		if companyID == 0 {
+			tags = []string{services.CacheTagCompanies}
+		}
+
+	case events.EventTypeCompanyUpdated:
+		var event events.CompanyUpdatedEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return fmt.Errorf("failed to unmarshal company updated event: %w", err)
+		}
+		if id, ok := event.Data.UpdatedFields["company_id"].(float64); ok {
+			companyID = uint(id)
+		}
+		userID = event.Data.UserID
+
+	case events.EventTypeCompanyDeleted:
+		var event events.CompanyDeletedEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return fmt.Errorf("failed to unmarshal company deleted event: %w", err)
+		}
+		userID = event.Data.UserID
+
+	case events.EventTypeCompanyVerified:
+		var event events.CompanyVerifiedEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return fmt.Errorf("failed to unmarshal company verified event: %w", err)
+		}
+		companyID, userID = event.Data.CompanyID, event.Data.UserID
+
+	case events.EventTypeCompanySubscriptionChanged:
+		var event events.CompanySubscriptionChangedEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return fmt.Errorf("failed to unmarshal company subscription changed event: %w", err)
+		}
+		companyID, userID = event.Data.CompanyID, event.Data.UserID
+
+	default:
+		return nil
+	}
+
+	if tags == nil {
+		tags = c.companyTags(companyID, userID)
+	}
+	if err := c.cache.Invalidate(ctx, tags...); err != nil {
+		return fmt.Errorf("failed to invalidate cached responses: %w", err)
+	}
+	slog.DebugContext(ctx, "Cached responses invalidated", "event_type", baseEvent.EventType, "tags", tags)
+	return nil
+}
+
+// companyTags returns the tags of the responses showing a company: every
+// listing and its profile. When the company can't be found by its ID or its
+// user (e.g. it was already deleted), every profile is dropped.
+func (c *CacheEventConsumer) companyTags(companyID, userID uint) []string {
+	if companyID == 0 && userID != 0 {
+		if company, err := c.companyRepo.GetByUserID(userID); err == nil {
+			companyID = company.ID
+		}
+	}
+	if companyID == 0 {
+		return []string{services.CacheTagCompanies, services.CacheTagCompanyProfiles}
+	}
+	return []string{services.CacheTagCompanies, services.CompanyCacheTag(companyID)}
+}
+
+// Check reports whether the consumer is still connected to RabbitMQ
+func (c *CacheEventConsumer) Check(ctx context.Context) error {
+	return c.consumer.Check(ctx)
+}
+
+// Close closes the consumer
+func (c *CacheEventConsumer) Close() error {
+	if c.consumer != nil {
+		return c.consumer.Close()
+	}
+	return nil
+}
@@ -5,13 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 
 	"jobfair-company-service/internal/models"
 	"jobfair-company-service/internal/repository"
 	"jobfair-company-service/internal/utils"
 
 	"github.com/jobfair/shared/events"
+	"github.com/jobfair/shared/plans"
 )
 
 type CompanyEventConsumer struct {
@@ -44,7 +45,7 @@ func NewCompanyEventConsumer(
 
 // Start begins consuming company events
 func (c *CompanyEventConsumer) Start() error {
-	log.Println("🚀 Starting company event consumer...")
+	slog.Info("Starting company event consumer")
 
 	return c.consumer.SubscribeCompanyEvents(c.handleEvent)
 }
@@ -57,7 +58,7 @@ func (c *CompanyEventConsumer) handleEvent(ctx context.Context, body []byte) err
 		return fmt.Errorf("failed to unmarshal base event: %w", err)
 	}
 
-	log.Printf("📨 Processing event: %s", baseEvent.EventType)
+	slog.DebugContext(ctx, "Processing event", "event_type", baseEvent.EventType)
 
 	// Route to appropriate handler based on event type
 	switch baseEvent.EventType {
@@ -68,7 +69,7 @@ func (c *CompanyEventConsumer) handleEvent(ctx context.Context, body []byte) err
 	case events.EventTypeCompanyDeleted:
 		return c.handleCompanyDeleted(ctx, body)
 	default:
-		log.Printf("⚠️ Unknown event type: %s", baseEvent.EventType)
+		slog.WarnContext(ctx, "Unknown event type", "event_type", baseEvent.EventType)
 		return nil // Don't fail on unknown events
 	}
 }
@@ -81,12 +82,12 @@ func (c *CompanyEventConsumer) handleCompanyRegistered(ctx context.Context, body
 	}
 
 	data := event.Data
-	log.Printf("🏢 Creating company for user_id: %d, name: %s", data.UserID, data.CompanyName)
+	slog.InfoContext(ctx, "Creating company", "user_id", data.UserID, "company_name", data.CompanyName)
 
 	// Check if company already exists
 	existingCompany, _ := c.companyRepo.GetByUserID(data.UserID)
 	if existingCompany != nil {
-		log.Printf("ℹ️ Company already exists for user_id: %d, skipping", data.UserID)
+		slog.InfoContext(ctx, "Company already exists, skipping", "user_id", data.UserID)
 		return nil // Not an error, just idempotency
 	}
 
@@ -117,8 +118,7 @@ func (c *CompanyEventConsumer) handleCompanyRegistered(ctx context.Context, body
 		return fmt.Errorf("failed to create company: %w", err)
 	}
 
-	log.Printf("✅ Company created successfully: ID=%d, UserID=%d, Name=%s",
-		createdCompany.ID, createdCompany.UserID, createdCompany.Name)
+	slog.InfoContext(ctx, "Company created", "company_id", createdCompany.ID, "user_id", createdCompany.UserID)
 
 	// 🚀 Re-publish event WITH company_id for other services (job-service)
 	if c.eventPublisher != nil {
@@ -134,13 +134,15 @@ func (c *CompanyEventConsumer) handleCompanyRegistered(ctx context.Context, body
 			LogoURL:     data.LogoURL,
 			CountryCode: data.CountryCode,
 			ContactName: data.ContactName,
+
+			SubscriptionTier: plans.Tier(createdCompany.SubscriptionTier),
 		}
 
 		if err := c.eventPublisher.PublishCompanyRegistered(ctx, eventDataWithID); err != nil {
-			log.Printf("⚠️ Warning: Failed to re-publish company registered event with ID: %v", err)
+			slog.WarnContext(ctx, "Failed to re-publish company.registered event", "company_id", createdCompany.ID, "error", err)
 			// Don't fail the whole operation
 		} else {
-			log.Printf("📤 Re-published company.registered event with company_id=%d", createdCompany.ID)
+			slog.DebugContext(ctx, "Re-published company.registered event", "company_id", createdCompany.ID)
 		}
 	}
 
@@ -155,7 +157,7 @@ func (c *CompanyEventConsumer) handleCompanyUpdated(ctx context.Context, body []
 	}
 
 	data := event.Data
-	log.Printf("🔄 Updating company for user_id: %d", data.UserID)
+	slog.InfoContext(ctx, "Updating company", "user_id", data.UserID)
 
 	company, err := c.companyRepo.GetByUserID(data.UserID)
 	if err != nil {
@@ -169,7 +171,7 @@ func (c *CompanyEventConsumer) handleCompanyUpdated(ctx context.Context, body []
 		return fmt.Errorf("failed to update company: %w", err)
 	}
 
-	log.Printf("✅ Company updated successfully: ID=%d, UserID=%d", company.ID, company.UserID)
+	slog.InfoContext(ctx, "Company updated", "company_id", company.ID, "user_id", company.UserID)
 	return nil
 }
 
@@ -181,7 +183,7 @@ func (c *CompanyEventConsumer) handleCompanyDeleted(ctx context.Context, body []
 	}
 
 	data := event.Data
-	log.Printf("🗑️ Deleting company for user_id: %d", data.UserID)
+	slog.InfoContext(ctx, "Deleting company", "user_id", data.UserID)
 
 	company, err := c.companyRepo.GetByUserID(data.UserID)
 	if err != nil {
@@ -192,10 +194,15 @@ func (c *CompanyEventConsumer) handleCompanyDeleted(ctx context.Context, body []
 		return fmt.Errorf("failed to delete company: %w", err)
 	}
 
-	log.Printf("✅ Company deleted successfully: ID=%d, UserID=%d", company.ID, company.UserID)
+	slog.InfoContext(ctx, "Company deleted", "company_id", company.ID, "user_id", company.UserID)
 	return nil
 }
 
+// Check reports whether the consumer is still connected to RabbitMQ
+func (c *CompanyEventConsumer) Check(ctx context.Context) error {
+	return c.consumer.Check(ctx)
+}
+
 // Close closes the consumer and publisher
 func (c *CompanyEventConsumer) Close() error {
 	var err error
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type AnalyticsHandler struct {
+	service *services.AnalyticsService
+}
+
+func NewAnalyticsHandler(service *services.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{service: service}
+}
+
+// GetAnalytics returns the company's analytics time series and job funnels
+// GET /api/v1/companies/:id/analytics?from=YYYY-MM-DD&to=YYYY-MM-DD&granularity=day|week|month
+func (h *AnalyticsHandler) GetAnalytics(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+		return
+	}
+
+	companyID, ok := parseUintParam(c, "id", "Invalid company ID")
+	if !ok {
+		return
+	}
+
+	query := models.AnalyticsQuery{
+		Granularity: models.AnalyticsGranularity(c.Query("granularity")),
+	}
+	var err error
+	if query.From, err = parseDateQuery(c, "from"); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid from date, expected YYYY-MM-DD", "VALIDATION_ERROR", nil))
+		return
+	}
+	if query.To, err = parseDateQuery(c, "to"); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid to date, expected YYYY-MM-DD", "VALIDATION_ERROR", nil))
+		return
+	}
+
+	report, err := h.service.GetReport(userID.(uint), c.GetString("user_type"), companyID, query)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+		case errors.Is(err, services.ErrNotCompanyOwner):
+			c.JSON(http.StatusForbidden, models.ErrorResponse(err.Error(), "FORBIDDEN", nil))
+		case errors.Is(err, services.ErrInvalidAnalyticsQuery):
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "VALIDATION_ERROR", nil))
+		default:
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve analytics", "SERVER_ERROR", nil))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Analytics retrieved successfully", report))
+}
+
+// parseDateQuery parses an optional YYYY-MM-DD query parameter (zero if absent)
+func parseDateQuery(c *gin.Context, name string) (time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
@@ -1,22 +1,26 @@
 package handlers
 
 import (
-	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"jobfair-company-service/internal/models"
 	"jobfair-company-service/internal/services"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jobfair/shared/httpcache"
+	"github.com/jobfair/shared/identity"
 )
 
 type CompanyHandler struct {
-	service *services.CompanyService
+	service   *services.CompanyService
+	analytics *services.AnalyticsService
+	identity  identity.Config // Who may mark lookups as not being profile views
 }
 
-func NewCompanyHandler(service *services.CompanyService) *CompanyHandler {
-	return &CompanyHandler{service: service}
+func NewCompanyHandler(service *services.CompanyService, analytics *services.AnalyticsService, identityConfig identity.Config) *CompanyHandler {
+	return &CompanyHandler{service: service, analytics: analytics, identity: identityConfig}
 }
 
 func (h *CompanyHandler) CreateCompany(c *gin.Context) {
@@ -54,9 +58,28 @@ func (h *CompanyHandler) GetCompany(c *gin.Context) {
 		return
 	}
 
+	// Internal lookups (e.g. job-service enriching jobs) are not profile views
+	if !identity.SkipAnalytics(c.Request, h.identity, time.Now()) {
+		h.analytics.TrackProfileView(company.ID, c.GetUint("user_id"))
+	}
+
+	// Cached anonymous profiles are dropped when the company changes
+	httpcache.Tag(c, services.CompanyCacheTag(company.ID))
+
 	c.JSON(http.StatusOK, models.SuccessResponse("Company retrieved successfully", company))
 }
 
+// RecordView counts the profile view of a company served from the response
+// cache, which GetCompany counts for the profiles it computes
+func (h *CompanyHandler) RecordView(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || identity.SkipAnalytics(c.Request, h.identity, time.Now()) {
+		return
+	}
+
+	h.analytics.TrackProfileView(uint(id), c.GetUint("user_id"))
+}
+
 func (h *CompanyHandler) GetMyCompany(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -99,9 +122,6 @@ func (h *CompanyHandler) UploadFile(c *gin.Context, fileType string) {
 	// Check user type
 	userType, exists := c.Get("user_type")
 	
-	// DEBUG: Log actual value
-	log.Printf("[UploadFile] user_type exists: %v, value: %v, type: %T", exists, userType, userType)
-	
 	if !exists {
 		c.JSON(http.StatusForbidden, models.ErrorResponse("Only companies can upload company files", "FORBIDDEN", nil))
 		return
@@ -109,8 +129,6 @@ func (h *CompanyHandler) UploadFile(c *gin.Context, fileType string) {
 	
 	// Convert to string and check
 	userTypeStr, ok := userType.(string)
-	log.Printf("[UploadFile] type assertion ok: %v, userTypeStr: %s", ok, userTypeStr)
-	
 	if !ok || userTypeStr != "company" {
 		c.JSON(http.StatusForbidden, models.ErrorResponse("Only companies can upload company files", "FORBIDDEN", nil))
 		return
@@ -142,22 +160,6 @@ func (h *CompanyHandler) UploadBanner(c *gin.Context)  { h.UploadFile(c, "banner
 func (h *CompanyHandler) UploadVideo(c *gin.Context)   { h.UploadFile(c, "video") }
 func (h *CompanyHandler) UploadGallery(c *gin.Context) { h.UploadFile(c, "gallery") }
 
-func (h *CompanyHandler) GetAnalytics(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid company ID", "INVALID_ID", nil))
-		return
-	}
-
-	analytics, err := h.service.GetAnalytics(uint(id))
-	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse("Analytics not found", "NOT_FOUND", nil))
-		return
-	}
-
-	c.JSON(http.StatusOK, models.SuccessResponse("Analytics retrieved successfully", analytics))
-}
-
 func (h *CompanyHandler) ListCompanies(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
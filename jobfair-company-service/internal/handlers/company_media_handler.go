@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jobfair/shared/upload"
+	"gorm.io/gorm"
+)
+
+func (h *CompanyHandler) ListMedia(c *gin.Context) {
+	companyID, ok := parseUintParam(c, "id", "Invalid company ID")
+	if !ok {
+		return
+	}
+
+	media, err := h.service.ListMedia(companyID, c.Query("type"))
+	if err != nil {
+		respondMediaError(c, err, "Failed to retrieve media")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Media retrieved successfully", media))
+}
+
+func (h *CompanyHandler) UploadMedia(c *gin.Context) {
+	userID, ok := companyUserID(c)
+	if !ok {
+		return
+	}
+
+	companyID, ok := parseUintParam(c, "id", "Invalid company ID")
+	if !ok {
+		return
+	}
+
+	var req models.UploadCompanyMediaRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("File upload failed", "UPLOAD_FAILED", err.Error()))
+		return
+	}
+
+	media, err := h.service.UploadMedia(userID, companyID, file, &req)
+	if err != nil {
+		if upload.IsRejected(err) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "UPLOAD_FAILED", nil))
+			return
+		}
+		respondMediaError(c, err, "Failed to upload media")
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse("Media uploaded successfully", media))
+}
+
+func (h *CompanyHandler) UpdateMedia(c *gin.Context) {
+	userID, ok := companyUserID(c)
+	if !ok {
+		return
+	}
+
+	companyID, ok := parseUintParam(c, "id", "Invalid company ID")
+	if !ok {
+		return
+	}
+	mediaID, ok := parseUintParam(c, "media_id", "Invalid media ID")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateCompanyMediaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	media, err := h.service.UpdateMedia(userID, companyID, mediaID, &req)
+	if err != nil {
+		respondMediaError(c, err, "Failed to update media")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Media updated successfully", media))
+}
+
+func (h *CompanyHandler) ReorderMedia(c *gin.Context) {
+	userID, ok := companyUserID(c)
+	if !ok {
+		return
+	}
+
+	companyID, ok := parseUintParam(c, "id", "Invalid company ID")
+	if !ok {
+		return
+	}
+
+	var req models.ReorderCompanyMediaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	media, err := h.service.ReorderMedia(userID, companyID, &req)
+	if err != nil {
+		respondMediaError(c, err, "Failed to reorder media")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Media reordered successfully", media))
+}
+
+func (h *CompanyHandler) DeleteMedia(c *gin.Context) {
+	userID, ok := companyUserID(c)
+	if !ok {
+		return
+	}
+
+	companyID, ok := parseUintParam(c, "id", "Invalid company ID")
+	if !ok {
+		return
+	}
+	mediaID, ok := parseUintParam(c, "media_id", "Invalid media ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteMedia(userID, companyID, mediaID); err != nil {
+		respondMediaError(c, err, "Failed to delete media")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Media deleted successfully", nil))
+}
+
+// companyUserID returns the authenticated user's ID, rejecting non-company users
+func companyUserID(c *gin.Context) (uint, bool) {
+	userType, _ := c.Get("user_type")
+	if userTypeStr, _ := userType.(string); userTypeStr != "company" {
+		c.JSON(http.StatusForbidden, models.ErrorResponse("Only company accounts can perform this action", "FORBIDDEN", nil))
+		return 0, false
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+		return 0, false
+	}
+
+	return userID.(uint), true
+}
+
+func parseUintParam(c *gin.Context, name, message string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(message, "INVALID_ID", nil))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondMediaError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+	case errors.Is(err, services.ErrMediaNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse(err.Error(), "NOT_FOUND", nil))
+	case errors.Is(err, services.ErrNotCompanyOwner):
+		c.JSON(http.StatusForbidden, models.ErrorResponse(err.Error(), "FORBIDDEN", nil))
+	case errors.Is(err, services.ErrInvalidMediaType), errors.Is(err, services.ErrInvalidMediaOrder):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "VALIDATION_ERROR", nil))
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(message, "SERVER_ERROR", nil))
+	}
+}
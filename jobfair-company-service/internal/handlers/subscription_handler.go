@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type SubscriptionHandler struct {
+	service *services.SubscriptionService
+}
+
+func NewSubscriptionHandler(service *services.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{service: service}
+}
+
+// ListPlans returns the plan catalog with the limits of each tier
+// GET /api/v1/plans
+func (h *SubscriptionHandler) ListPlans(c *gin.Context) {
+	c.JSON(http.StatusOK, models.SuccessResponse("Plans retrieved successfully", h.service.Plans()))
+}
+
+// GetSubscription returns the company's current plan and subscription periods
+// GET /api/v1/companies/:id/subscription
+func (h *SubscriptionHandler) GetSubscription(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+		return
+	}
+
+	companyID, ok := parseUintParam(c, "id", "Invalid company ID")
+	if !ok {
+		return
+	}
+
+	overview, err := h.service.GetOverview(userID.(uint), c.GetString("user_type"), companyID)
+	if err != nil {
+		respondSubscriptionError(c, err, "Failed to retrieve subscription")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Subscription retrieved successfully", overview))
+}
+
+// ChangeSubscription moves a company to a tier from effective_from (default
+// now) until effective_until (default open-ended)
+// POST /api/v1/admin/companies/:id/subscriptions
+func (h *SubscriptionHandler) ChangeSubscription(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	companyID, ok := parseUintParam(c, "id", "Invalid company ID")
+	if !ok {
+		return
+	}
+
+	var req models.ChangeSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	subscription, err := h.service.ChangeSubscription(c.GetUint("user_id"), companyID, &req)
+	if err != nil {
+		respondSubscriptionError(c, err, "Failed to change subscription")
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse("Subscription changed successfully", subscription))
+}
+
+func respondSubscriptionError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+	case errors.Is(err, services.ErrNotCompanyOwner):
+		c.JSON(http.StatusForbidden, models.ErrorResponse(err.Error(), "FORBIDDEN", nil))
+	case errors.Is(err, services.ErrInvalidSubscriptionTier), errors.Is(err, services.ErrInvalidSubscriptionPeriod):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "VALIDATION_ERROR", nil))
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(message, "SERVER_ERROR", nil))
+	}
+}
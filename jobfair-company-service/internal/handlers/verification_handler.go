@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jobfair/shared/upload"
+	"gorm.io/gorm"
+)
+
+type VerificationHandler struct {
+	service *services.VerificationService
+}
+
+func NewVerificationHandler(service *services.VerificationService) *VerificationHandler {
+	return &VerificationHandler{service: service}
+}
+
+// Submit uploads the verification documents of a company
+// POST /api/v1/companies/:id/verification
+func (h *VerificationHandler) Submit(c *gin.Context) {
+	userID, ok := companyUserID(c)
+	if !ok {
+		return
+	}
+
+	companyID, ok := parseUintParam(c, "id", "Invalid company ID")
+	if !ok {
+		return
+	}
+
+	var req models.SubmitVerificationRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	registrationDoc, err := c.FormFile(models.VerificationDocumentRegistration)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Business registration document is required", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+	taxDoc, err := c.FormFile(models.VerificationDocumentTax)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Tax document is required", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	verification, err := h.service.Submit(userID, companyID, &req, registrationDoc, taxDoc)
+	if err != nil {
+		if upload.IsRejected(err) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "UPLOAD_FAILED", nil))
+			return
+		}
+		respondVerificationError(c, err, "Failed to submit verification request")
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse("Verification request submitted successfully", verification))
+}
+
+// GetStatus returns the company's latest verification request
+// GET /api/v1/companies/:id/verification
+func (h *VerificationHandler) GetStatus(c *gin.Context) {
+	userID, ok := companyUserID(c)
+	if !ok {
+		return
+	}
+
+	companyID, ok := parseUintParam(c, "id", "Invalid company ID")
+	if !ok {
+		return
+	}
+
+	verification, err := h.service.GetLatest(userID, companyID)
+	if err != nil {
+		respondVerificationError(c, err, "Failed to retrieve verification request")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Verification request retrieved successfully", verification))
+}
+
+// ListQueue lists verification requests for review, pending ones by default
+// GET /api/v1/admin/verifications?status=pending|approved|rejected|all
+func (h *VerificationHandler) ListQueue(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	status := models.VerificationStatus(c.DefaultQuery("status", string(models.VerificationPending)))
+	switch status {
+	case models.VerificationPending, models.VerificationApproved, models.VerificationRejected:
+	case "all":
+		status = ""
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid status", "VALIDATION_ERROR", nil))
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	verifications, total, err := h.service.ListQueue(status, limit, (page-1)*limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve verification requests", "SERVER_ERROR", nil))
+		return
+	}
+
+	pagination := models.PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedSuccessResponse("Verification requests retrieved successfully", verifications, pagination))
+}
+
+// GetVerification returns a verification request with its company
+// GET /api/v1/admin/verifications/:id
+func (h *VerificationHandler) GetVerification(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	id, ok := parseUintParam(c, "id", "Invalid verification ID")
+	if !ok {
+		return
+	}
+
+	verification, err := h.service.Get(id)
+	if err != nil {
+		respondVerificationError(c, err, "Failed to retrieve verification request")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Verification request retrieved successfully", verification))
+}
+
+// DownloadDocument streams a submitted document to an admin
+// GET /api/v1/admin/verifications/:id/documents/:document
+func (h *VerificationHandler) DownloadDocument(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	id, ok := parseUintParam(c, "id", "Invalid verification ID")
+	if !ok {
+		return
+	}
+
+	file, err := h.service.OpenDocument(id, c.Param("document"))
+	if err != nil {
+		respondVerificationError(c, err, "Failed to retrieve document")
+		return
+	}
+	defer file.Reader.Close()
+
+	c.Header("Cache-Control", "private, no-store")
+	c.DataFromReader(http.StatusOK, file.Size, file.ContentType, file.Reader, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}),
+	})
+}
+
+// Approve verifies the company
+// POST /api/v1/admin/verifications/:id/approve
+func (h *VerificationHandler) Approve(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	id, ok := parseUintParam(c, "id", "Invalid verification ID")
+	if !ok {
+		return
+	}
+
+	verification, err := h.service.Approve(c.GetUint("user_id"), id)
+	if err != nil {
+		respondVerificationError(c, err, "Failed to approve verification request")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Company verified successfully", verification))
+}
+
+// Reject rejects the request with a reason
+// POST /api/v1/admin/verifications/:id/reject
+func (h *VerificationHandler) Reject(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	id, ok := parseUintParam(c, "id", "Invalid verification ID")
+	if !ok {
+		return
+	}
+
+	var req models.RejectVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("A rejection reason is required", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	verification, err := h.service.Reject(c.GetUint("user_id"), id, req.Reason)
+	if err != nil {
+		respondVerificationError(c, err, "Failed to reject verification request")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Verification request rejected", verification))
+}
+
+// requireAdmin rejects requests from non-admin users
+func requireAdmin(c *gin.Context) bool {
+	if c.GetString("user_type") != "admin" {
+		c.JSON(http.StatusForbidden, models.ErrorResponse("Only admins can perform this action", "FORBIDDEN", nil))
+		return false
+	}
+	return true
+}
+
+func respondVerificationError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+	case errors.Is(err, services.ErrVerificationNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse(err.Error(), "NOT_FOUND", nil))
+	case errors.Is(err, services.ErrNotCompanyOwner):
+		c.JSON(http.StatusForbidden, models.ErrorResponse(err.Error(), "FORBIDDEN", nil))
+	case errors.Is(err, services.ErrAlreadyVerified),
+		errors.Is(err, services.ErrVerificationPending),
+		errors.Is(err, services.ErrVerificationReviewed):
+		c.JSON(http.StatusConflict, models.ErrorResponse(err.Error(), "CONFLICT", nil))
+	case errors.Is(err, services.ErrInvalidDocument):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "VALIDATION_ERROR", nil))
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(message, "SERVER_ERROR", nil))
+	}
+}
@@ -6,10 +6,17 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/jobfair/shared/identity"
 )
 
 func JWTMiddleware(jwtSecret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Sudah diautentikasi oleh API gateway
+		if identity.Verified(c) {
+			c.Next()
+			return
+		}
+
 		// Ambil header Authorization
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
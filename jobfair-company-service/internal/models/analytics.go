@@ -0,0 +1,112 @@
+package models
+
+import "time"
+
+type AnalyticsMetric string
+
+const (
+	MetricProfileView AnalyticsMetric = "profile_view"
+	MetricJobView     AnalyticsMetric = "job_view"
+	MetricJobSave     AnalyticsMetric = "job_save"
+	MetricJobApply    AnalyticsMetric = "job_apply"
+	MetricHire        AnalyticsMetric = "hire"
+)
+
+type AnalyticsGranularity string
+
+const (
+	GranularityDay   AnalyticsGranularity = "day"
+	GranularityWeek  AnalyticsGranularity = "week"
+	GranularityMonth AnalyticsGranularity = "month"
+)
+
+// IsValid reports whether the granularity is a known value
+func (g AnalyticsGranularity) IsValid() bool {
+	switch g {
+	case GranularityDay, GranularityWeek, GranularityMonth:
+		return true
+	}
+	return false
+}
+
+// CompanyAnalyticsEvent is a single tracked interaction with a company or one of its jobs
+type CompanyAnalyticsEvent struct {
+	ID         uint            `json:"id" gorm:"primaryKey"`
+	EventID    *string         `json:"event_id,omitempty"`
+	CompanyID  uint            `json:"company_id" gorm:"not null"`
+	JobID      uint            `json:"job_id"` // 0 for company-level events
+	JobTitle   string          `json:"job_title,omitempty"`
+	Metric     AnalyticsMetric `json:"metric" gorm:"not null"`
+	UserID     *uint           `json:"user_id,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+func (CompanyAnalyticsEvent) TableName() string {
+	return "company_analytics_events"
+}
+
+// AnalyticsQuery selects the date range (inclusive, UTC dates) and bucket size of a report
+type AnalyticsQuery struct {
+	From        time.Time
+	To          time.Time
+	Granularity AnalyticsGranularity
+}
+
+// AnalyticsCounts holds one count per metric
+type AnalyticsCounts struct {
+	ProfileViews int64 `json:"profile_views"`
+	JobViews     int64 `json:"job_views"`
+	JobSaves     int64 `json:"job_saves"`
+	JobApplies   int64 `json:"job_applies"`
+	Hires        int64 `json:"hires"`
+}
+
+// Add adds n to the counter of metric
+func (c *AnalyticsCounts) Add(metric AnalyticsMetric, n int64) {
+	switch metric {
+	case MetricProfileView:
+		c.ProfileViews += n
+	case MetricJobView:
+		c.JobViews += n
+	case MetricJobSave:
+		c.JobSaves += n
+	case MetricJobApply:
+		c.JobApplies += n
+	case MetricHire:
+		c.Hires += n
+	}
+}
+
+// AnalyticsPoint is one bucket of the time series, starting at Date
+type AnalyticsPoint struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	AnalyticsCounts
+}
+
+// JobFunnel is the view -> save -> apply -> hire funnel of one job. Saving is
+// optional, so SaveRate and ApplyRate are relative to views and HireRate to
+// applies. Rates are 0 when the base has no events and capped at 1, as events
+// of a range can follow views from before it.
+type JobFunnel struct {
+	JobID     uint    `json:"job_id"`
+	JobTitle  string  `json:"job_title"`
+	Views     int64   `json:"views"`
+	Saves     int64   `json:"saves"`
+	Applies   int64   `json:"applies"`
+	Hires     int64   `json:"hires"`
+	SaveRate  float64 `json:"save_rate"`
+	ApplyRate float64 `json:"apply_rate"`
+	HireRate  float64 `json:"hire_rate"`
+}
+
+// AnalyticsReport is returned by GET /companies/:id/analytics
+type AnalyticsReport struct {
+	CompanyID   uint                 `json:"company_id"`
+	From        string               `json:"from"`
+	To          string               `json:"to"`
+	Granularity AnalyticsGranularity `json:"granularity"`
+	Totals      AnalyticsCounts      `json:"totals"`
+	Series      []AnalyticsPoint     `json:"series"`
+	Funnels     []JobFunnel          `json:"funnels"`
+	Lifetime    *CompanyAnalytics    `json:"lifetime,omitempty"`
+}
@@ -53,10 +53,13 @@ type Company struct {
 	Longitude  float64 `json:"longitude,omitempty"`
 	
 	// Media
-	LogoURL     string         `json:"logo_url"`
-	BannerURL   string         `json:"banner_url"`
-	VideoURLs   pq.StringArray `json:"video_urls" gorm:"type:text[]"`
-	GalleryURLs pq.StringArray `json:"gallery_urls" gorm:"type:text[]"`
+	LogoURL   string         `json:"logo_url"`
+	BannerURL string         `json:"banner_url"`
+	Media     []CompanyMedia `json:"media,omitempty" gorm:"foreignKey:CompanyID"`
+
+	// Video and gallery URLs in display order, derived from Media for older clients
+	VideoURLs   []string `json:"video_urls,omitempty" gorm:"-"`
+	GalleryURLs []string `json:"gallery_urls,omitempty" gorm:"-"`
 	
 	// Social Media
 	LinkedinURL  string `json:"linkedin_url"`
@@ -133,16 +136,53 @@ type CompanyAnalytics struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
-// CompanyMedia for company media assets
+const (
+	MediaTypeVideo   = "video"
+	MediaTypeGallery = "gallery"
+)
+
+// CompanyMedia for company media assets (videos and gallery images)
 type CompanyMedia struct {
 	ID           uint           `json:"id" gorm:"primaryKey"`
 	CompanyID    uint           `json:"company_id" gorm:"not null;index"`
-	MediaType    string         `json:"media_type"`
-	MediaURL     string         `json:"media_url" gorm:"not null"`
+	MediaType    string         `json:"media_type" gorm:"not null"`
+	FileName     string         `json:"file_name" gorm:"not null"`
+	FileURL      string         `json:"file_url" gorm:"not null"`
+	FileSize     int64          `json:"file_size"`
+	MimeType     string         `json:"mime_type"`
+	ThumbnailURL string         `json:"thumbnail_url,omitempty"`
 	Title        string         `json:"title"`
 	Description  string         `json:"description"`
+	AltText      string         `json:"alt_text"`
 	DisplayOrder int            `json:"display_order" gorm:"default:0"`
+	IsPublic     bool           `json:"is_public" gorm:"default:true"`
+	UploadedAt   time.Time      `json:"uploaded_at"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
 }
+
+func (CompanyMedia) TableName() string {
+	return "company_media"
+}
+
+// UploadCompanyMediaRequest holds the form fields sent with a media upload
+type UploadCompanyMediaRequest struct {
+	MediaType   string `form:"media_type" binding:"required,oneof=video gallery"`
+	Title       string `form:"title"`
+	Description string `form:"description"`
+	AltText     string `form:"alt_text"`
+}
+
+// UpdateCompanyMediaRequest for updating media metadata
+type UpdateCompanyMediaRequest struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	AltText     *string `json:"alt_text"`
+	IsPublic    *bool   `json:"is_public"`
+}
+
+// ReorderCompanyMediaRequest lists media IDs in their new display order
+type ReorderCompanyMediaRequest struct {
+	MediaIDs []uint `json:"media_ids" binding:"required,min=1"`
+}
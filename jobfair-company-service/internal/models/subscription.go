@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jobfair/shared/plans"
+)
+
+// CompanySubscription is a period in which a company is on a subscription tier
+type CompanySubscription struct {
+	ID             uint             `json:"id" gorm:"primaryKey"`
+	CompanyID      uint             `json:"company_id" gorm:"not null;index"`
+	Tier           SubscriptionTier `json:"tier" gorm:"not null"`
+	EffectiveFrom  time.Time        `json:"effective_from" gorm:"not null"`
+	EffectiveUntil *time.Time       `json:"effective_until,omitempty"` // nil = open-ended
+	Reason         string           `json:"reason,omitempty"`
+	ChangedBy      uint             `json:"changed_by" gorm:"not null"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+func (CompanySubscription) TableName() string {
+	return "company_subscriptions"
+}
+
+// ChangeSubscriptionRequest moves a company to a tier for a period.
+// EffectiveFrom defaults to now.
+type ChangeSubscriptionRequest struct {
+	Tier           SubscriptionTier `json:"tier" binding:"required,oneof=free basic premium pro"`
+	EffectiveFrom  *time.Time       `json:"effective_from"`
+	EffectiveUntil *time.Time       `json:"effective_until"`
+	Reason         string           `json:"reason" binding:"max=500"`
+}
+
+// SubscriptionOverview is a company's current plan and its subscription
+// periods, newest first (including changes that are not effective yet)
+type SubscriptionOverview struct {
+	CompanyID     uint                  `json:"company_id"`
+	Plan          plans.Plan            `json:"plan"`
+	Current       *CompanySubscription  `json:"current,omitempty"`
+	Subscriptions []CompanySubscription `json:"subscriptions"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+type VerificationStatus string
+
+const (
+	VerificationPending  VerificationStatus = "pending"
+	VerificationApproved VerificationStatus = "approved"
+	VerificationRejected VerificationStatus = "rejected"
+)
+
+// VerificationBadgeVerified is set on Company.VerificationBadge once approved
+const VerificationBadgeVerified = "verified"
+
+// Verification documents an admin can download
+const (
+	VerificationDocumentRegistration = "business_registration"
+	VerificationDocumentTax          = "tax_document"
+)
+
+// CompanyVerification is a company's request to be verified, with the
+// documents submitted for review
+type CompanyVerification struct {
+	ID                       uint               `json:"id" gorm:"primaryKey"`
+	CompanyID                uint               `json:"company_id" gorm:"not null;index"`
+	SubmittedBy              uint               `json:"submitted_by" gorm:"not null"`
+	Status                   VerificationStatus `json:"status" gorm:"default:'pending'"`
+	RegistrationNumber       string             `json:"registration_number" gorm:"not null"`
+	TaxID                    string             `json:"tax_id" gorm:"not null"`
+	RegistrationDocumentKey  string             `json:"-" gorm:"not null"`
+	RegistrationDocumentName string             `json:"registration_document_name"`
+	TaxDocumentKey           string             `json:"-" gorm:"not null"`
+	TaxDocumentName          string             `json:"tax_document_name"`
+	RejectionReason          string             `json:"rejection_reason,omitempty"`
+	ReviewedBy               *uint              `json:"reviewed_by,omitempty"`
+	ReviewedAt               *time.Time         `json:"reviewed_at,omitempty"`
+	CreatedAt                time.Time          `json:"created_at"`
+	UpdatedAt                time.Time          `json:"updated_at"`
+
+	// Relations
+	Company *Company `json:"company,omitempty" gorm:"foreignKey:CompanyID"`
+}
+
+func (CompanyVerification) TableName() string {
+	return "company_verifications"
+}
+
+// SubmitVerificationRequest holds the form fields sent with the verification documents
+type SubmitVerificationRequest struct {
+	RegistrationNumber string `form:"registration_number" binding:"required,max=100"`
+	TaxID              string `form:"tax_id" binding:"required,max=100"`
+}
+
+// RejectVerificationRequest for rejecting a verification request
+type RejectVerificationRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
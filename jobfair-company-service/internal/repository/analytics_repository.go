@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"time"
+
+	"jobfair-company-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MetricCount is an aggregated count of one metric for a period or job
+type MetricCount struct {
+	Period time.Time
+	JobID  uint
+	Metric models.AnalyticsMetric
+	Count  int64
+}
+
+type AnalyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepository(db *gorm.DB) *AnalyticsRepository {
+	return &AnalyticsRepository{db: db}
+}
+
+// RecordEvent stores the event and adds it to its daily bucket. Events with an
+// EventID that was already recorded (redeliveries) are ignored.
+func (r *AnalyticsRepository) RecordEvent(event *models.CompanyAnalyticsEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		return tx.Exec(`
+			INSERT INTO company_analytics_daily (company_id, job_id, bucket_date, metric, count)
+			VALUES (?, ?, ?, ?, 1)
+			ON CONFLICT (company_id, bucket_date, job_id, metric)
+			DO UPDATE SET count = company_analytics_daily.count + 1`,
+			event.CompanyID, event.JobID, event.OccurredAt.UTC().Format("2006-01-02"), event.Metric,
+		).Error
+	})
+}
+
+// CountsByPeriod sums the company's daily buckets between from and to
+// (inclusive dates) per metric and period of the given granularity
+func (r *AnalyticsRepository) CountsByPeriod(companyID uint, from, to time.Time, granularity models.AnalyticsGranularity) ([]MetricCount, error) {
+	var counts []MetricCount
+	err := r.db.Raw(`
+		SELECT date_trunc(?, bucket_date)::date AS period, metric, SUM(count) AS count
+		FROM company_analytics_daily
+		WHERE company_id = ? AND bucket_date BETWEEN ? AND ?
+		GROUP BY 1, 2
+		ORDER BY 1`,
+		string(granularity), companyID, from.Format("2006-01-02"), to.Format("2006-01-02"),
+	).Scan(&counts).Error
+	return counts, err
+}
+
+// CountsByJob sums the company's daily buckets between from and to per job and metric
+func (r *AnalyticsRepository) CountsByJob(companyID uint, from, to time.Time) ([]MetricCount, error) {
+	var counts []MetricCount
+	err := r.db.Raw(`
+		SELECT job_id, metric, SUM(count) AS count
+		FROM company_analytics_daily
+		WHERE company_id = ? AND job_id <> 0 AND bucket_date BETWEEN ? AND ?
+		GROUP BY 1, 2`,
+		companyID, from.Format("2006-01-02"), to.Format("2006-01-02"),
+	).Scan(&counts).Error
+	return counts, err
+}
+
+// JobTitles returns the most recently tracked title of each job
+func (r *AnalyticsRepository) JobTitles(companyID uint, jobIDs []uint) (map[uint]string, error) {
+	titles := make(map[uint]string, len(jobIDs))
+	if len(jobIDs) == 0 {
+		return titles, nil
+	}
+
+	var rows []struct {
+		JobID    uint
+		JobTitle string
+	}
+	if err := r.db.Raw(`
+		SELECT DISTINCT ON (job_id) job_id, job_title
+		FROM company_analytics_events
+		WHERE company_id = ? AND job_id IN ? AND job_title <> ''
+		ORDER BY job_id, occurred_at DESC`,
+		companyID, jobIDs,
+	).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		titles[row.JobID] = row.JobTitle
+	}
+	return titles, nil
+}
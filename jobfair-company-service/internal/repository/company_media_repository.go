@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"jobfair-company-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type CompanyMediaRepository struct {
+	db *gorm.DB
+}
+
+func NewCompanyMediaRepository(db *gorm.DB) *CompanyMediaRepository {
+	return &CompanyMediaRepository{db: db}
+}
+
+// Create appends the media item after the company's existing media
+func (r *CompanyMediaRepository) Create(media *models.CompanyMedia) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var next int
+		if err := tx.Model(&models.CompanyMedia{}).
+			Where("company_id = ?", media.CompanyID).
+			Select("COALESCE(MAX(display_order) + 1, 0)").
+			Scan(&next).Error; err != nil {
+			return err
+		}
+
+		media.DisplayOrder = next
+		return tx.Create(media).Error
+	})
+}
+
+func (r *CompanyMediaRepository) GetByID(companyID, id uint) (*models.CompanyMedia, error) {
+	var media models.CompanyMedia
+	if err := r.db.Where("company_id = ?", companyID).First(&media, id).Error; err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// ListByCompany returns the company's media in display order. mediaType may be
+// empty to list every type.
+func (r *CompanyMediaRepository) ListByCompany(companyID uint, mediaType string, publicOnly bool) ([]models.CompanyMedia, error) {
+	var media []models.CompanyMedia
+
+	query := r.db.Where("company_id = ?", companyID)
+	if mediaType != "" {
+		query = query.Where("media_type = ?", mediaType)
+	}
+	if publicOnly {
+		query = query.Where("is_public = ?", true)
+	}
+
+	if err := query.Order("display_order ASC, id ASC").Find(&media).Error; err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+func (r *CompanyMediaRepository) Update(media *models.CompanyMedia) error {
+	return r.db.Save(media).Error
+}
+
+func (r *CompanyMediaRepository) Delete(companyID, id uint) error {
+	return r.db.Where("company_id = ?", companyID).Delete(&models.CompanyMedia{}, id).Error
+}
+
+// Reorder sets the display order of the given media to their position in ids
+func (r *CompanyMediaRepository) Reorder(companyID uint, ids []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			if err := tx.Model(&models.CompanyMedia{}).
+				Where("company_id = ? AND id = ?", companyID, id).
+				Update("display_order", i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"jobfair-company-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type SubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionRepository(db *gorm.DB) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db}
+}
+
+func (r *SubscriptionRepository) Create(subscription *models.CompanySubscription) error {
+	return r.db.Create(subscription).Error
+}
+
+// ListByCompany returns the company's subscription periods, newest first
+func (r *SubscriptionRepository) ListByCompany(companyID uint) ([]models.CompanySubscription, error) {
+	var subscriptions []models.CompanySubscription
+	err := r.db.Where("company_id = ?", companyID).
+		Order("effective_from DESC, id DESC").
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// GetEffective returns the company's subscription in effect at the given
+// time: the latest period that has started and not ended. It returns nil if
+// the company has none.
+func (r *SubscriptionRepository) GetEffective(companyID uint, at time.Time) (*models.CompanySubscription, error) {
+	var subscription models.CompanySubscription
+	err := r.db.Where("company_id = ? AND effective_from <= ?", companyID, at).
+		Where("effective_until IS NULL OR effective_until > ?", at).
+		Order("effective_from DESC, id DESC").
+		First(&subscription).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// SyncTiers sets subscription_tier and is_premium of companies with
+// subscription periods to the tier in effect at the given time (free when no
+// period is in effect). premiumTiers are the tiers that make a company
+// premium. Only the given companies are synced if any are passed. It returns
+// the number of companies whose tier changed.
+func (r *SubscriptionRepository) SyncTiers(at time.Time, premiumTiers []string, companyIDs ...uint) (int64, error) {
+	query := `
+		UPDATE companies c
+		SET subscription_tier = t.tier, is_premium = t.tier IN ?
+		FROM (
+			SELECT cs.company_id, COALESCE((
+				SELECT s.tier FROM company_subscriptions s
+				WHERE s.company_id = cs.company_id AND s.effective_from <= ?
+				  AND (s.effective_until IS NULL OR s.effective_until > ?)
+				ORDER BY s.effective_from DESC, s.id DESC
+				LIMIT 1
+			), 'free') AS tier
+			FROM (SELECT DISTINCT company_id FROM company_subscriptions) cs
+		) t
+		WHERE c.id = t.company_id AND c.subscription_tier IS DISTINCT FROM t.tier`
+	args := []interface{}{premiumTiers, at, at}
+	if len(companyIDs) > 0 {
+		query += " AND c.id IN ?"
+		args = append(args, companyIDs)
+	}
+
+	result := r.db.Exec(query, args...)
+	return result.RowsAffected, result.Error
+}
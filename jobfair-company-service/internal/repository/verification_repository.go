@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"jobfair-company-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrAlreadyReviewed is returned when a verification request is no longer pending
+var ErrAlreadyReviewed = errors.New("verification request has already been reviewed")
+
+type VerificationRepository struct {
+	db *gorm.DB
+}
+
+func NewVerificationRepository(db *gorm.DB) *VerificationRepository {
+	return &VerificationRepository{db: db}
+}
+
+func (r *VerificationRepository) Create(verification *models.CompanyVerification) error {
+	return r.db.Create(verification).Error
+}
+
+func (r *VerificationRepository) GetByID(id uint) (*models.CompanyVerification, error) {
+	var verification models.CompanyVerification
+	if err := r.db.Preload("Company").First(&verification, id).Error; err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// GetLatestByCompany returns the company's most recent verification request
+func (r *VerificationRepository) GetLatestByCompany(companyID uint) (*models.CompanyVerification, error) {
+	var verification models.CompanyVerification
+	if err := r.db.Where("company_id = ?", companyID).
+		Order("created_at DESC, id DESC").
+		First(&verification).Error; err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+func (r *VerificationRepository) HasPending(companyID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.CompanyVerification{}).
+		Where("company_id = ? AND status = ?", companyID, models.VerificationPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// List returns verification requests with the given status (all if empty),
+// oldest first so the review queue is worked in submission order
+func (r *VerificationRepository) List(status models.VerificationStatus, limit, offset int) ([]*models.CompanyVerification, int64, error) {
+	var verifications []*models.CompanyVerification
+	var total int64
+
+	query := r.db.Model(&models.CompanyVerification{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Preload("Company").
+		Order("created_at ASC, id ASC").
+		Limit(limit).Offset(offset).
+		Find(&verifications).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return verifications, total, nil
+}
+
+// Review records the admin's decision and updates the company's verification
+// fields in one transaction. It fails with ErrAlreadyReviewed if another admin
+// reviewed the request first.
+func (r *VerificationRepository) Review(verification *models.CompanyVerification, reviewerID uint, status models.VerificationStatus, reason string, reviewedAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.CompanyVerification{}).
+			Where("id = ? AND status = ?", verification.ID, models.VerificationPending).
+			Updates(map[string]interface{}{
+				"status":           status,
+				"rejection_reason": reason,
+				"reviewed_by":      reviewerID,
+				"reviewed_at":      reviewedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrAlreadyReviewed
+		}
+
+		if status == models.VerificationApproved {
+			if err := tx.Model(&models.Company{}).
+				Where("id = ?", verification.CompanyID).
+				Updates(map[string]interface{}{
+					"is_verified":        true,
+					"verified_at":        reviewedAt,
+					"verification_badge": models.VerificationBadgeVerified,
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		verification.Status = status
+		verification.RejectionReason = reason
+		verification.ReviewedBy = &reviewerID
+		verification.ReviewedAt = &reviewedAt
+		return nil
+	})
+}
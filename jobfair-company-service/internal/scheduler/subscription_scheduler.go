@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"jobfair-company-service/internal/services"
+)
+
+// SubscriptionScheduler periodically applies subscription changes whose
+// effective period started or ended to the companies' stored tier. The sync
+// is idempotent, so every replica may run a scheduler.
+type SubscriptionScheduler struct {
+	subscriptionService *services.SubscriptionService
+	interval            time.Duration
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewSubscriptionScheduler(subscriptionService *services.SubscriptionService, interval time.Duration) *SubscriptionScheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	return &SubscriptionScheduler{
+		subscriptionService: subscriptionService,
+		interval:            interval,
+		stop:                make(chan struct{}),
+		done:                make(chan struct{}),
+	}
+}
+
+// Start runs the scheduler loop in the background
+func (s *SubscriptionScheduler) Start() {
+	slog.Info("Subscription scheduler started", "interval", s.interval)
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.run()
+		for {
+			select {
+			case <-ticker.C:
+				s.run()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the scheduler and waits for the current pass to finish
+func (s *SubscriptionScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	<-s.done
+	slog.Info("Subscription scheduler stopped")
+}
+
+// run executes a single scheduler pass
+func (s *SubscriptionScheduler) run() {
+	updated, err := s.subscriptionService.ApplyDueChanges()
+	if err != nil {
+		slog.Error("Failed to apply subscription changes", "error", err)
+	} else if updated > 0 {
+		slog.Info("Subscription tiers updated", "companies", updated)
+	}
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/repository"
+
+	"github.com/jobfair/shared/events"
+	"gorm.io/gorm"
+)
+
+// defaultAnalyticsRange is the report range when no dates are given
+const defaultAnalyticsRange = 30 * 24 * time.Hour
+
+// maxAnalyticsRange caps the date range of a report
+const maxAnalyticsRange = 2 * 366 * 24 * time.Hour
+
+// profileViewQueueSize bounds the profile views waiting to be recorded;
+// views beyond it are dropped rather than piling up goroutines
+const profileViewQueueSize = 1024
+
+var ErrInvalidAnalyticsQuery = errors.New("invalid analytics query")
+
+// analyticsMetrics maps analytics event types published by job-service to metrics
+var analyticsMetrics = map[string]models.AnalyticsMetric{
+	events.EventTypeJobViewed:      models.MetricJobView,
+	events.EventTypeJobSaved:       models.MetricJobSave,
+	events.EventTypeJobApplied:     models.MetricJobApply,
+	events.EventTypeCandidateHired: models.MetricHire,
+}
+
+type profileView struct {
+	companyID uint
+	userID    uint
+}
+
+type AnalyticsService struct {
+	analyticsRepo *repository.AnalyticsRepository
+	companyRepo   *repository.CompanyRepository
+
+	mu      sync.RWMutex
+	views   chan profileView
+	stopped bool
+	done    chan struct{}
+}
+
+func NewAnalyticsService(analyticsRepo *repository.AnalyticsRepository, companyRepo *repository.CompanyRepository) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		companyRepo:   companyRepo,
+		views:         make(chan profileView, profileViewQueueSize),
+		done:          make(chan struct{}),
+	}
+}
+
+// Start records queued profile views in the background
+func (s *AnalyticsService) Start() {
+	go func() {
+		defer close(s.done)
+		for view := range s.views {
+			s.RecordProfileView(view.companyID, view.userID)
+		}
+	}()
+}
+
+// Stop stops queueing profile views and waits for the queued ones to be recorded
+func (s *AnalyticsService) Stop() {
+	s.mu.Lock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.views)
+	}
+	s.mu.Unlock()
+	<-s.done
+}
+
+// TrackProfileView queues a profile view to be recorded by the worker started
+// with Start, without blocking the request. Views are dropped when the queue
+// is full or the service is stopping.
+func (s *AnalyticsService) TrackProfileView(companyID, userID uint) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.stopped {
+		return
+	}
+	select {
+	case s.views <- profileView{companyID: companyID, userID: userID}:
+	default:
+		slog.Warn("Dropped profile view: queue is full", "company_id", companyID)
+	}
+}
+
+// RecordProfileView tracks a view of the public company profile. userID is
+// 0 for anonymous visitors. Failures are logged.
+func (s *AnalyticsService) RecordProfileView(companyID, userID uint) {
+	s.companyRepo.IncrementProfileViews(companyID)
+
+	event := &models.CompanyAnalyticsEvent{
+		CompanyID:  companyID,
+		Metric:     models.MetricProfileView,
+		OccurredAt: time.Now().UTC(),
+	}
+	if userID != 0 {
+		event.UserID = &userID
+	}
+
+	if err := s.analyticsRepo.RecordEvent(event); err != nil {
+		slog.Warn("Failed to record profile view", "company_id", companyID, "error", err)
+	}
+}
+
+// RecordJobEvent records an analytics event published by job-service. Events
+// of unknown types or companies are skipped.
+func (s *AnalyticsService) RecordJobEvent(event *events.AnalyticsEvent) error {
+	metric, ok := analyticsMetrics[event.EventType]
+	if !ok {
+		return nil
+	}
+
+	data := event.Data
+	if _, err := s.companyRepo.GetByID(data.CompanyID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			slog.Warn("Skipping analytics event for unknown company", "event_type", event.EventType, "company_id", data.CompanyID)
+			return nil
+		}
+		return err
+	}
+
+	record := &models.CompanyAnalyticsEvent{
+		CompanyID:  data.CompanyID,
+		JobID:      data.JobID,
+		JobTitle:   data.JobTitle,
+		Metric:     metric,
+		OccurredAt: data.OccurredAt.UTC(),
+	}
+	if event.EventID != "" {
+		eventID := event.EventID
+		record.EventID = &eventID
+	}
+	if data.UserID != 0 {
+		userID := data.UserID
+		record.UserID = &userID
+	}
+	if record.OccurredAt.IsZero() {
+		record.OccurredAt = event.Timestamp.UTC()
+	}
+
+	return s.analyticsRepo.RecordEvent(record)
+}
+
+// GetReport returns the company's time series, totals and per-job funnels.
+// Only the company owner and admins may read analytics.
+func (s *AnalyticsService) GetReport(userID uint, userType string, companyID uint, query models.AnalyticsQuery) (*models.AnalyticsReport, error) {
+	company, err := s.companyRepo.GetByID(companyID)
+	if err != nil {
+		return nil, err
+	}
+	if userType != "admin" && company.UserID != userID {
+		return nil, ErrNotCompanyOwner
+	}
+
+	query, err = normalizeAnalyticsQuery(query, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.AnalyticsReport{
+		CompanyID:   companyID,
+		From:        query.From.Format("2006-01-02"),
+		To:          query.To.Format("2006-01-02"),
+		Granularity: query.Granularity,
+		Series:      []models.AnalyticsPoint{},
+		Funnels:     []models.JobFunnel{},
+	}
+
+	periodCounts, err := s.analyticsRepo.CountsByPeriod(companyID, query.From, query.To, query.Granularity)
+	if err != nil {
+		return nil, err
+	}
+
+	// Zero-fill every period of the range
+	points := make(map[string]*models.AnalyticsPoint)
+	for period := truncatePeriod(query.From, query.Granularity); !period.After(query.To); period = nextPeriod(period, query.Granularity) {
+		date := period.Format("2006-01-02")
+		report.Series = append(report.Series, models.AnalyticsPoint{Date: date})
+		points[date] = &report.Series[len(report.Series)-1]
+	}
+	for _, c := range periodCounts {
+		if point, ok := points[c.Period.Format("2006-01-02")]; ok {
+			point.Add(c.Metric, c.Count)
+		}
+		report.Totals.Add(c.Metric, c.Count)
+	}
+
+	jobCounts, err := s.analyticsRepo.CountsByJob(companyID, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+	report.Funnels, err = s.buildFunnels(companyID, jobCounts)
+	if err != nil {
+		return nil, err
+	}
+
+	if lifetime, err := s.companyRepo.GetAnalytics(companyID); err == nil {
+		report.Lifetime = lifetime
+	}
+
+	return report, nil
+}
+
+func (s *AnalyticsService) buildFunnels(companyID uint, counts []repository.MetricCount) ([]models.JobFunnel, error) {
+	byJob := make(map[uint]*models.JobFunnel)
+	for _, c := range counts {
+		funnel, ok := byJob[c.JobID]
+		if !ok {
+			funnel = &models.JobFunnel{JobID: c.JobID}
+			byJob[c.JobID] = funnel
+		}
+		switch c.Metric {
+		case models.MetricJobView:
+			funnel.Views += c.Count
+		case models.MetricJobSave:
+			funnel.Saves += c.Count
+		case models.MetricJobApply:
+			funnel.Applies += c.Count
+		case models.MetricHire:
+			funnel.Hires += c.Count
+		}
+	}
+
+	jobIDs := make([]uint, 0, len(byJob))
+	for jobID := range byJob {
+		jobIDs = append(jobIDs, jobID)
+	}
+	titles, err := s.analyticsRepo.JobTitles(companyID, jobIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	funnels := make([]models.JobFunnel, 0, len(byJob))
+	for _, funnel := range byJob {
+		funnel.JobTitle = titles[funnel.JobID]
+		funnel.SaveRate = rate(funnel.Saves, funnel.Views)
+		funnel.ApplyRate = rate(funnel.Applies, funnel.Views)
+		funnel.HireRate = rate(funnel.Hires, funnel.Applies)
+		funnels = append(funnels, *funnel)
+	}
+
+	// Most viewed jobs first
+	sort.Slice(funnels, func(i, j int) bool {
+		if funnels[i].Views != funnels[j].Views {
+			return funnels[i].Views > funnels[j].Views
+		}
+		return funnels[i].JobID < funnels[j].JobID
+	})
+
+	return funnels, nil
+}
+
+// normalizeAnalyticsQuery applies defaults (last 30 days, daily buckets) and validates the range
+func normalizeAnalyticsQuery(query models.AnalyticsQuery, now time.Time) (models.AnalyticsQuery, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if query.Granularity == "" {
+		query.Granularity = models.GranularityDay
+	}
+	if !query.Granularity.IsValid() {
+		return query, fmt.Errorf("%w: granularity must be day, week or month", ErrInvalidAnalyticsQuery)
+	}
+
+	if query.To.IsZero() {
+		query.To = today
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-defaultAnalyticsRange)
+	}
+	if query.From.After(query.To) {
+		return query, fmt.Errorf("%w: from must not be after to", ErrInvalidAnalyticsQuery)
+	}
+	if query.To.Sub(query.From) > maxAnalyticsRange {
+		return query, fmt.Errorf("%w: date range must not exceed two years", ErrInvalidAnalyticsQuery)
+	}
+
+	return query, nil
+}
+
+// truncatePeriod returns the start of the period containing t, matching
+// PostgreSQL's date_trunc (weeks start on Monday)
+func truncatePeriod(t time.Time, granularity models.AnalyticsGranularity) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch granularity {
+	case models.GranularityWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case models.GranularityMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+func nextPeriod(t time.Time, granularity models.AnalyticsGranularity) time.Time {
+	switch granularity {
+	case models.GranularityWeek:
+		return t.AddDate(0, 0, 7)
+	case models.GranularityMonth:
+		return t.AddDate(0, 1, 0)
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+// rate returns part/whole rounded to four decimals, 0 if whole is 0 and at
+// most 1
+func rate(part, whole int64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	if part >= whole {
+		return 1
+	}
+	return float64(part*10000/whole) / 10000
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime/multipart"
+	"time"
+
+	"jobfair-company-service/internal/models"
+
+	"github.com/jobfair/shared/storage"
+	"github.com/jobfair/shared/upload"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotCompanyOwner   = errors.New("you do not own this company")
+	ErrMediaNotFound     = errors.New("media not found")
+	ErrInvalidMediaType  = errors.New("invalid media type")
+	ErrInvalidMediaOrder = errors.New("media_ids must contain distinct media of this company")
+)
+
+// mediaKinds maps media types to their upload kind and storage folder
+var mediaKinds = map[string]struct {
+	kind   upload.Kind
+	folder string
+}{
+	models.MediaTypeVideo:   {upload.Video, "videos"},
+	models.MediaTypeGallery: {upload.GalleryImage, "gallery"},
+}
+
+// ListMedia returns the public media of a company in display order
+func (s *CompanyService) ListMedia(companyID uint, mediaType string) ([]models.CompanyMedia, error) {
+	if mediaType != "" {
+		if _, ok := mediaKinds[mediaType]; !ok {
+			return nil, ErrInvalidMediaType
+		}
+	}
+	if _, err := s.companyRepo.GetByID(companyID); err != nil {
+		return nil, err
+	}
+
+	return s.mediaRepo.ListByCompany(companyID, mediaType, true)
+}
+
+func (s *CompanyService) UploadMedia(userID, companyID uint, file *multipart.FileHeader, req *models.UploadCompanyMediaRequest) (*models.CompanyMedia, error) {
+	company, err := s.ownedCompany(userID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createMedia(company, file, req)
+}
+
+func (s *CompanyService) UpdateMedia(userID, companyID, mediaID uint, req *models.UpdateCompanyMediaRequest) (*models.CompanyMedia, error) {
+	company, err := s.ownedCompany(userID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := s.getMedia(companyID, mediaID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		media.Title = *req.Title
+	}
+	if req.Description != nil {
+		media.Description = *req.Description
+	}
+	if req.AltText != nil {
+		media.AltText = *req.AltText
+	}
+	if req.IsPublic != nil {
+		media.IsPublic = *req.IsPublic
+	}
+
+	if err := s.mediaRepo.Update(media); err != nil {
+		return nil, err
+	}
+
+	s.publishUpdated(company)
+
+	return media, nil
+}
+
+// ReorderMedia puts the listed media first, in the given order. Media not
+// listed follow them and keep their position relative to each other.
+func (s *CompanyService) ReorderMedia(userID, companyID uint, req *models.ReorderCompanyMediaRequest) ([]models.CompanyMedia, error) {
+	company, err := s.ownedCompany(userID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := s.mediaRepo.ListByCompany(companyID, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make(map[uint]bool, len(all))
+	for _, m := range all {
+		owned[m.ID] = true
+	}
+
+	seen := make(map[uint]bool, len(req.MediaIDs))
+	for _, id := range req.MediaIDs {
+		if !owned[id] || seen[id] {
+			return nil, ErrInvalidMediaOrder
+		}
+		seen[id] = true
+	}
+
+	// Unlisted media follow the listed ones in their current order
+	ids := append([]uint{}, req.MediaIDs...)
+	for _, m := range all {
+		if !seen[m.ID] {
+			ids = append(ids, m.ID)
+		}
+	}
+
+	if err := s.mediaRepo.Reorder(companyID, ids); err != nil {
+		return nil, err
+	}
+
+	s.publishUpdated(company)
+
+	return s.mediaRepo.ListByCompany(companyID, "", false)
+}
+
+// DeleteMedia removes a media item together with its stored file and thumbnail
+func (s *CompanyService) DeleteMedia(userID, companyID, mediaID uint) error {
+	company, err := s.ownedCompany(userID, companyID)
+	if err != nil {
+		return err
+	}
+
+	media, err := s.getMedia(companyID, mediaID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.mediaRepo.Delete(companyID, mediaID); err != nil {
+		return err
+	}
+
+	if mk, ok := mediaKinds[media.MediaType]; ok {
+		if err := s.uploads.RemoveURL(context.Background(), media.FileURL, mk.kind); err != nil {
+			slog.Warn("Failed to delete company media file", "url", media.FileURL, "error", err)
+		}
+	}
+
+	s.publishUpdated(company)
+
+	return nil
+}
+
+// createMedia stores an uploaded video or gallery image and appends it to the company's media
+func (s *CompanyService) createMedia(company *models.Company, file *multipart.FileHeader, req *models.UploadCompanyMediaRequest) (*models.CompanyMedia, error) {
+	mk, ok := mediaKinds[req.MediaType]
+	if !ok {
+		return nil, ErrInvalidMediaType
+	}
+
+	// Nanoseconds keep keys unique when several gallery images are uploaded at once
+	filename := fmt.Sprintf("%d_%s_%d", company.ID, req.MediaType, time.Now().UnixNano())
+	key := storage.Key("companies", mk.folder, filename)
+
+	ctx := context.Background()
+	result, err := s.uploads.ProcessFile(ctx, file, mk.kind, key)
+	if err != nil {
+		slog.Warn("Failed to store company media file", "company_id", company.ID, "error", err)
+		if upload.IsRejected(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to save file: %w", err)
+	}
+
+	media := &models.CompanyMedia{
+		CompanyID:    company.ID,
+		MediaType:    req.MediaType,
+		FileName:     file.Filename,
+		FileURL:      result.URL,
+		FileSize:     result.Size,
+		MimeType:     result.ContentType,
+		ThumbnailURL: result.Variants["thumb"],
+		Title:        req.Title,
+		Description:  req.Description,
+		AltText:      req.AltText,
+		IsPublic:     true,
+		UploadedAt:   time.Now(),
+	}
+
+	if err := s.mediaRepo.Create(media); err != nil {
+		// Cleanup uploaded file if the media record can't be saved
+		s.uploads.Remove(ctx, result.Key, mk.kind)
+		slog.Error("Failed to save company media, file removed", "company_id", company.ID, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Company media stored", "media_type", media.MediaType, "media_id", media.ID, "url", media.FileURL)
+
+	s.publishUpdated(company)
+
+	return media, nil
+}
+
+// attachMedia loads the company's media in display order and fills the
+// derived video/gallery URL lists
+func (s *CompanyService) attachMedia(company *models.Company, publicOnly bool) error {
+	media, err := s.mediaRepo.ListByCompany(company.ID, "", publicOnly)
+	if err != nil {
+		return err
+	}
+
+	company.Media = media
+	company.VideoURLs = nil
+	company.GalleryURLs = nil
+	for _, m := range media {
+		switch m.MediaType {
+		case models.MediaTypeVideo:
+			company.VideoURLs = append(company.VideoURLs, m.FileURL)
+		case models.MediaTypeGallery:
+			company.GalleryURLs = append(company.GalleryURLs, m.FileURL)
+		}
+	}
+
+	return nil
+}
+
+func (s *CompanyService) ownedCompany(userID, companyID uint) (*models.Company, error) {
+	company, err := s.companyRepo.GetByID(companyID)
+	if err != nil {
+		return nil, err
+	}
+	if company.UserID != userID {
+		return nil, ErrNotCompanyOwner
+	}
+	return company, nil
+}
+
+func (s *CompanyService) getMedia(companyID, mediaID uint) (*models.CompanyMedia, error) {
+	media, err := s.mediaRepo.GetByID(companyID, mediaID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMediaNotFound
+		}
+		return nil, err
+	}
+	return media, nil
+}
@@ -1,28 +1,35 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"io"
+	"log/slog"
 	"mime/multipart"
-	"os"
-	"path/filepath"
-	"strings"
 	"time"
 
 	"jobfair-company-service/internal/models"
 	"jobfair-company-service/internal/repository"
 
 	"github.com/gosimple/slug"
+	"github.com/jobfair/shared/events"
+	"github.com/jobfair/shared/storage"
+	"github.com/jobfair/shared/upload"
 )
 
 type CompanyService struct {
-	companyRepo *repository.CompanyRepository
+	companyRepo    *repository.CompanyRepository
+	mediaRepo      *repository.CompanyMediaRepository
+	uploads        *upload.Pipeline
+	eventPublisher *events.Publisher
 }
 
-func NewCompanyService(companyRepo *repository.CompanyRepository) *CompanyService {
+func NewCompanyService(companyRepo *repository.CompanyRepository, mediaRepo *repository.CompanyMediaRepository, uploads *upload.Pipeline, eventPublisher *events.Publisher) *CompanyService {
 	return &CompanyService{
-		companyRepo: companyRepo,
+		companyRepo:    companyRepo,
+		mediaRepo:      mediaRepo,
+		uploads:        uploads,
+		eventPublisher: eventPublisher,
 	}
 }
 
@@ -63,6 +70,8 @@ func (s *CompanyService) CreateCompany(userID uint, req *models.CreateCompanyReq
 	}
 	s.companyRepo.UpdateAnalytics(analytics)
 
+	s.publishUpdated(createdCompany)
+
 	return createdCompany, nil
 }
 
@@ -72,14 +81,25 @@ func (s *CompanyService) GetCompany(id uint) (*models.Company, error) {
 		return nil, err
 	}
 
-	// Increment profile views
-	s.companyRepo.IncrementProfileViews(id)
+	// Public profile only shows public media, in display order
+	if err := s.attachMedia(company, true); err != nil {
+		return nil, err
+	}
 
 	return company, nil
 }
 
 func (s *CompanyService) GetCompanyByUserID(userID uint) (*models.Company, error) {
-	return s.companyRepo.GetByUserID(userID)
+	company, err := s.companyRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachMedia(company, false); err != nil {
+		return nil, err
+	}
+
+	return company, nil
 }
 
 func (s *CompanyService) UpdateCompany(id uint, req *models.UpdateCompanyRequest) (*models.Company, error) {
@@ -149,6 +169,8 @@ func (s *CompanyService) UpdateCompany(id uint, req *models.UpdateCompanyRequest
 		return nil, err
 	}
 
+	s.publishUpdated(company)
+
 	return company, nil
 }
 
@@ -158,64 +180,45 @@ func (s *CompanyService) UploadFile(companyID uint, file *multipart.FileHeader,
 		return "", err
 	}
 
-	if err := s.validateFile(file, fileType); err != nil {
-		return "", err
+	// Videos and gallery images are company media items
+	if fileType == models.MediaTypeVideo || fileType == models.MediaTypeGallery {
+		media, err := s.createMedia(company, file, &models.UploadCompanyMediaRequest{MediaType: fileType})
+		if err != nil {
+			return "", err
+		}
+		return media.FileURL, nil
 	}
 
-	// Determine upload directory based on file type
-	var uploadDir string
-	var urlPrefix string
+	// Determine storage folder and upload kind based on file type
+	var folder string
+	var kind upload.Kind
 
 	switch fileType {
 	case "logo":
-		uploadDir = "./uploads/companies/logos"
-		urlPrefix = "/uploads/companies/logos"
+		folder, kind = "logos", upload.CompanyLogo
 	case "banner":
-		uploadDir = "./uploads/companies/banners"
-		urlPrefix = "/uploads/companies/banners"
-	case "video":
-		uploadDir = "./uploads/companies/videos"
-		urlPrefix = "/uploads/companies/videos"
-	case "gallery":
-		uploadDir = "./uploads/companies/gallery"
-		urlPrefix = "/uploads/companies/gallery"
+		folder, kind = "banners", upload.Banner
 	default:
 		return "", errors.New("invalid file type")
 	}
 
-	// Create upload directory if not exists
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create upload directory: %w", err)
-	}
-
-	// Generate filename
-	filename := fmt.Sprintf("%d_%s_%d%s", companyID, fileType, time.Now().Unix(), filepath.Ext(file.Filename))
-	filePath := filepath.Join(uploadDir, filename)
-	url := fmt.Sprintf("%s/%s", urlPrefix, filename)
-
-	fmt.Printf("[Company Upload] Saving %s file to: %s\n", fileType, filePath)
+	// Generate object key; the extension is derived from the file content
+	filename := fmt.Sprintf("%d_%s_%d", companyID, fileType, time.Now().Unix())
+	key := storage.Key("companies", folder, filename)
 
-	// Save file to disk
-	src, err := file.Open()
+	// Validate, scan and store the file (with thumbnails for images)
+	ctx := context.Background()
+	result, err := s.uploads.ProcessFile(ctx, file, kind, key)
 	if err != nil {
-		return "", fmt.Errorf("failed to open uploaded file: %w", err)
-	}
-	defer src.Close()
-
-	dst, err := os.Create(filePath)
-	if err != nil {
-		return "", fmt.Errorf("failed to create file: %w", err)
-	}
-	defer dst.Close()
-
-	if _, err := io.Copy(dst, src); err != nil {
-		fmt.Printf("[Company Upload ERROR] Failed to copy file: %v\n", err)
+		slog.Warn("Failed to store company file", "company_id", companyID, "file_type", fileType, "error", err)
+		if upload.IsRejected(err) {
+			return "", err
+		}
 		return "", fmt.Errorf("failed to save file: %w", err)
 	}
+	url := result.URL
 
-	fmt.Printf("[Company Upload] File saved successfully: %s\n", filePath)
-
-	// Delete old file if updating
+	// Remember the replaced logo/banner so it can be removed after the update
 	var oldURL string
 	switch fileType {
 	case "logo":
@@ -224,68 +227,55 @@ func (s *CompanyService) UploadFile(companyID uint, file *multipart.FileHeader,
 	case "banner":
 		oldURL = company.BannerURL
 		company.BannerURL = url
-	case "video":
-		company.VideoURLs = append(company.VideoURLs, url)
-	case "gallery":
-		company.GalleryURLs = append(company.GalleryURLs, url)
-	}
-
-	// Delete old file from disk
-	if oldURL != "" && (fileType == "logo" || fileType == "banner") {
-		// Extract path from URL
-		// oldURL format: /uploads/companies/logos/1_logo_xxx.jpg
-		oldPath := "." + oldURL // Convert to relative path
-		os.Remove(oldPath)
-		fmt.Printf("[Company Upload] Deleted old file: %s\n", oldPath)
 	}
 
 	// Update company in database
 	if err := s.companyRepo.Update(company); err != nil {
 		// Cleanup uploaded file if database update fails
-		os.Remove(filePath)
-		fmt.Printf("[Company Upload ERROR] Database update failed, file removed: %v\n", err)
+		s.uploads.Remove(ctx, result.Key, kind)
+		slog.Error("Failed to save company file, file removed", "company_id", companyID, "file_type", fileType, "error", err)
 		return "", err
 	}
 
-	fmt.Printf("[Company Upload] ✅ Upload successful! URL: %s\n", url)
+	// Delete old logo/banner (and its variants) once the company points at the new one
+	if oldURL != "" && oldURL != url {
+		s.uploads.RemoveURL(ctx, oldURL, kind)
+		slog.Debug("Old company file deleted", "url", oldURL)
+	}
 
-	return url, nil
-}
+	slog.Info("Company file uploaded", "company_id", companyID, "file_type", fileType, "url", url)
 
-func (s *CompanyService) validateFile(file *multipart.FileHeader, fileType string) error {
-	maxSize := int64(10 * 1024 * 1024) // 10MB default
-	if fileType == "video" {
-		maxSize = int64(50 * 1024 * 1024) // 50MB for videos
-	}
+	s.publishUpdated(company)
 
-	if file.Size > maxSize {
-		return fmt.Errorf("file size too large (max %dMB)", maxSize/(1024*1024))
-	}
+	return url, nil
+}
 
-	ext := strings.ToLower(filepath.Ext(file.Filename))
-	var allowed []string
+func (s *CompanyService) ListCompanies(limit, offset int, filters map[string]interface{}) ([]*models.Company, int64, error) {
+	return s.companyRepo.List(limit, offset, filters)
+}
 
-	switch fileType {
-	case "logo", "banner", "gallery":
-		allowed = []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
-	case "video":
-		allowed = []string{".mp4", ".avi", ".mov", ".webm"}
-	default:
-		return errors.New("invalid file type")
+// publishUpdated announces a change of the company's public profile, so
+// cached companies and job listings showing it are refreshed. The stored tier
+// is included so job-service knows the plan of companies without
+// subscription periods.
+func (s *CompanyService) publishUpdated(company *models.Company) {
+	if s.eventPublisher == nil {
+		return
 	}
 
-	for _, a := range allowed {
-		if ext == a {
-			return nil
-		}
+	data := events.CompanyUpdatedData{
+		UserID: company.UserID,
+		UpdatedFields: map[string]interface{}{
+			"company_id":        company.ID,
+			"company_name":      company.Name,
+			"subscription_tier": company.SubscriptionTier,
+		},
 	}
-	return errors.New("invalid file format")
-}
 
-func (s *CompanyService) GetAnalytics(companyID uint) (*models.CompanyAnalytics, error) {
-	return s.companyRepo.GetAnalytics(companyID)
-}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-func (s *CompanyService) ListCompanies(limit, offset int, filters map[string]interface{}) ([]*models.Company, int64, error) {
-	return s.companyRepo.List(limit, offset, filters)
+	if err := s.eventPublisher.PublishCompanyUpdated(ctx, data); err != nil {
+		slog.Warn("Failed to publish company.updated event", "company_id", company.ID, "error", err)
+	}
 }
@@ -0,0 +1,15 @@
+package services
+
+import "fmt"
+
+// Tags of cached responses (see httpcache). Listings carry CacheTagCompanies;
+// profiles carry CacheTagCompanyProfiles and the tag of their company.
+const (
+	CacheTagCompanies       = "companies"
+	CacheTagCompanyProfiles = "company_profiles"
+)
+
+// CompanyCacheTag tags the cached profile of the company
+func CompanyCacheTag(companyID uint) string {
+	return fmt.Sprintf("company:%d", companyID)
+}
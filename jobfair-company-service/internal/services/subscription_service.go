@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/repository"
+
+	"github.com/jobfair/shared/events"
+	"github.com/jobfair/shared/plans"
+)
+
+var (
+	ErrInvalidSubscriptionTier   = errors.New("unknown subscription tier")
+	ErrInvalidSubscriptionPeriod = errors.New("effective_until must be in the future and after effective_from")
+)
+
+type SubscriptionService struct {
+	subscriptionRepo *repository.SubscriptionRepository
+	companyRepo      *repository.CompanyRepository
+	eventPublisher   *events.Publisher
+}
+
+// NewSubscriptionService creates a subscription service. eventPublisher may
+// be nil, in which case subscription changes are not published.
+func NewSubscriptionService(
+	subscriptionRepo *repository.SubscriptionRepository,
+	companyRepo *repository.CompanyRepository,
+	eventPublisher *events.Publisher,
+) *SubscriptionService {
+	return &SubscriptionService{
+		subscriptionRepo: subscriptionRepo,
+		companyRepo:      companyRepo,
+		eventPublisher:   eventPublisher,
+	}
+}
+
+// Plans returns the plan catalog
+func (s *SubscriptionService) Plans() []plans.Plan {
+	return plans.All()
+}
+
+// GetOverview returns the company's current plan and subscription periods.
+// Only the company owner and admins may read them.
+func (s *SubscriptionService) GetOverview(userID uint, userType string, companyID uint) (*models.SubscriptionOverview, error) {
+	company, err := s.companyRepo.GetByID(companyID)
+	if err != nil {
+		return nil, err
+	}
+	if userType != "admin" && company.UserID != userID {
+		return nil, ErrNotCompanyOwner
+	}
+
+	subscriptions, err := s.subscriptionRepo.ListByCompany(companyID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.subscriptionRepo.GetEffective(companyID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Companies without subscription periods keep their stored tier
+	tier := company.SubscriptionTier
+	if current != nil {
+		tier = current.Tier
+	} else if len(subscriptions) > 0 {
+		tier = models.SubscriptionFree
+	}
+
+	return &models.SubscriptionOverview{
+		CompanyID:     companyID,
+		Plan:          plans.Lookup(plans.Tier(tier)),
+		Current:       current,
+		Subscriptions: subscriptions,
+	}, nil
+}
+
+// ChangeSubscription moves the company to a tier for the requested period.
+// Changes effective now update the company right away; later ones are applied
+// by the subscription scheduler. Every change is published so job-service can
+// enforce the plan's limits.
+func (s *SubscriptionService) ChangeSubscription(adminID, companyID uint, req *models.ChangeSubscriptionRequest) (*models.CompanySubscription, error) {
+	plan, ok := plans.Get(plans.Tier(req.Tier))
+	if !ok {
+		return nil, ErrInvalidSubscriptionTier
+	}
+
+	company, err := s.companyRepo.GetByID(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	effectiveFrom := now
+	if req.EffectiveFrom != nil {
+		effectiveFrom = *req.EffectiveFrom
+	}
+	if req.EffectiveUntil != nil && (!req.EffectiveUntil.After(effectiveFrom) || !req.EffectiveUntil.After(now)) {
+		return nil, ErrInvalidSubscriptionPeriod
+	}
+
+	subscription := &models.CompanySubscription{
+		CompanyID:      companyID,
+		Tier:           req.Tier,
+		EffectiveFrom:  effectiveFrom,
+		EffectiveUntil: req.EffectiveUntil,
+		Reason:         req.Reason,
+		ChangedBy:      adminID,
+	}
+	if err := s.subscriptionRepo.Create(subscription); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.subscriptionRepo.SyncTiers(now, premiumTiers(), companyID); err != nil {
+		slog.Warn("Failed to sync subscription tier", "company_id", companyID, "error", err)
+	}
+
+	slog.Info("Subscription changed", "company_id", companyID, "tier", req.Tier,
+		"effective_from", effectiveFrom.Format(time.RFC3339), "admin_id", adminID)
+
+	s.publishChange(company, subscription, plan)
+
+	return subscription, nil
+}
+
+// ApplyDueChanges syncs the stored tier of companies whose subscription
+// period started or ended. It is called periodically by the subscription
+// scheduler and returns the number of companies updated.
+func (s *SubscriptionService) ApplyDueChanges() (int64, error) {
+	return s.subscriptionRepo.SyncTiers(time.Now(), premiumTiers())
+}
+
+// publishChange publishes a company.subscription_changed event. Failures are
+// logged and do not fail the change.
+func (s *SubscriptionService) publishChange(company *models.Company, subscription *models.CompanySubscription, plan plans.Plan) {
+	if s.eventPublisher == nil {
+		return
+	}
+
+	data := events.CompanySubscriptionChangedData{
+		SubscriptionID: subscription.ID,
+		CompanyID:      company.ID,
+		UserID:         company.UserID,
+		Tier:           plan.Tier,
+		Limits:         plan.Limits,
+		EffectiveFrom:  subscription.EffectiveFrom,
+		EffectiveUntil: subscription.EffectiveUntil,
+		ChangedBy:      subscription.ChangedBy,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.eventPublisher.PublishCompanySubscriptionChanged(ctx, data); err != nil {
+		slog.Warn("Failed to publish company.subscription_changed event", "company_id", company.ID, "error", err)
+	}
+}
+
+// premiumTiers returns the tiers that set Company.IsPremium
+func premiumTiers() []string {
+	var tiers []string
+	for _, plan := range plans.All() {
+		if plan.Premium {
+			tiers = append(tiers, string(plan.Tier))
+		}
+	}
+	return tiers
+}
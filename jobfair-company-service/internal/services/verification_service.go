@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"path"
+	"time"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/repository"
+
+	"github.com/jobfair/shared/events"
+	"github.com/jobfair/shared/storage"
+	"github.com/jobfair/shared/upload"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAlreadyVerified      = errors.New("company is already verified")
+	ErrVerificationPending  = errors.New("a verification request is already waiting for review")
+	ErrVerificationNotFound = errors.New("verification request not found")
+	ErrVerificationReviewed = repository.ErrAlreadyReviewed
+	ErrInvalidDocument      = errors.New("invalid verification document")
+)
+
+// verificationDocument is the upload kind for business registration and tax
+// documents. They are stored under verification/ which, unlike companies/,
+// is never served publicly; admins download them through the API.
+var verificationDocument = upload.Kind{
+	Name:         "verification_document",
+	MaxSize:      10 << 20,
+	AllowedTypes: []string{upload.TypePDF, upload.TypeJPEG, upload.TypePNG},
+}
+
+// VerificationDocumentFile is an open verification document
+type VerificationDocumentFile struct {
+	Reader      io.ReadCloser
+	FileName    string
+	ContentType string
+	Size        int64
+}
+
+type VerificationService struct {
+	verificationRepo *repository.VerificationRepository
+	companyRepo      *repository.CompanyRepository
+	store            storage.Store
+	uploads          *upload.Pipeline
+	eventPublisher   *events.Publisher
+}
+
+// NewVerificationService creates a verification service. eventPublisher may be
+// nil, in which case company.verified events are not published.
+func NewVerificationService(
+	verificationRepo *repository.VerificationRepository,
+	companyRepo *repository.CompanyRepository,
+	store storage.Store,
+	uploads *upload.Pipeline,
+	eventPublisher *events.Publisher,
+) *VerificationService {
+	return &VerificationService{
+		verificationRepo: verificationRepo,
+		companyRepo:      companyRepo,
+		store:            store,
+		uploads:          uploads,
+		eventPublisher:   eventPublisher,
+	}
+}
+
+// Submit stores the verification documents and queues the request for review
+func (s *VerificationService) Submit(userID, companyID uint, req *models.SubmitVerificationRequest, registrationDoc, taxDoc *multipart.FileHeader) (*models.CompanyVerification, error) {
+	company, err := s.companyRepo.GetByID(companyID)
+	if err != nil {
+		return nil, err
+	}
+	if company.UserID != userID {
+		return nil, ErrNotCompanyOwner
+	}
+	if company.IsVerified {
+		return nil, ErrAlreadyVerified
+	}
+
+	pending, err := s.verificationRepo.HasPending(companyID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, ErrVerificationPending
+	}
+
+	ctx := context.Background()
+	stamp := time.Now().Unix()
+
+	registration, err := s.storeDocument(ctx, companyID, stamp, models.VerificationDocumentRegistration, registrationDoc)
+	if err != nil {
+		return nil, err
+	}
+	tax, err := s.storeDocument(ctx, companyID, stamp, models.VerificationDocumentTax, taxDoc)
+	if err != nil {
+		s.uploads.Remove(ctx, registration.Key, verificationDocument)
+		return nil, err
+	}
+
+	verification := &models.CompanyVerification{
+		CompanyID:                companyID,
+		SubmittedBy:              userID,
+		Status:                   models.VerificationPending,
+		RegistrationNumber:       req.RegistrationNumber,
+		TaxID:                    req.TaxID,
+		RegistrationDocumentKey:  registration.Key,
+		RegistrationDocumentName: registrationDoc.Filename,
+		TaxDocumentKey:           tax.Key,
+		TaxDocumentName:          taxDoc.Filename,
+	}
+
+	if err := s.verificationRepo.Create(verification); err != nil {
+		// Cleanup uploaded documents if the request can't be saved
+		s.uploads.Remove(ctx, registration.Key, verificationDocument)
+		s.uploads.Remove(ctx, tax.Key, verificationDocument)
+		return nil, err
+	}
+
+	slog.Info("Verification request submitted", "company_id", companyID, "verification_id", verification.ID)
+
+	return verification, nil
+}
+
+// GetLatest returns the company's most recent verification request
+func (s *VerificationService) GetLatest(userID, companyID uint) (*models.CompanyVerification, error) {
+	company, err := s.companyRepo.GetByID(companyID)
+	if err != nil {
+		return nil, err
+	}
+	if company.UserID != userID {
+		return nil, ErrNotCompanyOwner
+	}
+
+	verification, err := s.verificationRepo.GetLatestByCompany(companyID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrVerificationNotFound
+	}
+	return verification, err
+}
+
+// ListQueue returns verification requests for admins, pending ones by default
+func (s *VerificationService) ListQueue(status models.VerificationStatus, limit, offset int) ([]*models.CompanyVerification, int64, error) {
+	return s.verificationRepo.List(status, limit, offset)
+}
+
+func (s *VerificationService) Get(id uint) (*models.CompanyVerification, error) {
+	verification, err := s.verificationRepo.GetByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrVerificationNotFound
+	}
+	return verification, err
+}
+
+// OpenDocument opens one of the documents of a verification request
+func (s *VerificationService) OpenDocument(id uint, document string) (*VerificationDocumentFile, error) {
+	verification, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var key, name string
+	switch document {
+	case models.VerificationDocumentRegistration:
+		key, name = verification.RegistrationDocumentKey, verification.RegistrationDocumentName
+	case models.VerificationDocumentTax:
+		key, name = verification.TaxDocumentKey, verification.TaxDocumentName
+	default:
+		return nil, ErrInvalidDocument
+	}
+
+	reader, info, err := s.store.Get(context.Background(), key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrVerificationNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	contentType := info.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(key))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	if name == "" {
+		name = document + path.Ext(key)
+	}
+
+	return &VerificationDocumentFile{
+		Reader:      reader,
+		FileName:    name,
+		ContentType: contentType,
+		Size:        info.Size,
+	}, nil
+}
+
+// Approve marks the company as verified and publishes the result
+func (s *VerificationService) Approve(adminID, id uint) (*models.CompanyVerification, error) {
+	return s.review(adminID, id, models.VerificationApproved, "")
+}
+
+// Reject rejects the request with a reason shown to the company and publishes the result
+func (s *VerificationService) Reject(adminID, id uint, reason string) (*models.CompanyVerification, error) {
+	return s.review(adminID, id, models.VerificationRejected, reason)
+}
+
+func (s *VerificationService) review(adminID, id uint, status models.VerificationStatus, reason string) (*models.CompanyVerification, error) {
+	verification, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if verification.Status != models.VerificationPending {
+		return nil, ErrVerificationReviewed
+	}
+
+	now := time.Now()
+	if err := s.verificationRepo.Review(verification, adminID, status, reason, now); err != nil {
+		return nil, err
+	}
+
+	if verification.Company != nil && status == models.VerificationApproved {
+		verification.Company.IsVerified = true
+		verification.Company.VerifiedAt = &now
+		verification.Company.VerificationBadge = models.VerificationBadgeVerified
+	}
+
+	slog.Info("Verification request reviewed", "verification_id", verification.ID, "status", status, "admin_id", adminID)
+
+	s.publishResult(verification)
+
+	return verification, nil
+}
+
+// publishResult publishes a company.verified event. Failures are logged and
+// do not fail the review.
+func (s *VerificationService) publishResult(verification *models.CompanyVerification) {
+	if s.eventPublisher == nil {
+		return
+	}
+
+	data := events.CompanyVerifiedData{
+		CompanyID: verification.CompanyID,
+		Verified:  verification.Status == models.VerificationApproved,
+		Status:    string(verification.Status),
+		Reason:    verification.RejectionReason,
+	}
+	if verification.ReviewedAt != nil {
+		data.ReviewedAt = *verification.ReviewedAt
+	}
+	if verification.Company != nil {
+		data.UserID = verification.Company.UserID
+		data.CompanyName = verification.Company.Name
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.eventPublisher.PublishCompanyVerified(ctx, data); err != nil {
+		slog.Warn("Failed to publish company.verified event", "company_id", verification.CompanyID, "error", err)
+	}
+}
+
+func (s *VerificationService) storeDocument(ctx context.Context, companyID uint, stamp int64, document string, file *multipart.FileHeader) (*upload.Result, error) {
+	key := storage.Key("verification", fmt.Sprint(companyID), fmt.Sprintf("%d_%s", stamp, document))
+
+	result, err := s.uploads.ProcessFile(ctx, file, verificationDocument, key)
+	if err != nil {
+		if upload.IsRejected(err) {
+			return nil, fmt.Errorf("%s: %w", document, err)
+		}
+		return nil, fmt.Errorf("failed to save %s: %w", document, err)
+	}
+	return result, nil
+}
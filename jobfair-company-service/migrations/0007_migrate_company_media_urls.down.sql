@@ -0,0 +1,24 @@
+DROP INDEX IF EXISTS idx_company_media_company_order;
+
+ALTER TABLE companies
+ADD COLUMN IF NOT EXISTS video_urls TEXT[],
+ADD COLUMN IF NOT EXISTS gallery_urls TEXT[];
+
+-- Restore the arrays from company_media in display order
+UPDATE companies c SET
+    video_urls = (
+        SELECT array_agg(m.file_url ORDER BY m.display_order, m.id)
+        FROM company_media m
+        WHERE m.company_id = c.id AND m.media_type = 'video' AND m.deleted_at IS NULL
+    ),
+    gallery_urls = (
+        SELECT array_agg(m.file_url ORDER BY m.display_order, m.id)
+        FROM company_media m
+        WHERE m.company_id = c.id AND m.media_type = 'gallery' AND m.deleted_at IS NULL
+    );
+
+DELETE FROM company_media WHERE media_type IN ('video', 'gallery');
+
+CREATE INDEX IF NOT EXISTS idx_companies_video_urls ON companies USING GIN (video_urls);
+
+COMMENT ON COLUMN companies.video_urls IS 'Array of video URLs for virtual booth';
@@ -0,0 +1,24 @@
+-- Move video and gallery URLs from the companies arrays into company_media
+INSERT INTO company_media (company_id, media_type, file_name, file_url, display_order, uploaded_at)
+SELECT c.id, 'video', LEFT(regexp_replace(u.url, '^.*/', ''), 255), u.url, u.ord - 1, c.updated_at
+FROM companies c
+CROSS JOIN LATERAL unnest(c.video_urls) WITH ORDINALITY AS u(url, ord)
+WHERE u.url IS NOT NULL AND u.url <> '';
+
+INSERT INTO company_media (company_id, media_type, file_name, file_url, display_order, uploaded_at)
+SELECT c.id, 'gallery', LEFT(regexp_replace(u.url, '^.*/', ''), 255), u.url, u.ord - 1, c.updated_at
+FROM companies c
+CROSS JOIN LATERAL unnest(c.gallery_urls) WITH ORDINALITY AS u(url, ord)
+WHERE u.url IS NOT NULL AND u.url <> '';
+
+-- company_media is now the only source of videos and gallery images
+DROP INDEX IF EXISTS idx_companies_video_urls;
+
+ALTER TABLE companies
+DROP COLUMN IF EXISTS video_urls,
+DROP COLUMN IF EXISTS gallery_urls;
+
+-- Media is listed per company in display order
+CREATE INDEX IF NOT EXISTS idx_company_media_company_order
+ON company_media(company_id, display_order, id)
+WHERE deleted_at IS NULL;
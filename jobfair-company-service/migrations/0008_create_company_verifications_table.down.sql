@@ -0,0 +1,10 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_company_verifications_updated_at ON company_verifications;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_company_verifications_one_pending;
+DROP INDEX IF EXISTS idx_company_verifications_status_created;
+DROP INDEX IF EXISTS idx_company_verifications_company_id;
+
+-- Drop table
+DROP TABLE IF EXISTS company_verifications;
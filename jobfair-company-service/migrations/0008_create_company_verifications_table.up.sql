@@ -0,0 +1,47 @@
+-- Verification requests submitted by companies and reviewed by admins
+CREATE TABLE IF NOT EXISTS company_verifications (
+    id SERIAL PRIMARY KEY,
+    company_id INTEGER NOT NULL,
+    submitted_by INTEGER NOT NULL,
+
+    -- Review Status
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+
+    -- Submitted Documents (object storage keys, never served publicly)
+    registration_number VARCHAR(100) NOT NULL,
+    tax_id VARCHAR(100) NOT NULL,
+    registration_document_key VARCHAR(500) NOT NULL,
+    registration_document_name VARCHAR(255),
+    tax_document_key VARCHAR(500) NOT NULL,
+    tax_document_name VARCHAR(255),
+
+    -- Review
+    rejection_reason TEXT,
+    reviewed_by INTEGER,
+    reviewed_at TIMESTAMP,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Foreign Key
+    CONSTRAINT fk_company_verifications_company_id
+        FOREIGN KEY (company_id)
+        REFERENCES companies(id)
+        ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX idx_company_verifications_company_id ON company_verifications(company_id);
+CREATE INDEX idx_company_verifications_status_created ON company_verifications(status, created_at);
+
+-- A company can only have one request waiting for review
+CREATE UNIQUE INDEX idx_company_verifications_one_pending
+ON company_verifications(company_id)
+WHERE status = 'pending';
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_company_verifications_updated_at BEFORE UPDATE ON company_verifications
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE company_verifications IS 'Company verification requests (business registration, tax ID) reviewed by admins';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS company_analytics_daily;
+
+DROP INDEX IF EXISTS idx_company_analytics_events_job;
+DROP INDEX IF EXISTS idx_company_analytics_events_company_occurred;
+DROP INDEX IF EXISTS idx_company_analytics_events_event_id;
+DROP TABLE IF EXISTS company_analytics_events;
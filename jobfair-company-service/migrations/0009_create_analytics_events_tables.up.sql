@@ -0,0 +1,40 @@
+-- Raw analytics events (profile views, job views, saves, applies, hires)
+CREATE TABLE IF NOT EXISTS company_analytics_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(64), -- id of the source event, NULL for events recorded by this service
+    company_id INTEGER NOT NULL,
+    job_id INTEGER NOT NULL DEFAULT 0, -- 0 for company-level events
+    job_title VARCHAR(255),
+    metric VARCHAR(30) NOT NULL CHECK (metric IN ('profile_view', 'job_view', 'job_save', 'job_apply', 'hire')),
+    user_id INTEGER,
+    occurred_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_company_analytics_events_company_id
+        FOREIGN KEY (company_id)
+        REFERENCES companies(id)
+        ON DELETE CASCADE
+);
+
+-- Redelivered events are recorded once
+CREATE UNIQUE INDEX idx_company_analytics_events_event_id ON company_analytics_events(event_id) WHERE event_id IS NOT NULL;
+CREATE INDEX idx_company_analytics_events_company_occurred ON company_analytics_events(company_id, occurred_at);
+CREATE INDEX idx_company_analytics_events_job ON company_analytics_events(job_id, occurred_at) WHERE job_id <> 0;
+
+-- Daily buckets aggregated from the events (UTC dates)
+CREATE TABLE IF NOT EXISTS company_analytics_daily (
+    company_id INTEGER NOT NULL,
+    job_id INTEGER NOT NULL DEFAULT 0,
+    bucket_date DATE NOT NULL,
+    metric VARCHAR(30) NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (company_id, bucket_date, job_id, metric),
+
+    CONSTRAINT fk_company_analytics_daily_company_id
+        FOREIGN KEY (company_id)
+        REFERENCES companies(id)
+        ON DELETE CASCADE
+);
+
+COMMENT ON TABLE company_analytics_events IS 'Event-level company analytics';
+COMMENT ON TABLE company_analytics_daily IS 'Company analytics aggregated per day, job and metric';
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_company_subscriptions_updated_at ON company_subscriptions;
+DROP TABLE IF EXISTS company_subscriptions;
@@ -0,0 +1,37 @@
+-- Subscription tier changes made by admins. The tier in effect is the latest
+-- period that has started and not ended; companies.subscription_tier is kept
+-- in sync with it.
+CREATE TABLE IF NOT EXISTS company_subscriptions (
+    id SERIAL PRIMARY KEY,
+    company_id INTEGER NOT NULL,
+    tier VARCHAR(50) NOT NULL CHECK (tier IN ('free', 'basic', 'premium', 'pro')),
+
+    -- Effective Period (effective_until NULL = open-ended)
+    effective_from TIMESTAMP NOT NULL,
+    effective_until TIMESTAMP,
+
+    reason TEXT,
+    changed_by INTEGER NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_company_subscriptions_period
+        CHECK (effective_until IS NULL OR effective_until > effective_from),
+
+    -- Foreign Key
+    CONSTRAINT fk_company_subscriptions_company_id
+        FOREIGN KEY (company_id)
+        REFERENCES companies(id)
+        ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX idx_company_subscriptions_company_from ON company_subscriptions(company_id, effective_from DESC);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_company_subscriptions_updated_at BEFORE UPDATE ON company_subscriptions
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE company_subscriptions IS 'Subscription tier periods set by admins; plan limits come from the shared plan catalog';
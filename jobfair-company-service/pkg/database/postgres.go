@@ -2,12 +2,13 @@ package database
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
+	"github.com/jobfair/shared/logging/gormlogging"
+	"github.com/jobfair/shared/tracing/gormtracing"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 func Connect(databaseURL string) (*gorm.DB, error) {
@@ -18,7 +19,7 @@ func Connect(databaseURL string) (*gorm.DB, error) {
 	maxRetries := 10
 	for i := 0; i < maxRetries; i++ {
 		db, err = gorm.Open(postgres.Open(databaseURL), &gorm.Config{
-			Logger: logger.Default.LogMode(logger.Info),
+			Logger: gormlogging.New(),
 		})
 
 		if err == nil {
@@ -32,7 +33,12 @@ func Connect(databaseURL string) (*gorm.DB, error) {
 
 				// Test connection
 				if err := sqlDB.Ping(); err == nil {
-					log.Println("✅ Database connected successfully")
+					slog.Info("Database connected")
+
+					// Record statements of traced requests as spans
+					if err := db.Use(gormtracing.New()); err != nil {
+						return nil, fmt.Errorf("failed to install tracing plugin: %w", err)
+					}
 
 					// Auto migrate tables
 					// err = db.AutoMigrate(&models.Company{}, &models.CompanyAnalytics{})
@@ -47,8 +53,8 @@ func Connect(databaseURL string) (*gorm.DB, error) {
 		}
 
 		waitTime := time.Duration(i+1) * 2 * time.Second
-		log.Printf("⏳ Failed to connect to database (attempt %d/%d). Retrying in %v... Error: %v",
-			i+1, maxRetries, waitTime, err)
+		slog.Warn("Failed to connect to database, retrying",
+			"attempt", i+1, "max_attempts", maxRetries, "retry_in", waitTime.String(), "error", err)
 		time.Sleep(waitTime)
 	}
 
@@ -1,39 +1,62 @@
 package main
 
 import (
-	"log"
+	"context"
+	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"jobfair-job-service/internal/config"
 	"jobfair-job-service/internal/consumers"
 	"jobfair-job-service/internal/handlers"
 	"jobfair-job-service/internal/middleware"
+	"jobfair-job-service/internal/monitoring"
 	"jobfair-job-service/internal/repository"
+	"jobfair-job-service/internal/scheduler"
 	"jobfair-job-service/internal/services"
+	"jobfair-job-service/internal/utils"
 	"jobfair-job-service/pkg/database"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jobfair/shared/events"
+	"github.com/jobfair/shared/health"
+	"github.com/jobfair/shared/httpcache"
+	"github.com/jobfair/shared/httpclient"
+	"github.com/jobfair/shared/identity"
+	"github.com/jobfair/shared/logging"
+	"github.com/jobfair/shared/metrics"
+	"github.com/jobfair/shared/tracing"
 )
 
 func main() {
 	cfg := config.Load()
 
+	// JSON logs with request, trace and user IDs; LOG_LEVEL and LOG_FORMAT tune them
+	logging.Setup(cfg.Logging)
+
+	// Initialize tracing; OTEL_TRACES_EXPORTER selects where spans go
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		logging.Fatal("Failed to initialize tracing", "error", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	// Connect to database
 	db, err := database.Connect(cfg.DatabaseURL)
 	if err != nil {
-		log.Fatal("❌ Failed to connect to database:", err)
+		logging.Fatal("Failed to connect to database", "error", err)
 	}
 
 	defer func() {
 		sqlDB, err := db.DB()
 		if err != nil {
-			log.Printf("Error getting underlying SQL DB: %v", err)
+			slog.Error("Failed to get underlying SQL DB", "error", err)
 			return
 		}
 		if err := sqlDB.Close(); err != nil {
-			log.Printf("Error closing database connection: %v", err)
+			slog.Error("Failed to close database connection", "error", err)
 		}
 	}()
 
@@ -42,34 +65,111 @@ func main() {
 	applicationRepo := repository.NewApplicationRepository(db)
 	savedJobRepo := repository.NewSavedJobRepository(db)
 	companyRepo := repository.NewCompanyRepository(db)
+	revisionRepo := repository.NewJobRevisionRepository(db)
+	templateRepo := repository.NewJobTemplateRepository(db)
+	importRepo := repository.NewJobImportRepository(db)
+	subscriptionRepo := repository.NewSubscriptionRepository(db)
+	promotionRepo := repository.NewPromotionRepository(db)
+	applicantViewRepo := repository.NewApplicantViewRepository(db)
+
+	// Initialize event publisher (job lifecycle events)
+	eventPublisher, err := events.NewPublisher(cfg.RabbitMQURL)
+	if err != nil {
+		slog.Warn("Failed to initialize event publisher; job events will not be published", "error", err)
+		eventPublisher = nil
+	}
+
+	// Job views, saves, applies and hires feed company analytics
+	analyticsTracker := services.NewAnalyticsTracker(eventPublisher)
+
+	// Company lookups go through a client with retries and a circuit breaker,
+	// so a slow company-service can't stall job listings
+	companyClient := services.NewCompanyClient(cfg.CompanyServiceURL, httpclient.New(cfg.HTTPClient), cfg.Identity.Secret)
 
 	// Initialize services
-	jobService := services.NewJobService(jobRepo, applicationRepo, savedJobRepo, companyRepo, cfg.CompanyServiceURL)
+	jobService := services.NewJobService(jobRepo, applicationRepo, savedJobRepo, companyRepo, revisionRepo, templateRepo, subscriptionRepo, companyClient, eventPublisher, analyticsTracker, services.PublishOptions{
+		RequireVerifiedCompany: cfg.RequireVerifiedCompany,
+		EnforcePlanQuotas:      cfg.EnforcePlanQuotas,
+	}, services.BulkApplyOptions{
+		MaxBatchSize: cfg.BulkApplyMaxBatch,
+		Concurrency:  4,
+		Limiter:      utils.NewRateLimiter(cfg.BulkApplyRateLimit, cfg.BulkApplyRateWindow),
+	})
 	// applicationService := services.NewApplicationService(applicationRepo, jobRepo)
-	applicationService := services.NewApplicationService(applicationRepo, jobRepo, cfg.CompanyServiceURL) // Tambahkan cfg.CompanyServiceURL
+	applicationService := services.NewApplicationService(applicationRepo, jobRepo, applicantViewRepo, jobService, companyClient, analyticsTracker)
+	templateService := services.NewJobTemplateService(templateRepo, companyRepo)
+	importService := services.NewJobImportService(importRepo, jobService)
+	promotionService := services.NewPromotionService(promotionRepo, jobRepo, jobService, cfg.PromotedSlotsPerPage)
 
 	// Initialize handlers
-	jobHandler := handlers.NewJobHandler(jobService)
+	jobHandler := handlers.NewJobHandler(jobService, promotionService)
+	promotionHandler := handlers.NewPromotionHandler(promotionService, jobService)
 	applicationHandler := handlers.NewApplicationHandler(applicationService)
 	adminHandler := handlers.NewAdminHandler(companyRepo, jobService)
 	statsHandler := handlers.NewStatsHandler(applicationService, jobService)
+	templateHandler := handlers.NewJobTemplateHandler(templateService)
+	importHandler := handlers.NewJobImportHandler(importService, jobService)
 
 	// Initialize and start event consumer
-	companyConsumer, err := consumers.NewCompanyEventConsumer(cfg.RabbitMQURL, companyRepo)
+	companyConsumer, err := consumers.NewCompanyEventConsumer(cfg.RabbitMQURL, companyRepo, subscriptionRepo)
 	if err != nil {
-		log.Printf("⚠️ Warning: Failed to initialize company event consumer: %v", err)
-		log.Println("Service will continue without event consumption")
+		slog.Warn("Failed to initialize company event consumer; company events will not be consumed", "error", err)
 	} else {
 		go func() {
 			if err := companyConsumer.Start(); err != nil {
-				log.Printf("❌ Company event consumer error: %v", err)
+				slog.Error("Company event consumer stopped", "error", err)
 			}
 		}()
-		log.Println("✅ Company event consumer started")
+		slog.Info("Company event consumer started")
 	}
 
+	// Public listings and job details are kept until a job or company event
+	// invalidates them; HTTP_CACHE_BACKEND selects memory or Redis
+	responseCache, err := httpcache.New(context.Background(), cfg.HTTPCache)
+	if err != nil {
+		slog.Warn("Failed to initialize response cache; falling back to memory", "backend", cfg.HTTPCache.Backend, "error", err)
+		responseCache = httpcache.NewWithStore(httpcache.NewMemoryStore(cfg.HTTPCache.MaxEntries), cfg.HTTPCache.TTL)
+	}
+	cacheConsumer, err := consumers.NewCacheEventConsumer(cfg.RabbitMQURL, responseCache, companyRepo)
+	if err == nil {
+		if err = cacheConsumer.Start(); err != nil {
+			cacheConsumer.Close()
+			cacheConsumer = nil
+		}
+	}
+	if err != nil {
+		// Without invalidations stored responses would go stale, so only
+		// ETags and 304s are kept
+		slog.Warn("Failed to initialize response cache consumer; responses will not be stored", "error", err)
+		responseCache.Close()
+		responseCache = httpcache.NewWithStore(nil, cfg.HTTPCache.TTL)
+	} else {
+		slog.Info("Response cache consumer started")
+	}
+
+	// Fail imports interrupted by a restart; running ones are stopped on shutdown
+	importService.Start()
+
+	// Record promotion clicks in the background
+	promotionService.Start()
+
+	// Start job scheduler (auto-publish and deadline expiry)
+	jobScheduler := scheduler.NewJobScheduler(jobService, cfg.SchedulerInterval)
+	jobScheduler.Start()
+
 	// Setup Gin
-	router := gin.Default()
+	// Requests are logged by logging.Middleware
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	// Request IDs and a trace span for every request
+	router.Use(tracing.Middleware(cfg.Tracing.ServiceName)...)
+
+	// Request rate, errors and latency per route
+	router.Use(metrics.Middleware())
+
+	// Access log
+	router.Use(logging.Middleware())
 
 	// Disable automatic trailing slash redirect to prevent 301 loops
 	router.RedirectTrailingSlash = false
@@ -89,14 +189,33 @@ func main() {
 		c.Next()
 	})
 
-	// Health check
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "healthy",
-			"service": "job-service",
-			"version": "1.0.0",
-		})
-	})
+	// Trust identity headers set by the API gateway
+	router.Use(identity.Middleware(cfg.Identity))
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		logging.Fatal("Failed to get database connection pool", "error", err)
+	}
+
+	// Prometheus metrics: connection pool stats and business gauges
+	metrics.RegisterDB("jobfair_jobs", sqlDB)
+	monitoring.RegisterBusinessMetrics(jobRepo, applicationRepo)
+	metrics.Register(router)
+
+	// Liveness (/livez) and readiness (/readyz, /health) probes
+	// Listings fall back to cached company data, so company-service is optional
+	checker := health.NewChecker("job-service").
+		Add("database", health.Database(sqlDB)).
+		AddOptional("rabbitmq_publisher", eventPublisher.Check).
+		AddOptional("company_service", health.HTTP(strings.TrimRight(cfg.CompanyServiceURL, "/")+"/livez")).
+		AddOptional("response_cache", responseCache.Check)
+	if companyConsumer != nil {
+		checker.AddOptional("rabbitmq_company_events", companyConsumer.Check)
+	}
+	if cacheConsumer != nil {
+		checker.AddOptional("rabbitmq_cache_events", cacheConsumer.Check)
+	}
+	checker.Register(router)
 
 	// JWT Secret
 	jwtSecret := cfg.JWTSecret
@@ -110,8 +229,17 @@ func main() {
 		public := api.Group("")
 		public.Use(optionalJWT)
 		{
-			public.GET("/jobs", jobHandler.ListJobs)
-			public.GET("/jobs/:id", jobHandler.GetJob)
+			public.GET("/jobs", responseCache.Handler(httpcache.Options{
+				Tags: []string{services.CacheTagJobs},
+			}), jobHandler.ListJobs)
+			public.GET("/jobs/popular", promotionHandler.GetPopularJobs)
+			public.GET("/jobs/recent", promotionHandler.GetRecentJobs)
+			// Views and promotion clicks are still counted on cached responses
+			public.GET("/jobs/:id", responseCache.Handler(httpcache.Options{
+				Tags:   []string{services.CacheTagJobDetails},
+				Ignore: []string{"promotion_id"},
+				OnHit:  jobHandler.RecordView,
+			}), jobHandler.GetJob)
 		}
 
 		// Protected routes (require authentication)
@@ -125,6 +253,30 @@ func main() {
 			protected.POST("/jobs/:id/publish", jobHandler.PublishJob)
 			protected.POST("/jobs/:id/close", jobHandler.CloseJob)
 			protected.GET("/jobs/my", jobHandler.GetMyJobs)
+			protected.POST("/jobs/:id/duplicate", jobHandler.DuplicateJob)
+
+			// Bulk import/export (company only)
+			protected.POST("/jobs/import", importHandler.ImportJobs)
+			protected.GET("/jobs/imports", importHandler.ListImports)
+			protected.GET("/jobs/imports/:id", importHandler.GetImport)
+			protected.GET("/jobs/export", importHandler.ExportJobs)
+
+			// Job templates (company only)
+			protected.GET("/jobs/templates", templateHandler.ListTemplates)
+			protected.POST("/jobs/templates", templateHandler.CreateTemplate)
+			protected.GET("/jobs/templates/:id", templateHandler.GetTemplate)
+			protected.PUT("/jobs/templates/:id", templateHandler.UpdateTemplate)
+			protected.DELETE("/jobs/templates/:id", templateHandler.DeleteTemplate)
+
+			// Job revision history
+			protected.GET("/jobs/:id/revisions", jobHandler.ListRevisions)
+			protected.GET("/jobs/:id/revisions/diff", jobHandler.DiffRevisions)
+			protected.GET("/jobs/:id/revisions/:revision", jobHandler.GetRevision)
+
+			// Featured placements (company only, stats also for admins)
+			protected.POST("/jobs/:id/promotions", promotionHandler.CreatePromotion)
+			protected.GET("/jobs/:id/promotions", promotionHandler.ListPromotions)
+			protected.DELETE("/jobs/:id/promotions/:promotion_id", promotionHandler.CancelPromotion)
 
 			// Job application (job seeker only)
 			protected.POST("/jobs/:id/apply", jobHandler.ApplyToJob)
@@ -146,11 +298,14 @@ func main() {
 			protected.GET("/jobs/:id/applications", applicationHandler.GetApplicationsByJobID)
 		}
 
-		// Stats routes (public for internal service calls)
+		// Stats routes, for internal calls signed by other services only
 		stats := api.Group("/stats")
+		stats.Use(identity.RequireService(cfg.Identity))
 		{
 			stats.GET("/user/:user_id/applications", statsHandler.GetUserApplicationsCount)
 			stats.GET("/user/:user_id/saved", statsHandler.GetUserSavedCount)
+			stats.GET("/user/:user_id/applied/:employer_id", statsHandler.HasUserAppliedToEmployer)
+			stats.GET("/employer/:employer_id/applicants", statsHandler.GetEmployerApplicants)
 		}
 
 		// Admin routes (admin only)
@@ -161,6 +316,9 @@ func main() {
 			admin.POST("/sync-company-mapping", adminHandler.SyncCompanyMapping)
 			admin.GET("/company-mappings", adminHandler.GetCompanyMappings)
 
+			// Featured placements granted outside plan limits
+			admin.POST("/jobs/:id/promotions", promotionHandler.GrantPromotion)
+
 			// Health checks
 			admin.GET("/health/data-consistency", adminHandler.HealthCheckDataConsistency)
 		}
@@ -176,24 +334,48 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
 	go func() {
-		log.Printf("🚀 Job service starting on port %s", port)
-		log.Printf("📊 Health check: http://localhost:%s/health", port)
-		log.Printf("🔑 API endpoint: http://localhost:%s/api/v1", port)
+		slog.Info("Job service starting", "port", port)
 		if err := router.Run(":" + port); err != nil {
-			log.Fatal("Failed to start server:", err)
+			logging.Fatal("Failed to start job service", "error", err)
 		}
 	}()
 
 	// Wait for interrupt signal
 	<-quit
-	log.Println("🛑 Shutting down server...")
+	slog.Info("Job service shutting down")
 
-	// Close consumer
+	// Stop scheduler
+	jobScheduler.Stop()
+
+	// Interrupt running imports while the database is still open
+	importService.Stop()
+
+	// Record queued promotion clicks
+	promotionService.Stop()
+
+	// Close consumers
 	if companyConsumer != nil {
 		if err := companyConsumer.Close(); err != nil {
-			log.Printf("Error closing consumer: %v", err)
+			slog.Error("Failed to close consumer", "error", err)
+		}
+	}
+	if cacheConsumer != nil {
+		if err := cacheConsumer.Close(); err != nil {
+			slog.Error("Failed to close response cache consumer", "error", err)
+		}
+	}
+
+	// Close response cache
+	if err := responseCache.Close(); err != nil {
+		slog.Error("Failed to close response cache", "error", err)
+	}
+
+	// Close publisher
+	if eventPublisher != nil {
+		if err := eventPublisher.Close(); err != nil {
+			slog.Error("Failed to close publisher", "error", err)
 		}
 	}
 
-	log.Println("✅ Server exited")
+	slog.Info("Job service stopped")
 }
@@ -0,0 +1,138 @@
+// File: internal/consumers/cache_event_consumer.go
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"jobfair-job-service/internal/repository"
+	"jobfair-job-service/internal/services"
+
+	"github.com/jobfair/shared/events"
+	"github.com/jobfair/shared/httpcache"
+)
+
+// CacheEventConsumer drops cached job listings and details when jobs or
+// their companies change, so updates show up immediately
+type CacheEventConsumer struct {
+	cache       *httpcache.Cache
+	companyRepo *repository.CompanyRepository
+	consumer    *events.Consumer
+}
+
+func NewCacheEventConsumer(
+	rabbitmqURL string,
+	cache *httpcache.Cache,
+	companyRepo *repository.CompanyRepository,
+) (*CacheEventConsumer, error) {
+	consumer, err := events.NewConsumer(rabbitmqURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer: %w", err)
+	}
+
+	return &CacheEventConsumer{
+		cache:       cache,
+		companyRepo: companyRepo,
+		consumer:    consumer,
+	}, nil
+}
+
+// Start begins consuming job and company events
+func (c *CacheEventConsumer) Start() error {
+	slog.Info("Starting response cache event consumer")
+
+	// A queue of its own, so every event reaches the cache and not only the
+	// consumers of the company events queue. Replicas sharing the Redis cache
+	// share the queue; in-memory caches need every replica to receive every event.
+	const queue = "job-service.response-cache"
+	routingKeys := []string{
+		"job.*",
+		events.EventTypeCompanyUpdated,
+		events.EventTypeCompanyDeleted,
+		events.EventTypeCompanyVerified,
+	}
+	if c.cache.Shared() {
+		return c.consumer.Subscribe(queue, routingKeys, c.handleEvent)
+	}
+	return c.consumer.SubscribeExclusive(queue, routingKeys, c.handleEvent)
+}
+
+// handleEvent invalidates the responses showing the event's job or company
+func (c *CacheEventConsumer) handleEvent(ctx context.Context, body []byte) error {
+	var baseEvent events.BaseEvent
+	if err := json.Unmarshal(body, &baseEvent); err != nil {
+		return fmt.Errorf("failed to unmarshal base event: %w", err)
+	}
+
+	var tags []string
+	switch baseEvent.EventType {
+	case events.EventTypeJobPublished, events.EventTypeJobUpdated, events.EventTypeJobClosed, events.EventTypeJobDeleted:
+		var event events.JobLifecycleEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return fmt.Errorf("failed to unmarshal job lifecycle event: %w", err)
+		}
+		tags = []string{services.CacheTagJobs, services.JobCacheTag(event.Data.JobID)}
+
+	case events.EventTypeCompanyUpdated:
+		var event events.CompanyUpdatedEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return fmt.Errorf("failed to unmarshal company updated event: %w", err)
+		}
+		var companyID uint
+		if id, ok := event.Data.UpdatedFields["company_id"].(float64); ok {
+			companyID = uint(id)
+		}
+		tags = c.companyTags(companyID, event.Data.UserID)
+
+	case events.EventTypeCompanyDeleted:
+		var event events.CompanyDeletedEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return fmt.Errorf("failed to unmarshal company deleted event: %w", err)
+		}
+		tags = c.companyTags(0, event.Data.UserID)
+
+	case events.EventTypeCompanyVerified:
+		var event events.CompanyVerifiedEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return fmt.Errorf("failed to unmarshal company verified event: %w", err)
+		}
+		tags = c.companyTags(event.Data.CompanyID, event.Data.UserID)
+
+	default:
+		return nil
+	}
+
+	if err := c.cache.Invalidate(ctx, tags...); err != nil {
+		return fmt.Errorf("failed to invalidate cached responses: %w", err)
+	}
+	slog.DebugContext(ctx, "Cached responses invalidated", "event_type", baseEvent.EventType, "tags", tags)
+	return nil
+}
+
+// companyTags returns the tags of the responses showing a company: every
+// listing and the details of its jobs. When the company can't be found by
+// its ID or its user, the details of every job are dropped.
+func (c *CacheEventConsumer) companyTags(companyID, userID uint) []string {
+	if companyID == 0 && userID != 0 {
+		companyID, _ = c.companyRepo.GetCompanyIDByUserID(userID)
+	}
+	if companyID == 0 {
+		return []string{services.CacheTagJobs, services.CacheTagJobDetails}
+	}
+	return []string{services.CacheTagJobs, services.CompanyCacheTag(companyID)}
+}
+
+// Check reports whether the consumer is still connected to RabbitMQ
+func (c *CacheEventConsumer) Check(ctx context.Context) error {
+	return c.consumer.Check(ctx)
+}
+
+// Close closes the consumer
+func (c *CacheEventConsumer) Close() error {
+	if c.consumer != nil {
+		return c.consumer.Close()
+	}
+	return nil
+}
@@ -5,21 +5,25 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 
 	"jobfair-job-service/internal/repository"
 
 	"github.com/jobfair/shared/events"
+	"github.com/jobfair/shared/plans"
 )
 
 type CompanyEventConsumer struct {
-	companyRepo *repository.CompanyRepository
-	consumer    *events.Consumer
+	companyRepo      *repository.CompanyRepository
+	subscriptionRepo *repository.SubscriptionRepository
+	consumer         *events.Consumer
 }
 
 func NewCompanyEventConsumer(
 	rabbitmqURL string,
 	companyRepo *repository.CompanyRepository,
+	subscriptionRepo *repository.SubscriptionRepository,
 ) (*CompanyEventConsumer, error) {
 	consumer, err := events.NewConsumer(rabbitmqURL)
 	if err != nil {
@@ -27,16 +31,36 @@ func NewCompanyEventConsumer(
 	}
 
 	return &CompanyEventConsumer{
-		companyRepo: companyRepo,
-		consumer:    consumer,
+		companyRepo:      companyRepo,
+		subscriptionRepo: subscriptionRepo,
+		consumer:         consumer,
 	}, nil
 }
 
 // Start begins consuming company events
 func (c *CompanyEventConsumer) Start() error {
-	log.Println("🚀 [JOB-SERVICE] Starting company event consumer...")
+	slog.Info("Starting company event consumer")
 
-	return c.consumer.SubscribeCompanyEvents(c.handleEvent)
+	if err := c.consumer.SubscribeCompanyEvents(c.handleEvent); err != nil {
+		return err
+	}
+
+	// Verification results and subscription changes get queues of their own
+	// so they aren't shared with the company-service consumer of the company
+	// events queue
+	if err := c.consumer.Subscribe(
+		"job-service.company-verification",
+		[]string{events.EventTypeCompanyVerified},
+		c.handleEvent,
+	); err != nil {
+		return err
+	}
+
+	return c.consumer.Subscribe(
+		"job-service.company-subscriptions",
+		[]string{events.EventTypeCompanySubscriptionChanged},
+		c.handleEvent,
+	)
 }
 
 // handleEvent processes incoming events
@@ -47,7 +71,7 @@ func (c *CompanyEventConsumer) handleEvent(ctx context.Context, body []byte) err
 		return fmt.Errorf("failed to unmarshal base event: %w", err)
 	}
 
-	log.Printf("📨 [JOB-SERVICE] Processing event: %s", baseEvent.EventType)
+	slog.DebugContext(ctx, "Processing event", "event_type", baseEvent.EventType)
 
 	// Route to appropriate handler based on event type
 	switch baseEvent.EventType {
@@ -57,8 +81,12 @@ func (c *CompanyEventConsumer) handleEvent(ctx context.Context, body []byte) err
 		return c.handleCompanyUpdated(ctx, body)
 	case events.EventTypeCompanyDeleted:
 		return c.handleCompanyDeleted(ctx, body)
+	case events.EventTypeCompanyVerified:
+		return c.handleCompanyVerified(ctx, body)
+	case events.EventTypeCompanySubscriptionChanged:
+		return c.handleSubscriptionChanged(ctx, body)
 	default:
-		log.Printf("⚠️ [JOB-SERVICE] Unknown event type: %s", baseEvent.EventType)
+		slog.WarnContext(ctx, "Unknown event type", "event_type", baseEvent.EventType)
 		return nil // Don't fail on unknown events
 	}
 }
@@ -71,12 +99,11 @@ func (c *CompanyEventConsumer) handleCompanyRegistered(ctx context.Context, body
 	}
 
 	data := event.Data
-	log.Printf("🏢 [JOB-SERVICE] Creating company mapping for user_id: %d, company: %s", 
-		data.UserID, data.CompanyName)
+	slog.InfoContext(ctx, "Creating company mapping", "user_id", data.UserID, "company_name", data.CompanyName)
 
 	// Check if company_id is provided (re-published event from company-service)
 	if data.CompanyID == 0 {
-		log.Printf("⚠️ [JOB-SERVICE] Company event without company_id, skipping mapping (will be created by re-published event)")
+		slog.DebugContext(ctx, "Company event without company_id, skipping mapping until it is re-published", "user_id", data.UserID)
 		return nil
 	}
 
@@ -84,9 +111,13 @@ func (c *CompanyEventConsumer) handleCompanyRegistered(ctx context.Context, body
 	if err := c.companyRepo.UpsertCompanyMapping(data.UserID, data.CompanyID, data.CompanyName); err != nil {
 		return fmt.Errorf("failed to upsert company mapping: %w", err)
 	}
+	if data.SubscriptionTier != "" {
+		if err := c.companyRepo.SetSubscriptionTier(data.CompanyID, data.SubscriptionTier); err != nil {
+			return fmt.Errorf("failed to store subscription tier: %w", err)
+		}
+	}
 
-	log.Printf("✅ [JOB-SERVICE] Company mapping created: UserID=%d, CompanyID=%d, Name=%s",
-		data.UserID, data.CompanyID, data.CompanyName)
+	slog.InfoContext(ctx, "Company mapping created", "user_id", data.UserID, "company_id", data.CompanyID)
 
 	return nil
 }
@@ -99,7 +130,7 @@ func (c *CompanyEventConsumer) handleCompanyUpdated(ctx context.Context, body []
 	}
 
 	data := event.Data
-	log.Printf("🔄 [JOB-SERVICE] Updating company mapping for user_id: %d", data.UserID)
+	slog.InfoContext(ctx, "Updating company mapping", "user_id", data.UserID)
 
 	// Extract updated fields
 	var companyID uint
@@ -118,9 +149,13 @@ func (c *CompanyEventConsumer) handleCompanyUpdated(ctx context.Context, body []
 		if err := c.companyRepo.UpsertCompanyMapping(data.UserID, companyID, companyName); err != nil {
 			return fmt.Errorf("failed to upsert company mapping: %w", err)
 		}
+		if tier, ok := data.UpdatedFields["subscription_tier"].(string); ok && tier != "" {
+			if err := c.companyRepo.SetSubscriptionTier(companyID, plans.Tier(tier)); err != nil {
+				return fmt.Errorf("failed to store subscription tier: %w", err)
+			}
+		}
 		
-		log.Printf("✅ [JOB-SERVICE] Company mapping updated: UserID=%d, CompanyID=%d", 
-			data.UserID, companyID)
+		slog.InfoContext(ctx, "Company mapping updated", "user_id", data.UserID, "company_id", companyID)
 	}
 
 	return nil
@@ -134,17 +169,89 @@ func (c *CompanyEventConsumer) handleCompanyDeleted(ctx context.Context, body []
 	}
 
 	data := event.Data
-	log.Printf("🗑️ [JOB-SERVICE] Deleting company mapping for user_id: %d", data.UserID)
+	slog.InfoContext(ctx, "Deleting company mapping", "user_id", data.UserID)
 
 	if err := c.companyRepo.DeleteMappingByUserID(data.UserID); err != nil {
-		log.Printf("⚠️ [JOB-SERVICE] Failed to delete mapping (may not exist): %v", err)
+		slog.WarnContext(ctx, "Failed to delete company mapping (may not exist)", "user_id", data.UserID, "error", err)
 		// Don't return error as mapping might not exist
 	}
 
-	log.Printf("✅ [JOB-SERVICE] Company mapping deleted for UserID=%d", data.UserID)
+	slog.InfoContext(ctx, "Company mapping deleted", "user_id", data.UserID)
 	return nil
 }
 
+// handleCompanyVerified stores the verification result used for job badges
+// and the publishing restriction
+func (c *CompanyEventConsumer) handleCompanyVerified(ctx context.Context, body []byte) error {
+	var event events.CompanyVerifiedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal company verified event: %w", err)
+	}
+
+	data := event.Data
+	slog.InfoContext(ctx, "Company verification received", "company_id", data.CompanyID, "status", data.Status)
+
+	if data.CompanyID == 0 {
+		slog.WarnContext(ctx, "Company verified event without company_id, skipping", "user_id", data.UserID)
+		return nil
+	}
+
+	var verifiedAt *time.Time
+	if data.Verified {
+		reviewedAt := data.ReviewedAt
+		verifiedAt = &reviewedAt
+	}
+
+	if err := c.companyRepo.SetCompanyVerified(data.UserID, data.CompanyID, data.CompanyName, data.Verified, verifiedAt); err != nil {
+		return fmt.Errorf("failed to update company verification: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Company verification stored", "company_id", data.CompanyID, "verified", data.Verified)
+	return nil
+}
+
+// handleSubscriptionChanged stores a subscription period and its plan limits,
+// used to enforce job quotas
+func (c *CompanyEventConsumer) handleSubscriptionChanged(ctx context.Context, body []byte) error {
+	var event events.CompanySubscriptionChangedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal company subscription changed event: %w", err)
+	}
+
+	data := event.Data
+	slog.InfoContext(ctx, "Company subscription received", "company_id", data.CompanyID, "subscription_id", data.SubscriptionID,
+		"tier", data.Tier, "effective_from", data.EffectiveFrom.Format(time.RFC3339))
+
+	if data.CompanyID == 0 || data.SubscriptionID == 0 {
+		slog.WarnContext(ctx, "Subscription event without company_id or subscription_id, skipping")
+		return nil
+	}
+
+	subscription := &repository.CompanySubscription{
+		SubscriptionID: data.SubscriptionID,
+		CompanyID:      data.CompanyID,
+		Tier:           data.Tier,
+		ActiveJobSlots: data.Limits.ActiveJobSlots,
+		FeaturedJobs:   data.Limits.FeaturedJobs,
+		ApplicantViews: data.Limits.ApplicantViews,
+		TeamSeats:      data.Limits.TeamSeats,
+		EffectiveFrom:  data.EffectiveFrom,
+		EffectiveUntil: data.EffectiveUntil,
+		UpdatedAt:      time.Now(),
+	}
+	if err := c.subscriptionRepo.Upsert(subscription); err != nil {
+		return fmt.Errorf("failed to store company subscription: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Company subscription stored", "company_id", data.CompanyID, "subscription_id", data.SubscriptionID)
+	return nil
+}
+
+// Check reports whether the consumer is still connected to RabbitMQ
+func (c *CompanyEventConsumer) Check(ctx context.Context) error {
+	return c.consumer.Check(ctx)
+}
+
 // Close closes the consumer
 func (c *CompanyEventConsumer) Close() error {
 	if c.consumer != nil {
@@ -2,7 +2,7 @@ package handlers
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 
 	"jobfair-job-service/internal/repository"
@@ -56,7 +56,7 @@ func (h *AdminHandler) SyncCompanyMapping(c *gin.Context) {
 
 	// Create or update mapping
 	if err := h.companyRepo.UpsertCompanyMapping(req.UserID, req.CompanyID, req.CompanyName); err != nil {
-		log.Printf("❌ Failed to sync company mapping: %v", err)
+		slog.ErrorContext(c.Request.Context(), "Failed to sync company mapping", "user_id", req.UserID, "company_id", req.CompanyID, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"message": "Failed to sync company mapping",
@@ -65,8 +65,7 @@ func (h *AdminHandler) SyncCompanyMapping(c *gin.Context) {
 		return
 	}
 
-	log.Printf("✅ [ADMIN] Company mapping synced: UserID=%d, CompanyID=%d, Name=%s",
-		req.UserID, req.CompanyID, req.CompanyName)
+	slog.InfoContext(c.Request.Context(), "Company mapping synced", "user_id", req.UserID, "company_id", req.CompanyID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
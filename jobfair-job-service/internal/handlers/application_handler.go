@@ -35,6 +35,9 @@ func (h *ApplicationHandler) GetApplication(c *gin.Context) {
 
 	isCompany := userType == "company"
 	application, err := h.applicationService.GetApplicationByID(uint(id), userID, isCompany)
+	if respondQuotaError(c, err) {
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.APIResponse{
 			Success: false,
@@ -76,7 +79,7 @@ func (h *ApplicationHandler) GetMyApplications(c *gin.Context) {
 	}
 
 	// Enrich applications with company data
-	enrichedApplications, err := h.applicationService.EnrichApplicationsWithCompanyData(applications)
+	enrichedApplications, err := h.applicationService.EnrichApplicationsWithCompanyData(c.Request.Context(), applications)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
@@ -120,6 +123,9 @@ func (h *ApplicationHandler) GetApplicationsByJobID(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
 	applications, meta, err := h.applicationService.GetApplicationsByJobID(uint(jobID), userID, status, page, limit)
+	if respondQuotaError(c, err) {
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
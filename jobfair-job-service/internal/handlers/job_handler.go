@@ -1,6 +1,10 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
 	"net/http"
 	"strconv"
 
@@ -8,14 +12,20 @@ import (
 	"jobfair-job-service/internal/services"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jobfair/shared/httpcache"
+	"gorm.io/gorm"
 )
 
 type JobHandler struct {
-	jobService *services.JobService
+	jobService       *services.JobService
+	promotionService *services.PromotionService
 }
 
-func NewJobHandler(jobService *services.JobService) *JobHandler {
-	return &JobHandler{jobService: jobService}
+func NewJobHandler(jobService *services.JobService, promotionService *services.PromotionService) *JobHandler {
+	return &JobHandler{
+		jobService:       jobService,
+		promotionService: promotionService,
+	}
 }
 
 // CreateJob handles POST /jobs
@@ -51,6 +61,9 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 	}
 
 	job, err := h.jobService.CreateJob(userID, companyID, &req)
+	if respondQuotaError(c, err) {
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
@@ -94,8 +107,11 @@ func (h *JobHandler) GetJob(c *gin.Context) {
 		return
 	}
 
+	// Visits from a promoted slot count as clicks on the promotion
+	h.trackPromotionClick(c, uint(id))
+
 	// Enrich with company data
-	jobDetailWithCompany, err := h.jobService.EnrichJobDetailWithCompanyData(jobDetail)
+	jobDetailWithCompany, err := h.jobService.EnrichJobDetailWithCompanyData(c.Request.Context(), jobDetail)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
@@ -104,6 +120,9 @@ func (h *JobHandler) GetJob(c *gin.Context) {
 		return
 	}
 
+	// Cached anonymous details are dropped when the job or its company changes
+	httpcache.Tag(c, services.JobCacheTag(uint(id)), services.CompanyCacheTag(jobDetail.Job.CompanyID))
+
 	// IMPORTANT: Re-check user context after enrichment
 	// This ensures is_saved and has_applied are accurate
 	if userID != nil {
@@ -123,6 +142,37 @@ func (h *JobHandler) GetJob(c *gin.Context) {
 	})
 }
 
+// RecordView counts the view (and the promotion click) of a job detail served
+// from the response cache, which GetJob counts for the details it computes
+func (h *JobHandler) RecordView(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return
+	}
+
+	go func() {
+		if err := h.jobService.RecordView(uint(id)); err != nil {
+			slog.Warn("Failed to record job view", "job_id", id, "error", err)
+		}
+	}()
+	h.trackPromotionClick(c, uint(id))
+}
+
+// trackPromotionClick counts a visit from a promoted slot (?promotion_id=) as
+// a click on the promotion, once per user, or per IP for anonymous visitors
+func (h *JobHandler) trackPromotionClick(c *gin.Context, jobID uint) {
+	promotionID, err := strconv.ParseUint(c.Query("promotion_id"), 10, 32)
+	if err != nil {
+		return
+	}
+
+	visitor := "ip:" + c.ClientIP()
+	if userID, exists := c.Get("user_id"); exists {
+		visitor = fmt.Sprintf("user:%d", userID.(uint))
+	}
+	h.promotionService.TrackClick(visitor, jobID, uint(promotionID))
+}
+
 // UpdateJob handles PUT /jobs/:id
 func (h *JobHandler) UpdateJob(c *gin.Context) {
 	userID := c.GetUint("user_id")
@@ -147,6 +197,9 @@ func (h *JobHandler) UpdateJob(c *gin.Context) {
 	}
 
 	job, err := h.jobService.UpdateJob(uint(id), userID, &req)
+	if respondQuotaError(c, err) {
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
@@ -205,6 +258,16 @@ func (h *JobHandler) PublishJob(c *gin.Context) {
 	}
 
 	job, err := h.jobService.PublishJob(uint(id), userID)
+	if respondQuotaError(c, err) {
+		return
+	}
+	if errors.Is(err, services.ErrCompanyNotVerified) {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
@@ -269,7 +332,7 @@ func (h *JobHandler) ListJobs(c *gin.Context) {
 		filter.Limit = 10
 	}
 
-	jobs, meta, err := h.jobService.ListJobs(filter)
+	jobs, meta, err := h.jobService.ListJobs(c.Request.Context(), filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
@@ -278,8 +341,18 @@ func (h *JobHandler) ListJobs(c *gin.Context) {
 		return
 	}
 
+	// Promoted jobs are extra slots in front of the first page. Pages showing
+	// them are not cached, so every impression is counted.
+	jobs = h.promotionService.Promote(filter, jobs)
+	for _, job := range jobs {
+		if job.PromotionID != nil {
+			httpcache.Skip(c)
+			break
+		}
+	}
+
 	// Enrich jobs with company data
-	jobsWithCompany, err := h.jobService.EnrichJobsWithCompanyData(jobs)
+	jobsWithCompany, err := h.jobService.EnrichJobsWithCompanyData(c.Request.Context(), jobs)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
@@ -296,7 +369,7 @@ func (h *JobHandler) ListJobs(c *gin.Context) {
 	}
 
 	// Enrich with user context (is_saved, has_applied)
-	jobsWithCompany = h.jobService.EnrichJobsWithUserContext(jobsWithCompany, userID)
+	jobsWithCompany = h.jobService.EnrichJobsWithUserContext(c.Request.Context(), jobsWithCompany, userID)
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
@@ -394,20 +467,38 @@ func (h *JobHandler) BulkApply(c *gin.Context) {
 		return
 	}
 
-	successIDs, errors := h.jobService.BulkApply(userID, &req)
-
-	response := map[string]interface{}{
-		"success_count": len(successIDs),
-		"success_ids":   successIDs,
-		"error_count":   len(errors),
+	response, err := h.jobService.BulkApply(userID, &req)
+	if err != nil {
+		var validationErr *services.ValidationError
+		var rateLimitErr *services.RateLimitError
+		switch {
+		case errors.As(err, &validationErr):
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		case errors.As(err, &rateLimitErr):
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(rateLimitErr.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, models.APIResponse{
+				Success: false,
+				Message: "Too many bulk applications, please try again later",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Message: "Failed to process bulk application",
+			})
+		}
+		return
 	}
 
-	if len(errors) > 0 {
-		errorMessages := make([]string, len(errors))
-		for i, err := range errors {
-			errorMessages[i] = err.Error()
-		}
-		response["errors"] = errorMessages
+	if !response.Committed {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Message: "Bulk application rolled back: not all jobs could be applied to",
+			Data:    response,
+		})
+		return
 	}
 
 	c.JSON(http.StatusOK, models.APIResponse{
@@ -495,3 +586,167 @@ func (h *JobHandler) GetSavedJobs(c *gin.Context) {
 		Meta:    meta,
 	})
 }
+
+// ListRevisions handles GET /jobs/:id/revisions
+func (h *JobHandler) ListRevisions(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid job ID",
+		})
+		return
+	}
+
+	revisions, err := h.jobService.ListRevisions(uint(id), userID)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    revisions,
+	})
+}
+
+// GetRevision handles GET /jobs/:id/revisions/:revision
+func (h *JobHandler) GetRevision(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid job ID",
+		})
+		return
+	}
+
+	revisionNumber, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid revision number",
+		})
+		return
+	}
+
+	revision, err := h.jobService.GetRevision(uint(id), userID, revisionNumber)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    revision,
+	})
+}
+
+// DiffRevisions handles GET /jobs/:id/revisions/diff?from=1&to=2
+func (h *JobHandler) DiffRevisions(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid job ID",
+		})
+		return
+	}
+
+	from, errFrom := strconv.Atoi(c.Query("from"))
+	to, errTo := strconv.Atoi(c.Query("to"))
+	if errFrom != nil || errTo != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Query parameters 'from' and 'to' must be revision numbers",
+		})
+		return
+	}
+
+	diff, err := h.jobService.DiffRevisions(uint(id), userID, from, to)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    diff,
+	})
+}
+
+// respondRevisionError maps revision history errors to responses
+func respondRevisionError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	message := "Failed to load job revisions"
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		status, message = http.StatusNotFound, "Job not found"
+	case errors.Is(err, services.ErrRevisionNotFound):
+		status, message = http.StatusNotFound, err.Error()
+	case errors.Is(err, services.ErrRevisionsForbidden):
+		status, message = http.StatusForbidden, err.Error()
+	default:
+		slog.ErrorContext(c.Request.Context(), "Failed to load job revisions", "error", err)
+	}
+
+	c.JSON(status, models.APIResponse{
+		Success: false,
+		Message: message,
+	})
+}
+
+// DuplicateJob handles POST /jobs/:id/duplicate
+func (h *JobHandler) DuplicateJob(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid job ID",
+		})
+		return
+	}
+
+	job, err := h.jobService.DuplicateJob(uint(id), userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Job duplicated successfully",
+		Data:    job,
+	})
+}
+
+// respondQuotaError responds with 403 and the exceeded limit if err is a
+// plan quota error, and reports whether it did
+func respondQuotaError(c *gin.Context, err error) bool {
+	var quotaErr *services.QuotaError
+	if !errors.As(err, &quotaErr) {
+		return false
+	}
+
+	c.JSON(http.StatusForbidden, models.APIResponse{
+		Success: false,
+		Message: quotaErr.Error(),
+		Data:    quotaErr,
+	})
+	return true
+}
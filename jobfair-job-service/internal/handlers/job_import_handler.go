@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"jobfair-job-service/internal/jobio"
+	"jobfair-job-service/internal/models"
+	"jobfair-job-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportFileSize is the maximum size of an import file (5MB)
+const maxImportFileSize = 5 << 20
+
+type JobImportHandler struct {
+	importService *services.JobImportService
+	jobService    *services.JobService
+}
+
+func NewJobImportHandler(importService *services.JobImportService, jobService *services.JobService) *JobImportHandler {
+	return &JobImportHandler{
+		importService: importService,
+		jobService:    jobService,
+	}
+}
+
+// companyID resolves the company of the authenticated company user.
+// It writes the error response and returns false on failure.
+func (h *JobImportHandler) companyID(c *gin.Context) (uint, bool) {
+	if c.GetString("user_type") != "company" {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Message: "Only companies can import or export jobs",
+		})
+		return 0, false
+	}
+
+	companyID, err := h.jobService.GetCompanyIDByUserID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Company profile not found. Please complete company registration first.",
+		})
+		return 0, false
+	}
+
+	return companyID, true
+}
+
+// ImportJobs handles POST /jobs/import?format=csv|ndjson&dry_run=true
+// The file is sent either as multipart field "file" or as the raw request body.
+func (h *JobImportHandler) ImportJobs(c *gin.Context) {
+	companyID, ok := h.companyID(c)
+	if !ok {
+		return
+	}
+
+	data, fileName, err := readImportFile(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	format, err := jobio.ParseFormat(detectImportFormat(c, fileName))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+
+	jobImport, err := h.importService.StartImport(c.GetUint("user_id"), companyID, format, fileName, data, dryRun)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrImportsStopped) {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/api/v1/jobs/imports/%d", jobImport.ID))
+	c.JSON(http.StatusAccepted, models.APIResponse{
+		Success: true,
+		Message: "Job import started",
+		Data:    jobImport,
+	})
+}
+
+// GetImport handles GET /jobs/imports/:id
+func (h *JobImportHandler) GetImport(c *gin.Context) {
+	companyID, ok := h.companyID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid import ID",
+		})
+		return
+	}
+
+	jobImport, err := h.importService.GetImport(uint(id), companyID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: "Import not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    jobImport,
+	})
+}
+
+// ListImports handles GET /jobs/imports
+func (h *JobImportHandler) ListImports(c *gin.Context) {
+	companyID, ok := h.companyID(c)
+	if !ok {
+		return
+	}
+
+	imports, err := h.importService.ListImports(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Failed to retrieve imports",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    imports,
+	})
+}
+
+// ExportJobs handles GET /jobs/export?format=csv|ndjson&status=published
+func (h *JobImportHandler) ExportJobs(c *gin.Context) {
+	companyID, ok := h.companyID(c)
+	if !ok {
+		return
+	}
+
+	format, err := jobio.ParseFormat(c.DefaultQuery("format", "csv"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	jobs, err := h.jobService.GetCompanyJobs(companyID, models.JobStatus(c.Query("status")))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Failed to retrieve jobs",
+		})
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == jobio.FormatNDJSON {
+		contentType = "application/x-ndjson"
+	}
+
+	fileName := fmt.Sprintf("jobs-%d-%s.%s", companyID, time.Now().Format("20060102"), format)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Status(http.StatusOK)
+
+	if err := jobio.Encode(c.Writer, format, jobs); err != nil {
+		// Headers are already sent; all we can do is stop writing
+		c.Error(err)
+	}
+}
+
+// readImportFile reads the import file from a multipart upload or the raw body
+func readImportFile(c *gin.Context) ([]byte, string, error) {
+	var reader io.Reader
+	fileName := ""
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			return nil, "", fmt.Errorf("file is required")
+		}
+		if file.Size > maxImportFileSize {
+			return nil, "", fmt.Errorf("file too large, maximum is %dMB", maxImportFileSize>>20)
+		}
+
+		f, err := file.Open()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to open file: %w", err)
+		}
+		defer f.Close()
+
+		reader = f
+		fileName = file.Filename
+	} else {
+		reader = c.Request.Body
+	}
+
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, io.LimitReader(reader, maxImportFileSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read file: %w", err)
+	}
+	if n > maxImportFileSize {
+		return nil, "", fmt.Errorf("file too large, maximum is %dMB", maxImportFileSize>>20)
+	}
+	if n == 0 {
+		return nil, "", fmt.Errorf("file is empty")
+	}
+
+	return buf.Bytes(), fileName, nil
+}
+
+// detectImportFormat uses the format query parameter, the file extension or the content type
+func detectImportFormat(c *gin.Context, fileName string) string {
+	if format := c.Query("format"); format != "" {
+		return format
+	}
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return "csv"
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	}
+
+	switch c.ContentType() {
+	case "text/csv":
+		return "csv"
+	case "application/x-ndjson", "application/jsonl":
+		return "ndjson"
+	}
+
+	return ""
+}
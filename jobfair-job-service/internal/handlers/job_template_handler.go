@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"jobfair-job-service/internal/models"
+	"jobfair-job-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobTemplateHandler struct {
+	templateService *services.JobTemplateService
+}
+
+func NewJobTemplateHandler(templateService *services.JobTemplateService) *JobTemplateHandler {
+	return &JobTemplateHandler{templateService: templateService}
+}
+
+// companyID resolves the company of the authenticated company user.
+// It writes the error response and returns false if the user can't manage templates.
+func (h *JobTemplateHandler) companyID(c *gin.Context) (uint, bool) {
+	if c.GetString("user_type") != "company" {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Message: "Only companies can manage job templates",
+		})
+		return 0, false
+	}
+
+	companyID, err := h.templateService.GetCompanyIDByUserID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Company profile not found. Please complete company registration first.",
+		})
+		return 0, false
+	}
+
+	return companyID, true
+}
+
+// CreateTemplate handles POST /jobs/templates
+func (h *JobTemplateHandler) CreateTemplate(c *gin.Context) {
+	companyID, ok := h.companyID(c)
+	if !ok {
+		return
+	}
+
+	var req models.JobTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	template, err := h.templateService.CreateTemplate(c.GetUint("user_id"), companyID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Job template created successfully",
+		Data:    template,
+	})
+}
+
+// ListTemplates handles GET /jobs/templates
+func (h *JobTemplateHandler) ListTemplates(c *gin.Context) {
+	companyID, ok := h.companyID(c)
+	if !ok {
+		return
+	}
+
+	templates, err := h.templateService.ListTemplates(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Failed to retrieve job templates",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    templates,
+	})
+}
+
+// GetTemplate handles GET /jobs/templates/:id
+func (h *JobTemplateHandler) GetTemplate(c *gin.Context) {
+	companyID, ok := h.companyID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid template ID",
+		})
+		return
+	}
+
+	template, err := h.templateService.GetTemplate(uint(id), companyID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: "Job template not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    template,
+	})
+}
+
+// UpdateTemplate handles PUT /jobs/templates/:id
+func (h *JobTemplateHandler) UpdateTemplate(c *gin.Context) {
+	companyID, ok := h.companyID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid template ID",
+		})
+		return
+	}
+
+	var req models.JobTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	template, err := h.templateService.UpdateTemplate(uint(id), companyID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Job template updated successfully",
+		Data:    template,
+	})
+}
+
+// DeleteTemplate handles DELETE /jobs/templates/:id
+func (h *JobTemplateHandler) DeleteTemplate(c *gin.Context) {
+	companyID, ok := h.companyID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid template ID",
+		})
+		return
+	}
+
+	if err := h.templateService.DeleteTemplate(uint(id), companyID); err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: "Job template not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Job template deleted successfully",
+	})
+}
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"jobfair-job-service/internal/models"
+	"jobfair-job-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const maxFeedLimit = 50
+
+type PromotionHandler struct {
+	promotionService *services.PromotionService
+	jobService       *services.JobService
+}
+
+func NewPromotionHandler(promotionService *services.PromotionService, jobService *services.JobService) *PromotionHandler {
+	return &PromotionHandler{
+		promotionService: promotionService,
+		jobService:       jobService,
+	}
+}
+
+// CreatePromotion handles POST /jobs/:id/promotions
+func (h *PromotionHandler) CreatePromotion(c *gin.Context) {
+	if c.GetString("user_type") != "company" {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Message: "Only companies can promote jobs",
+		})
+		return
+	}
+	h.createPromotion(c, h.promotionService.CreatePromotion)
+}
+
+// GrantPromotion handles POST /admin/jobs/:id/promotions
+func (h *PromotionHandler) GrantPromotion(c *gin.Context) {
+	if c.GetString("user_type") != "admin" {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Message: "Only admins can grant promotions",
+		})
+		return
+	}
+	h.createPromotion(c, h.promotionService.GrantPromotion)
+}
+
+func (h *PromotionHandler) createPromotion(c *gin.Context, create func(userID, jobID uint, req *models.CreatePromotionRequest) (*models.JobPromotion, error)) {
+	jobID, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreatePromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	promotion, err := create(c.GetUint("user_id"), jobID, &req)
+	if err != nil {
+		respondPromotionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Job promoted successfully",
+		Data:    promotion,
+	})
+}
+
+// ListPromotions handles GET /jobs/:id/promotions
+func (h *PromotionHandler) ListPromotions(c *gin.Context) {
+	jobID, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	promotions, err := h.promotionService.ListPromotions(c.GetUint("user_id"), c.GetString("user_type"), jobID)
+	if err != nil {
+		respondPromotionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    promotions,
+	})
+}
+
+// CancelPromotion handles DELETE /jobs/:id/promotions/:promotion_id
+func (h *PromotionHandler) CancelPromotion(c *gin.Context) {
+	jobID, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+	promotionID, err := strconv.ParseUint(c.Param("promotion_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid promotion ID",
+		})
+		return
+	}
+
+	if err := h.promotionService.CancelPromotion(c.GetUint("user_id"), c.GetString("user_type"), jobID, uint(promotionID)); err != nil {
+		respondPromotionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Promotion cancelled successfully",
+	})
+}
+
+// GetPopularJobs handles GET /jobs/popular
+func (h *PromotionHandler) GetPopularJobs(c *gin.Context) {
+	h.feed(c, services.FeedPopular)
+}
+
+// GetRecentJobs handles GET /jobs/recent
+func (h *PromotionHandler) GetRecentJobs(c *gin.Context) {
+	h.feed(c, services.FeedRecent)
+}
+
+func (h *PromotionHandler) feed(c *gin.Context, feed string) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+	if limit > maxFeedLimit {
+		limit = maxFeedLimit
+	}
+
+	jobs, err := h.promotionService.Feed(feed, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	jobsWithCompany, err := h.jobService.EnrichJobsWithCompanyData(c.Request.Context(), jobs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Failed to enrich job data: " + err.Error(),
+		})
+		return
+	}
+
+	var userID *uint
+	if uid, exists := c.Get("user_id"); exists {
+		uidValue := uid.(uint)
+		userID = &uidValue
+	}
+	jobsWithCompany = h.jobService.EnrichJobsWithUserContext(c.Request.Context(), jobsWithCompany, userID)
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    jobsWithCompany,
+	})
+}
+
+// parseJobID parses the :id param, writing the error response if it's invalid
+func parseJobID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid job ID",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// respondPromotionError maps promotion service errors to responses
+func respondPromotionError(c *gin.Context, err error) {
+	if respondQuotaError(c, err) {
+		return
+	}
+
+	status := http.StatusInternalServerError
+	message := err.Error()
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		status, message = http.StatusNotFound, "Job not found"
+	case errors.Is(err, services.ErrPromotionNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrNotJobOwner):
+		status = http.StatusForbidden
+	case errors.Is(err, services.ErrPromotionOverlap):
+		status = http.StatusConflict
+	case errors.Is(err, services.ErrInvalidPromotionPeriod), errors.Is(err, services.ErrJobNotPromotable):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, models.APIResponse{
+		Success: false,
+		Message: message,
+	})
+}
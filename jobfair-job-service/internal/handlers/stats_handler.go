@@ -75,3 +75,64 @@ func (h *StatsHandler) GetUserSavedCount(c *gin.Context) {
 		Data:    count,
 	})
 }
+
+// HasUserAppliedToEmployer reports whether the user applied to any job posted by the employer (company user)
+func (h *StatsHandler) HasUserAppliedToEmployer(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid user ID",
+		})
+		return
+	}
+
+	employerID, err := strconv.ParseUint(c.Param("employer_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid employer ID",
+		})
+		return
+	}
+
+	applied, err := h.applicationService.HasAppliedToEmployer(uint(userID), uint(employerID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    applied,
+	})
+}
+
+// GetEmployerApplicants lists the users who applied to any job posted by the employer (company user)
+func (h *StatsHandler) GetEmployerApplicants(c *gin.Context) {
+	employerID, err := strconv.ParseUint(c.Param("employer_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid employer ID",
+		})
+		return
+	}
+
+	userIDs, err := h.applicationService.GetApplicantUserIDs(uint(employerID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    userIDs,
+	})
+}
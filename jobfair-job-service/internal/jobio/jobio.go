@@ -0,0 +1,284 @@
+// Package jobio reads and writes job postings in the bulk import/export
+// formats: CSV (one job per row, list fields separated by "|") and NDJSON
+// (one JSON object per line).
+package jobio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"jobfair-job-service/internal/models"
+)
+
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// listSeparator separates items of list fields (requirements, skills, ...) in CSV
+const listSeparator = "|"
+
+// importColumns are the CSV columns understood by the importer. Exports write
+// these plus read-only columns (id, slug, status, ...) that imports ignore.
+var importColumns = []string{
+	"title", "description", "employment_type", "work_type", "experience_level",
+	"location", "salary_min", "salary_max", "requirements", "responsibilities",
+	"skills", "benefits", "receive_method", "contact_email", "external_url",
+	"deadline", "publish_at", "tags",
+}
+
+var exportColumns = append([]string{"id", "slug", "status"}, append(importColumns,
+	"views", "applications", "published_at", "closed_at", "created_at")...)
+
+// ParseFormat validates a format name
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(value)) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatNDJSON, "jsonl", "json":
+		return FormatNDJSON, nil
+	}
+	return "", fmt.Errorf("unsupported format %q, expected csv or ndjson", value)
+}
+
+// Row is a single decoded import row
+type Row struct {
+	Number  int // 1-based data row (CSV header and blank NDJSON lines are not counted)
+	Request *models.CreateJobRequest
+	Err     error
+}
+
+// Decode parses all rows of an import file. Malformed rows are returned with
+// Err set so the caller can report them; a non-nil error means the file as a
+// whole could not be read.
+func Decode(format Format, data []byte) ([]Row, error) {
+	switch format {
+	case FormatCSV:
+		return decodeCSV(data)
+	case FormatNDJSON:
+		return decodeNDJSON(data)
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+func decodeCSV(data []byte) ([]Row, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("CSV header must include a title column")
+	}
+
+	var rows []Row
+	for number := 1; ; number++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			rows = append(rows, Row{Number: number, Err: err})
+			continue
+		}
+
+		req, err := requestFromRecord(columns, record)
+		rows = append(rows, Row{Number: number, Request: req, Err: err})
+	}
+
+	return rows, nil
+}
+
+func requestFromRecord(columns map[string]int, record []string) (*models.CreateJobRequest, error) {
+	get := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	req := &models.CreateJobRequest{
+		Title:            get("title"),
+		Description:      get("description"),
+		EmploymentType:   models.EmploymentType(get("employment_type")),
+		WorkType:         models.WorkType(get("work_type")),
+		ExperienceLevel:  models.ExperienceLevel(get("experience_level")),
+		Location:         get("location"),
+		Requirements:     splitList(get("requirements")),
+		Responsibilities: splitList(get("responsibilities")),
+		Skills:           splitList(get("skills")),
+		Benefits:         splitList(get("benefits")),
+		ReceiveMethod:    get("receive_method"),
+		ContactEmail:     get("contact_email"),
+		Tags:             splitList(get("tags")),
+	}
+
+	var err error
+	if req.SalaryMin, err = parseInt(get("salary_min")); err != nil {
+		return nil, fmt.Errorf("salary_min: %w", err)
+	}
+	if req.SalaryMax, err = parseInt(get("salary_max")); err != nil {
+		return nil, fmt.Errorf("salary_max: %w", err)
+	}
+
+	if v := get("external_url"); v != "" {
+		req.ExternalURL = &v
+	}
+	if v := get("deadline"); v != "" {
+		req.Deadline = &v
+	}
+	if v := get("publish_at"); v != "" {
+		req.PublishAt = &v
+	}
+
+	return req, nil
+}
+
+func decodeNDJSON(data []byte) ([]Row, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []Row
+	number := 0
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		number++
+
+		var req models.CreateJobRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			rows = append(rows, Row{Number: number, Err: fmt.Errorf("invalid JSON: %w", err)})
+			continue
+		}
+		rows = append(rows, Row{Number: number, Request: &req})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read NDJSON: %w", err)
+	}
+	if number == 0 {
+		return nil, errors.New("file is empty")
+	}
+
+	return rows, nil
+}
+
+// Encode writes jobs in the given format
+func Encode(w io.Writer, format Format, jobs []*models.Job) error {
+	switch format {
+	case FormatCSV:
+		return encodeCSV(w, jobs)
+	case FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		for _, job := range jobs {
+			if err := encoder.Encode(job); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported format %q", format)
+}
+
+func encodeCSV(w io.Writer, jobs []*models.Job) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportColumns); err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		externalURL := ""
+		if job.ExternalURL != nil {
+			externalURL = *job.ExternalURL
+		}
+
+		record := []string{
+			strconv.FormatUint(uint64(job.ID), 10),
+			job.Slug,
+			string(job.Status),
+			job.Title,
+			job.Description,
+			string(job.EmploymentType),
+			string(job.WorkType),
+			string(job.ExperienceLevel),
+			job.Location,
+			strconv.Itoa(job.SalaryMin),
+			strconv.Itoa(job.SalaryMax),
+			strings.Join(job.Requirements, listSeparator),
+			strings.Join(job.Responsibilities, listSeparator),
+			strings.Join(job.Skills, listSeparator),
+			strings.Join(job.Benefits, listSeparator),
+			job.ReceiveMethod,
+			job.ContactEmail,
+			externalURL,
+			formatTime(job.Deadline),
+			formatTime(job.PublishAt),
+			strings.Join(job.Tags, listSeparator),
+			strconv.Itoa(job.Views),
+			strconv.Itoa(job.Applications),
+			formatTime(job.PublishedAt),
+			formatTime(job.ClosedAt),
+			job.CreatedAt.Format(time.RFC3339),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, listSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a whole number", value)
+	}
+	return n, nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
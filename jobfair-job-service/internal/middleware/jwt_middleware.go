@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/jobfair/shared/identity"
 )
 
 type Claims struct {
@@ -17,6 +18,12 @@ type Claims struct {
 // JWTMiddleware validates JWT tokens
 func JWTMiddleware(jwtSecret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Already authenticated by the API gateway
+		if identity.Verified(c) {
+			c.Next()
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
@@ -59,6 +66,12 @@ func JWTMiddleware(jwtSecret string) gin.HandlerFunc {
 // OptionalJWTMiddleware validates JWT tokens but doesn't fail if not present
 func OptionalJWTMiddleware(jwtSecret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Already authenticated by the API gateway
+		if identity.Verified(c) {
+			c.Next()
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.Next()
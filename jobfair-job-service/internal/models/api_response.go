@@ -1,5 +1,12 @@
 package models
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
 // APIResponse is the standard API response format
 type APIResponse struct {
 	Success bool        `json:"success"`
@@ -16,14 +23,17 @@ type PaginationMeta struct {
 	TotalPages  int   `json:"total_pages"`
 }
 
-// CreateJobRequest is the request for creating a job
+// CreateJobRequest is the request for creating a job.
+// When TemplateID is set, fields left empty are filled from the template and
+// any field provided in the request overrides the template value.
 type CreateJobRequest struct {
-	Title            string          `json:"title" binding:"required"`
-	Description      string          `json:"description" binding:"required"`
-	EmploymentType   EmploymentType  `json:"employment_type" binding:"required"`
-	WorkType         WorkType        `json:"work_type" binding:"required"`
-	ExperienceLevel  ExperienceLevel `json:"experience_level" binding:"required"`
-	Location         string          `json:"location" binding:"required"`
+	TemplateID       *uint           `json:"template_id"`
+	Title            string          `json:"title"`
+	Description      string          `json:"description"`
+	EmploymentType   EmploymentType  `json:"employment_type"`
+	WorkType         WorkType        `json:"work_type"`
+	ExperienceLevel  ExperienceLevel `json:"experience_level"`
+	Location         string          `json:"location"`
 	SalaryMin        int             `json:"salary_min"`
 	SalaryMax        int             `json:"salary_max"`
 	Requirements     []string        `json:"requirements"`
@@ -33,10 +43,110 @@ type CreateJobRequest struct {
 	ReceiveMethod    string          `json:"receive_method"` // email or external
 	ContactEmail     string          `json:"contact_email"`
 	ExternalURL      *string         `json:"external_url"`
-	Deadline         *string         `json:"deadline"` // ISO 8601 format
+	Deadline         *string         `json:"deadline"`   // ISO 8601 format
+	PublishAt        *string         `json:"publish_at"` // ISO 8601 format, schedules auto-publish
 	Tags             []string        `json:"tags"`
 }
 
+// ApplyTemplate fills fields the request left empty with values from the template
+func (r *CreateJobRequest) ApplyTemplate(t *JobTemplate) {
+	if r.Title == "" {
+		r.Title = t.Title
+	}
+	if r.Description == "" {
+		r.Description = t.Description
+	}
+	if r.EmploymentType == "" {
+		r.EmploymentType = t.EmploymentType
+	}
+	if r.WorkType == "" {
+		r.WorkType = t.WorkType
+	}
+	if r.ExperienceLevel == "" {
+		r.ExperienceLevel = t.ExperienceLevel
+	}
+	if r.Location == "" {
+		r.Location = t.Location
+	}
+	if r.SalaryMin == 0 {
+		r.SalaryMin = t.SalaryMin
+	}
+	if r.SalaryMax == 0 {
+		r.SalaryMax = t.SalaryMax
+	}
+	if r.Requirements == nil {
+		r.Requirements = t.Requirements
+	}
+	if r.Responsibilities == nil {
+		r.Responsibilities = t.Responsibilities
+	}
+	if r.Skills == nil {
+		r.Skills = t.Skills
+	}
+	if r.Benefits == nil {
+		r.Benefits = t.Benefits
+	}
+	if r.ReceiveMethod == "" {
+		r.ReceiveMethod = t.ReceiveMethod
+	}
+	if r.ContactEmail == "" {
+		r.ContactEmail = t.ContactEmail
+	}
+	if r.ExternalURL == nil {
+		r.ExternalURL = t.ExternalURL
+	}
+	if r.Tags == nil {
+		r.Tags = t.Tags
+	}
+}
+
+// Validate checks required fields and enum values of a create request
+func (r *CreateJobRequest) Validate() error {
+	switch {
+	case strings.TrimSpace(r.Title) == "":
+		return errors.New("title is required")
+	case strings.TrimSpace(r.Description) == "":
+		return errors.New("description is required")
+	case strings.TrimSpace(r.Location) == "":
+		return errors.New("location is required")
+	}
+
+	if !r.EmploymentType.IsValid() {
+		return fmt.Errorf("invalid employment_type %q", r.EmploymentType)
+	}
+	if !r.WorkType.IsValid() {
+		return fmt.Errorf("invalid work_type %q", r.WorkType)
+	}
+	if !r.ExperienceLevel.IsValid() {
+		return fmt.Errorf("invalid experience_level %q", r.ExperienceLevel)
+	}
+
+	if r.SalaryMin < 0 || r.SalaryMax < 0 {
+		return errors.New("salary cannot be negative")
+	}
+	if r.SalaryMax > 0 && r.SalaryMin > r.SalaryMax {
+		return errors.New("salary_min cannot be greater than salary_max")
+	}
+
+	switch r.ReceiveMethod {
+	case "", "email":
+	case "external":
+		if r.ExternalURL == nil || *r.ExternalURL == "" {
+			return errors.New("external_url is required when receive_method is external")
+		}
+	default:
+		return fmt.Errorf("invalid receive_method %q", r.ReceiveMethod)
+	}
+
+	if r.Deadline != nil && *r.Deadline != "" {
+		if _, err := time.Parse(time.RFC3339, *r.Deadline); err != nil {
+			return errors.New("invalid deadline, expected RFC3339 format")
+		}
+	}
+
+	return nil
+}
+
 // UpdateJobRequest is the request for updating a job
 type UpdateJobRequest struct {
 	Title            *string          `json:"title"`
@@ -55,6 +165,7 @@ type UpdateJobRequest struct {
 	ContactEmail     *string          `json:"contact_email"`
 	ExternalURL      *string          `json:"external_url"`
 	Deadline         *string          `json:"deadline"`
+	PublishAt        *string          `json:"publish_at"`
 	Tags             []string         `json:"tags"`
 }
 
@@ -64,11 +175,51 @@ type ApplyJobRequest struct {
 	CoverLetter string `json:"cover_letter"`
 }
 
+// BulkApplyMode controls how BulkApply handles jobs that can't be applied to
+type BulkApplyMode string
+
+const (
+	// BulkApplyModeBestEffort applies to every job it can and reports the rest
+	BulkApplyModeBestEffort BulkApplyMode = "best_effort"
+	// BulkApplyModeAllOrNothing applies to all jobs or to none of them
+	BulkApplyModeAllOrNothing BulkApplyMode = "all_or_nothing"
+)
+
 // BulkApplyRequest is the request for applying to multiple jobs
 type BulkApplyRequest struct {
-	JobIDs      []uint `json:"job_ids" binding:"required"`
-	CVURL       string `json:"cv_url"`
-	CoverLetter string `json:"cover_letter"`
+	JobIDs      []uint        `json:"job_ids" binding:"required"`
+	CVURL       string        `json:"cv_url"`
+	CoverLetter string        `json:"cover_letter"`
+	Mode        BulkApplyMode `json:"mode"` // best_effort (default) or all_or_nothing
+}
+
+// ApplyResultCode is the per-job outcome of an application attempt
+type ApplyResultCode string
+
+const (
+	ApplyResultApplied        ApplyResultCode = "applied"
+	ApplyResultAlreadyApplied ApplyResultCode = "already_applied"
+	ApplyResultClosed         ApplyResultCode = "closed" // not published or past its deadline
+	ApplyResultNotFound       ApplyResultCode = "not_found"
+	ApplyResultRolledBack     ApplyResultCode = "rolled_back" // would have applied, undone by all_or_nothing
+	ApplyResultError          ApplyResultCode = "error"
+)
+
+// BulkApplyResult is the outcome for a single job in a bulk apply
+type BulkApplyResult struct {
+	JobID         uint            `json:"job_id"`
+	Result        ApplyResultCode `json:"result"`
+	ApplicationID *uint           `json:"application_id,omitempty"`
+	Message       string          `json:"message,omitempty"`
+}
+
+// BulkApplyResponse summarises a bulk apply
+type BulkApplyResponse struct {
+	Mode         BulkApplyMode     `json:"mode"`
+	Committed    bool              `json:"committed"`
+	AppliedCount int               `json:"applied_count"`
+	FailedCount  int               `json:"failed_count"`
+	Results      []BulkApplyResult `json:"results"`
 }
 
 // UpdateApplicationStatusRequest is the request for updating application status
@@ -97,11 +248,12 @@ type JobListFilter struct {
 
 // JobDetailResponse includes company information
 type JobDetailResponse struct {
-	Job         *Job                   `json:"job"`
-	Company     map[string]interface{} `json:"company,omitempty"`
-	IsSaved     bool                   `json:"is_saved"`
-	HasApplied  bool                   `json:"has_applied"`
-	Application *JobApplication        `json:"application,omitempty"`
+	Job             *Job                   `json:"job"`
+	Company         map[string]interface{} `json:"company,omitempty"`
+	CompanyVerified bool                   `json:"company_verified"` // Verified badge
+	IsSaved         bool                   `json:"is_saved"`
+	HasApplied      bool                   `json:"has_applied"`
+	Application     *JobApplication        `json:"application,omitempty"`
 }
 
 // ApplicationListResponse includes job and company information
@@ -119,9 +271,10 @@ type ApplicationWithJob struct {
 // JobWithCompany combines job and company data for list responses
 type JobWithCompany struct {
 	*Job
-	Company    map[string]interface{} `json:"company,omitempty"`
-	IsSaved    bool                   `json:"is_saved"`
-	HasApplied bool                   `json:"has_applied"`
+	Company         map[string]interface{} `json:"company,omitempty"`
+	CompanyVerified bool                   `json:"company_verified"` // Verified badge
+	IsSaved         bool                   `json:"is_saved"`
+	HasApplied      bool                   `json:"has_applied"`
 }
 
 // JobListResponse for list jobs with company data
@@ -51,6 +51,33 @@ const (
 	ApplicationStatusRejected    ApplicationStatus = "rejected"
 )
 
+// IsValid reports whether the employment type is a known value
+func (e EmploymentType) IsValid() bool {
+	switch e {
+	case EmploymentTypeFullTime, EmploymentTypePartTime, EmploymentTypeContract, EmploymentTypeFreelance, EmploymentTypeIntern:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether the work type is a known value
+func (w WorkType) IsValid() bool {
+	switch w {
+	case WorkTypeOnsite, WorkTypeRemote, WorkTypeHybrid:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether the experience level is a known value
+func (e ExperienceLevel) IsValid() bool {
+	switch e {
+	case ExperienceLevelEntry, ExperienceLevelJunior, ExperienceLevelMid, ExperienceLevelSenior:
+		return true
+	}
+	return false
+}
+
 // Job represents a job posting
 type Job struct {
 	ID          uint   `json:"id" gorm:"primaryKey"`
@@ -95,6 +122,7 @@ type Job struct {
 	Views         int        `json:"views" gorm:"default:0"`
 	Applications  int        `json:"applications" gorm:"default:0"`
 	Deadline      *time.Time `json:"deadline"`
+	PublishAt     *time.Time `json:"publish_at"` // scheduled auto-publish time for drafts
 	PublishedAt   *time.Time `json:"published_at"`
 	ClosedAt      *time.Time `json:"closed_at"`
 
@@ -107,6 +135,10 @@ type Job struct {
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Set on listing results shown in a promoted slot (not stored in DB)
+	Promoted    bool  `json:"promoted,omitempty" gorm:"-"`
+	PromotionID *uint `json:"promotion_id,omitempty" gorm:"-"`
 }
 
 // JobApplication represents a job application
@@ -118,6 +150,7 @@ type JobApplication struct {
 	// Application Data
 	CVURL       string `json:"cv_url"`
 	CoverLetter string `json:"cover_letter" gorm:"type:text"`
+	RevisionID  *uint  `json:"revision_id"` // Job revision the applicant applied against
 
 	// Status
 	Status     ApplicationStatus `json:"status" gorm:"default:'applied'"`
@@ -162,3 +195,8 @@ func (JobApplication) TableName() string {
 func (SavedJob) TableName() string {
 	return "saved_jobs"
 }
+
+// IsPastDeadline reports whether the job's application deadline has passed
+func (j *Job) IsPastDeadline(now time.Time) bool {
+	return j.Deadline != nil && !j.Deadline.After(now)
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type ImportStatus string
+
+const (
+	ImportStatusPending    ImportStatus = "pending"
+	ImportStatusProcessing ImportStatus = "processing"
+	ImportStatusCompleted  ImportStatus = "completed"
+	ImportStatusFailed     ImportStatus = "failed"
+)
+
+// JobImport tracks an asynchronous bulk job import
+type JobImport struct {
+	ID        uint         `json:"id" gorm:"primaryKey"`
+	CompanyID uint         `json:"company_id" gorm:"not null;index"`
+	UserID    uint         `json:"user_id" gorm:"not null"`
+	Format    string       `json:"format" gorm:"type:varchar(20)"`
+	FileName  string       `json:"file_name"`
+	DryRun    bool         `json:"dry_run"`
+	Status    ImportStatus `json:"status" gorm:"type:varchar(20);default:'pending'"`
+
+	// Progress
+	TotalRows     int             `json:"total_rows"`
+	ProcessedRows int             `json:"processed_rows"`
+	SuccessCount  int             `json:"success_count"`
+	ErrorCount    int             `json:"error_count"`
+	RowErrors     ImportRowErrors `json:"row_errors" gorm:"type:jsonb"`
+	CreatedJobIDs pq.Int64Array   `json:"created_job_ids" gorm:"type:integer[]"`
+	ErrorMessage  string          `json:"error_message,omitempty" gorm:"type:text"` // Fatal error for the whole file
+
+	// Timestamps
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (JobImport) TableName() string {
+	return "job_imports"
+}
+
+// ImportRowError describes why a single import row was rejected
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ImportRowErrors is stored as a JSONB array
+type ImportRowErrors []ImportRowError
+
+// Value implements driver.Valuer
+func (e ImportRowErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(e)
+}
+
+// Scan implements sql.Scanner
+func (e *ImportRowErrors) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	case nil:
+		*e = nil
+		return nil
+	}
+	return errors.New("unsupported type for import row errors")
+}
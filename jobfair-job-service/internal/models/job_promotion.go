@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+type PromotionPlacement string
+type PromotionSource string
+
+const (
+	PromotionPlacementPin   PromotionPlacement = "pin"   // Always shown first
+	PromotionPlacementBoost PromotionPlacement = "boost" // Rotated through the remaining promoted slots
+)
+
+const (
+	PromotionSourcePurchased PromotionSource = "purchased" // Counts against the plan's featured_jobs limit
+	PromotionSourceGranted   PromotionSource = "granted"   // Granted by an admin
+)
+
+// JobPromotion is a time-boxed featured placement of a job in listings and
+// homepage feeds
+type JobPromotion struct {
+	ID          uint               `json:"id" gorm:"primaryKey"`
+	JobID       uint               `json:"job_id" gorm:"not null;index"`
+	CompanyID   uint               `json:"company_id" gorm:"not null;index"`
+	Placement   PromotionPlacement `json:"placement" gorm:"type:varchar(20);not null"`
+	Source      PromotionSource    `json:"source" gorm:"type:varchar(20);not null"`
+	Priority    int                `json:"priority" gorm:"default:0"`
+	StartsAt    time.Time          `json:"starts_at" gorm:"not null"`
+	EndsAt      time.Time          `json:"ends_at" gorm:"not null"`
+	CancelledAt *time.Time         `json:"cancelled_at"`
+
+	// Tracking
+	Impressions int64 `json:"impressions" gorm:"default:0"`
+	Clicks      int64 `json:"clicks" gorm:"default:0"`
+
+	CreatedBy uint      `json:"created_by" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (JobPromotion) TableName() string {
+	return "job_promotions"
+}
+
+// IsActive reports whether the promotion is running at the given time
+func (p *JobPromotion) IsActive(at time.Time) bool {
+	return p.CancelledAt == nil && !p.StartsAt.After(at) && p.EndsAt.After(at)
+}
+
+// CTR returns the click-through rate in percent
+func (p *JobPromotion) CTR() float64 {
+	if p.Impressions == 0 {
+		return 0
+	}
+	return float64(p.Clicks) / float64(p.Impressions) * 100
+}
+
+// JobPromotionResponse is a promotion with its tracking stats
+type JobPromotionResponse struct {
+	*JobPromotion
+	Active bool    `json:"active"`
+	CTR    float64 `json:"ctr"` // Clicks per 100 impressions
+}
+
+// CreatePromotionRequest is the payload to promote a job. Priority only
+// applies to promotions granted by an admin.
+type CreatePromotionRequest struct {
+	Placement PromotionPlacement `json:"placement" binding:"omitempty,oneof=pin boost"`
+	StartsAt  *time.Time         `json:"starts_at"` // Defaults to now
+	EndsAt    time.Time          `json:"ends_at" binding:"required"`
+	Priority  int                `json:"priority" binding:"min=0,max=100"`
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// JobRevision is an immutable snapshot of a published job. A revision is
+// recorded when a job is published and on every later update to it.
+type JobRevision struct {
+	ID             uint        `json:"id" gorm:"primaryKey"`
+	JobID          uint        `json:"job_id" gorm:"not null;index"`
+	RevisionNumber int         `json:"revision_number" gorm:"not null"`
+	Snapshot       JobSnapshot `json:"snapshot" gorm:"type:jsonb;not null"`
+	ChangedBy      uint        `json:"changed_by"` // User who triggered the revision
+	CreatedAt      time.Time   `json:"created_at"`
+}
+
+func (JobRevision) TableName() string {
+	return "job_revisions"
+}
+
+// JobSnapshot holds the applicant-facing content of a job at a point in time
+type JobSnapshot struct {
+	Title            string          `json:"title"`
+	Description      string          `json:"description"`
+	EmploymentType   EmploymentType  `json:"employment_type"`
+	WorkType         WorkType        `json:"work_type"`
+	ExperienceLevel  ExperienceLevel `json:"experience_level"`
+	Location         string          `json:"location"`
+	City             string          `json:"city"`
+	Country          string          `json:"country"`
+	IsRemote         bool            `json:"is_remote"`
+	SalaryMin        int             `json:"salary_min"`
+	SalaryMax        int             `json:"salary_max"`
+	SalaryCurrency   string          `json:"salary_currency"`
+	SalaryPeriod     string          `json:"salary_period"`
+	Requirements     []string        `json:"requirements"`
+	Responsibilities []string        `json:"responsibilities"`
+	Skills           []string        `json:"skills"`
+	Benefits         []string        `json:"benefits"`
+	ReceiveMethod    string          `json:"receive_method"`
+	ContactEmail     string          `json:"contact_email"`
+	ExternalURL      *string         `json:"external_url"`
+	Deadline         *time.Time      `json:"deadline"`
+	Tags             []string        `json:"tags"`
+}
+
+// NewJobSnapshot captures the current content of a job
+func NewJobSnapshot(job *Job) JobSnapshot {
+	snapshot := JobSnapshot{
+		Title:            job.Title,
+		Description:      job.Description,
+		EmploymentType:   job.EmploymentType,
+		WorkType:         job.WorkType,
+		ExperienceLevel:  job.ExperienceLevel,
+		Location:         job.Location,
+		City:             job.City,
+		Country:          job.Country,
+		IsRemote:         job.IsRemote,
+		SalaryMin:        job.SalaryMin,
+		SalaryMax:        job.SalaryMax,
+		SalaryCurrency:   job.SalaryCurrency,
+		SalaryPeriod:     job.SalaryPeriod,
+		Requirements:     copyStrings(job.Requirements),
+		Responsibilities: copyStrings(job.Responsibilities),
+		Skills:           copyStrings(job.Skills),
+		Benefits:         copyStrings(job.Benefits),
+		ReceiveMethod:    job.ReceiveMethod,
+		ContactEmail:     job.ContactEmail,
+		ExternalURL:      job.ExternalURL,
+		Tags:             copyStrings(job.Tags),
+	}
+
+	if job.Deadline != nil {
+		deadline := job.Deadline.UTC()
+		snapshot.Deadline = &deadline
+	}
+
+	return snapshot
+}
+
+// Value implements driver.Valuer so the snapshot is stored as JSONB
+func (s JobSnapshot) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Scan implements sql.Scanner for reading the JSONB snapshot
+func (s *JobSnapshot) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		return nil
+	default:
+		return errors.New("unsupported type for job snapshot")
+	}
+	return json.Unmarshal(data, s)
+}
+
+// FieldChange describes a single field that differs between two revisions
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// Diff compares two snapshots field by field and returns the changed fields
+// in declaration order
+func (s JobSnapshot) Diff(other JobSnapshot) []FieldChange {
+	changes := []FieldChange{}
+
+	from := reflect.ValueOf(s)
+	to := reflect.ValueOf(other)
+	t := from.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		fromValue := from.Field(i).Interface()
+		toValue := to.Field(i).Interface()
+
+		// Compare by JSON encoding so nil/empty slices and time zones
+		// don't show up as spurious changes
+		fromJSON, _ := json.Marshal(fromValue)
+		toJSON, _ := json.Marshal(toValue)
+		if bytes.Equal(fromJSON, toJSON) {
+			continue
+		}
+
+		changes = append(changes, FieldChange{
+			Field: strings.Split(t.Field(i).Tag.Get("json"), ",")[0],
+			From:  fromValue,
+			To:    toValue,
+		})
+	}
+
+	return changes
+}
+
+// JobRevisionDiff is the response for comparing two revisions
+type JobRevisionDiff struct {
+	JobID        uint          `json:"job_id"`
+	FromRevision int           `json:"from_revision"`
+	ToRevision   int           `json:"to_revision"`
+	Changes      []FieldChange `json:"changes"`
+}
+
+func copyStrings(values []string) []string {
+	result := make([]string, len(values))
+	copy(result, values)
+	return result
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// JobTemplate is a reusable, company-scoped set of job fields
+type JobTemplate struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	CompanyID uint   `json:"company_id" gorm:"not null;index"`
+	UserID    uint   `json:"user_id" gorm:"not null"` // Company user who created the template
+	Name      string `json:"name" gorm:"not null"`
+
+	// Job fields
+	Title            string          `json:"title"`
+	Description      string          `json:"description" gorm:"type:text"`
+	EmploymentType   EmploymentType  `json:"employment_type" gorm:"type:varchar(50)"`
+	WorkType         WorkType        `json:"work_type" gorm:"type:varchar(50)"`
+	ExperienceLevel  ExperienceLevel `json:"experience_level" gorm:"type:varchar(50)"`
+	Location         string          `json:"location"`
+	SalaryMin        int             `json:"salary_min"`
+	SalaryMax        int             `json:"salary_max"`
+	Requirements     pq.StringArray  `json:"requirements" gorm:"type:text[]"`
+	Responsibilities pq.StringArray  `json:"responsibilities" gorm:"type:text[]"`
+	Skills           pq.StringArray  `json:"skills" gorm:"type:text[]"`
+	Benefits         pq.StringArray  `json:"benefits" gorm:"type:text[]"`
+	ReceiveMethod    string          `json:"receive_method"`
+	ContactEmail     string          `json:"contact_email"`
+	ExternalURL      *string         `json:"external_url"`
+	Tags             pq.StringArray  `json:"tags" gorm:"type:text[]"`
+
+	// Timestamps
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (JobTemplate) TableName() string {
+	return "job_templates"
+}
+
+// JobTemplateRequest is the request for creating or replacing a job template
+type JobTemplateRequest struct {
+	Name             string          `json:"name" binding:"required"`
+	Title            string          `json:"title"`
+	Description      string          `json:"description"`
+	EmploymentType   EmploymentType  `json:"employment_type"`
+	WorkType         WorkType        `json:"work_type"`
+	ExperienceLevel  ExperienceLevel `json:"experience_level"`
+	Location         string          `json:"location"`
+	SalaryMin        int             `json:"salary_min"`
+	SalaryMax        int             `json:"salary_max"`
+	Requirements     []string        `json:"requirements"`
+	Responsibilities []string        `json:"responsibilities"`
+	Skills           []string        `json:"skills"`
+	Benefits         []string        `json:"benefits"`
+	ReceiveMethod    string          `json:"receive_method"`
+	ContactEmail     string          `json:"contact_email"`
+	ExternalURL      *string         `json:"external_url"`
+	Tags             []string        `json:"tags"`
+}
@@ -0,0 +1,41 @@
+package monitoring
+
+import (
+	"time"
+
+	"jobfair-job-service/internal/repository"
+
+	"github.com/jobfair/shared/metrics"
+)
+
+// refreshInterval bounds how often scrapes query the database
+const refreshInterval = 30 * time.Second
+
+// RegisterBusinessMetrics exports job and application counts on /metrics:
+// jobfair_jobs{status} and jobfair_applications{status}
+func RegisterBusinessMetrics(jobRepo *repository.JobRepository, applicationRepo *repository.ApplicationRepository) {
+	metrics.MustRegister(
+		metrics.NewQueryGauge("jobs", "Jobs by status.", []string{"status"}, refreshInterval, func() ([]metrics.Sample, error) {
+			counts, err := jobRepo.CountGroupedByStatus()
+			if err != nil {
+				return nil, err
+			}
+			samples := make([]metrics.Sample, 0, len(counts))
+			for status, count := range counts {
+				samples = append(samples, metrics.Sample{Labels: []string{string(status)}, Value: float64(count)})
+			}
+			return samples, nil
+		}),
+		metrics.NewQueryGauge("applications", "Job applications by status.", []string{"status"}, refreshInterval, func() ([]metrics.Sample, error) {
+			counts, err := applicationRepo.CountGroupedByStatus()
+			if err != nil {
+				return nil, err
+			}
+			samples := make([]metrics.Sample, 0, len(counts))
+			for status, count := range counts {
+				samples = append(samples, metrics.Sample{Labels: []string{string(status)}, Value: float64(count)})
+			}
+			return samples, nil
+		}),
+	)
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ApplicantView is an application a company viewed in a month
+type ApplicantView struct {
+	CompanyID     uint      `gorm:"primaryKey;autoIncrement:false"`
+	Period        time.Time `gorm:"primaryKey;type:date"` // First day of the month (UTC)
+	ApplicationID uint      `gorm:"primaryKey;autoIncrement:false"`
+	ViewedAt      time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName specifies the table name for ApplicantView
+func (ApplicantView) TableName() string {
+	return "applicant_views"
+}
+
+type ApplicantViewRepository struct {
+	db *gorm.DB
+}
+
+func NewApplicantViewRepository(db *gorm.DB) *ApplicantViewRepository {
+	return &ApplicantViewRepository{db: db}
+}
+
+// Transaction runs fn with a repository bound to a single transaction
+func (r *ApplicantViewRepository) Transaction(fn func(txRepo *ApplicantViewRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&ApplicantViewRepository{db: tx})
+	})
+}
+
+// LockCompany serializes applicant view changes of a company until the
+// surrounding transaction ends
+func (r *ApplicantViewRepository) LockCompany(companyID uint) error {
+	return r.db.Exec("SELECT pg_advisory_xact_lock(hashtext('applicant_views'), ?)", companyID).Error
+}
+
+// Count returns the number of applications the company viewed in the period
+func (r *ApplicantViewRepository) Count(companyID uint, period time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&ApplicantView{}).
+		Where("company_id = ? AND period = ?", companyID, period).
+		Count(&count).Error
+	return count, err
+}
+
+// Viewed returns which of the applications the company already viewed in the period
+func (r *ApplicantViewRepository) Viewed(companyID uint, period time.Time, applicationIDs []uint) (map[uint]bool, error) {
+	viewed := make(map[uint]bool)
+	if len(applicationIDs) == 0 {
+		return viewed, nil
+	}
+
+	var ids []uint
+	if err := r.db.Model(&ApplicantView{}).
+		Where("company_id = ? AND period = ? AND application_id IN ?", companyID, period, applicationIDs).
+		Pluck("application_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		viewed[id] = true
+	}
+	return viewed, nil
+}
+
+// Record stores views of the applications in the period; views already
+// stored are kept
+func (r *ApplicantViewRepository) Record(companyID uint, period time.Time, applicationIDs []uint) error {
+	if len(applicationIDs) == 0 {
+		return nil
+	}
+
+	views := make([]ApplicantView, 0, len(applicationIDs))
+	for _, id := range applicationIDs {
+		views = append(views, ApplicantView{CompanyID: companyID, Period: period, ApplicationID: id})
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&views).Error
+}
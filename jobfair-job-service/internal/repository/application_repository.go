@@ -1,9 +1,11 @@
 package repository
 
 import (
+	"context"
 	"jobfair-job-service/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ApplicationRepository struct {
@@ -140,6 +142,34 @@ func (r *ApplicationRepository) CountByUserID(userID uint) (int64, error) {
 	return count, nil
 }
 
+// HasAppliedToEmployer reports whether a user has an application on any job
+// posted by the given company user
+func (r *ApplicationRepository) HasAppliedToEmployer(userID, employerUserID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.JobApplication{}).
+		Joins("JOIN jobs ON jobs.id = job_applications.job_id AND jobs.deleted_at IS NULL").
+		Where("job_applications.user_id = ? AND jobs.user_id = ?", userID, employerUserID).
+		Limit(1).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ApplicantUserIDsByEmployer returns the users with an application on any job
+// posted by the given company user
+func (r *ApplicationRepository) ApplicantUserIDsByEmployer(employerUserID uint) ([]uint, error) {
+	var userIDs []uint
+	if err := r.db.Model(&models.JobApplication{}).
+		Joins("JOIN jobs ON jobs.id = job_applications.job_id AND jobs.deleted_at IS NULL").
+		Where("jobs.user_id = ?", employerUserID).
+		Distinct().
+		Pluck("job_applications.user_id", &userIDs).Error; err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
+
 // CountByStatus counts applications by status
 func (r *ApplicationRepository) CountByStatus(jobID uint, status models.ApplicationStatus) (int64, error) {
 	var count int64
@@ -151,6 +181,26 @@ func (r *ApplicationRepository) CountByStatus(jobID uint, status models.Applicat
 	return count, nil
 }
 
+// CountGroupedByStatus counts applications of all jobs per status
+func (r *ApplicationRepository) CountGroupedByStatus() (map[models.ApplicationStatus]int64, error) {
+	var results []struct {
+		Status models.ApplicationStatus
+		Count  int64
+	}
+	if err := r.db.Model(&models.JobApplication{}).
+		Select("status, COUNT(*) as count").
+		Group("status").
+		Scan(&results).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[models.ApplicationStatus]int64, len(results))
+	for _, result := range results {
+		counts[result.Status] = result.Count
+	}
+	return counts, nil
+}
+
 // GetApplicationStats retrieves application statistics
 func (r *ApplicationRepository) GetApplicationStats(companyID uint) (map[string]int64, error) {
 	stats := make(map[string]int64)
@@ -184,3 +234,60 @@ func (r *ApplicationRepository) GetByUserIDAndJobIDs(userID uint, jobIDs []uint)
 	}
 	return applications, nil
 }
+
+// Transaction runs fn inside a database transaction with a repository bound to it
+func (r *ApplicationRepository) Transaction(fn func(txRepo *ApplicationRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&ApplicationRepository{db: tx})
+	})
+}
+
+// WithContext returns a repository whose queries run with ctx
+func (r *ApplicationRepository) WithContext(ctx context.Context) *ApplicationRepository {
+	return &ApplicationRepository{db: r.db.WithContext(ctx)}
+}
+
+// Revisions returns a job revision repository on the same connection, so
+// inside a transaction it reads in that transaction
+func (r *ApplicationRepository) Revisions() *JobRevisionRepository {
+	return &JobRevisionRepository{db: r.db}
+}
+
+// LockJob loads a job with a row lock (FOR UPDATE). Inside a transaction this
+// serialises concurrent applications to the same job.
+func (r *ApplicationRepository) LockJob(jobID uint) (*models.Job, error) {
+	var job models.Job
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&job, jobID).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CreateAndCount creates an application and increments the job's applications
+// count in the same statement batch, so both commit or roll back together
+func (r *ApplicationRepository) CreateAndCount(application *models.JobApplication) (*models.JobApplication, error) {
+	if err := r.db.Create(application).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Model(&models.Job{}).
+		Where("id = ?", application.JobID).
+		UpdateColumn("applications", gorm.Expr("applications + ?", 1)).Error; err != nil {
+		return nil, err
+	}
+
+	return application, nil
+}
+
+// DeleteAndCount soft deletes an application and decrements the job's applications count
+func (r *ApplicationRepository) DeleteAndCount(application *models.JobApplication) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.JobApplication{}, application.ID).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Job{}).
+			Where("id = ?", application.JobID).
+			UpdateColumn("applications", gorm.Expr("GREATEST(applications - 1, 0)")).Error
+	})
+}
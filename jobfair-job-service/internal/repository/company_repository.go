@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"context"
 	"time"
 
+	"github.com/jobfair/shared/plans"
 	"gorm.io/gorm"
 )
 
@@ -11,8 +13,13 @@ type CompanyMapping struct {
 	UserID      uint      `gorm:"not null;uniqueIndex"`
 	CompanyID   uint      `gorm:"not null"`
 	CompanyName string    `gorm:"type:varchar(255)"`
+	IsVerified  bool      `gorm:"not null;default:false"`
+	VerifiedAt  *time.Time
 	CreatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP"`
 	UpdatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Tier stored on the company, for companies without subscription periods
+	SubscriptionTier plans.Tier `gorm:"type:varchar(50)"`
 }
 
 // TableName specifies the table name for CompanyMapping
@@ -28,6 +35,11 @@ func NewCompanyRepository(db *gorm.DB) *CompanyRepository {
 	return &CompanyRepository{db: db}
 }
 
+// WithContext returns a repository whose queries run with ctx
+func (r *CompanyRepository) WithContext(ctx context.Context) *CompanyRepository {
+	return &CompanyRepository{db: r.db.WithContext(ctx)}
+}
+
 // GetCompanyIDByUserID gets company ID from user ID via mapping table
 func (r *CompanyRepository) GetCompanyIDByUserID(userID uint) (uint, error) {
 	var mapping CompanyMapping
@@ -57,6 +69,85 @@ func (r *CompanyRepository) UpsertCompanyMapping(userID, companyID uint, company
 		FirstOrCreate(&mapping).Error
 }
 
+// SetCompanyVerified stores the company's verification status. The mapping is
+// created first if the company.registered event hasn't been processed yet.
+func (r *CompanyRepository) SetCompanyVerified(userID, companyID uint, companyName string, verified bool, verifiedAt *time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&CompanyMapping{}).Where("company_id = ?", companyID).Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count == 0 && userID != 0 {
+			mapping := CompanyMapping{UserID: userID, CompanyID: companyID, CompanyName: companyName}
+			if err := tx.Where("user_id = ?", userID).Assign(mapping).FirstOrCreate(&mapping).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&CompanyMapping{}).
+			Where("company_id = ?", companyID).
+			Updates(map[string]interface{}{
+				"is_verified": verified,
+				"verified_at": verifiedAt,
+				"updated_at":  time.Now(),
+			}).Error
+	})
+}
+
+// SetSubscriptionTier stores the subscription tier of the company
+func (r *CompanyRepository) SetSubscriptionTier(companyID uint, tier plans.Tier) error {
+	return r.db.Model(&CompanyMapping{}).
+		Where("company_id = ?", companyID).
+		Updates(map[string]interface{}{
+			"subscription_tier": tier,
+			"updated_at":        time.Now(),
+		}).Error
+}
+
+// GetSubscriptionTier returns the subscription tier stored on the company,
+// empty if no event has carried it yet
+func (r *CompanyRepository) GetSubscriptionTier(companyID uint) (plans.Tier, error) {
+	var tiers []plans.Tier
+	err := r.db.Model(&CompanyMapping{}).
+		Where("company_id = ? AND subscription_tier IS NOT NULL", companyID).
+		Limit(1).
+		Pluck("subscription_tier", &tiers).Error
+	if err != nil || len(tiers) == 0 {
+		return "", err
+	}
+	return tiers[0], nil
+}
+
+// IsCompanyVerified reports whether the company has been verified
+func (r *CompanyRepository) IsCompanyVerified(companyID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&CompanyMapping{}).
+		Where("company_id = ? AND is_verified = ?", companyID, true).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetVerifiedCompanies returns which of the given companies are verified
+func (r *CompanyRepository) GetVerifiedCompanies(companyIDs []uint) (map[uint]bool, error) {
+	verified := make(map[uint]bool)
+	if len(companyIDs) == 0 {
+		return verified, nil
+	}
+
+	var ids []uint
+	if err := r.db.Model(&CompanyMapping{}).
+		Where("company_id IN ? AND is_verified = ?", companyIDs, true).
+		Pluck("company_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		verified[id] = true
+	}
+	return verified, nil
+}
+
 // DeleteMappingByUserID deletes company mapping by user ID
 func (r *CompanyRepository) DeleteMappingByUserID(userID uint) error {
 	return r.db.Where("user_id = ?", userID).
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"time"
+
+	"jobfair-job-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type JobImportRepository struct {
+	db *gorm.DB
+}
+
+func NewJobImportRepository(db *gorm.DB) *JobImportRepository {
+	return &JobImportRepository{db: db}
+}
+
+// Create creates a new import record
+func (r *JobImportRepository) Create(jobImport *models.JobImport) (*models.JobImport, error) {
+	if err := r.db.Create(jobImport).Error; err != nil {
+		return nil, err
+	}
+	return jobImport, nil
+}
+
+// GetByID retrieves an import by ID
+func (r *JobImportRepository) GetByID(id uint) (*models.JobImport, error) {
+	var jobImport models.JobImport
+	if err := r.db.First(&jobImport, id).Error; err != nil {
+		return nil, err
+	}
+	return &jobImport, nil
+}
+
+// GetByCompanyID retrieves the most recent imports of a company
+func (r *JobImportRepository) GetByCompanyID(companyID uint, limit int) ([]*models.JobImport, error) {
+	var imports []*models.JobImport
+	if err := r.db.Where("company_id = ?", companyID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&imports).Error; err != nil {
+		return nil, err
+	}
+	return imports, nil
+}
+
+// Update updates an import record
+func (r *JobImportRepository) Update(jobImport *models.JobImport) error {
+	return r.db.Save(jobImport).Error
+}
+
+// FailStale marks pending and processing imports not updated since before as
+// failed with message, and returns how many were marked
+func (r *JobImportRepository) FailStale(before time.Time, message string) (int64, error) {
+	result := r.db.Model(&models.JobImport{}).
+		Where("status IN ?", []models.ImportStatus{models.ImportStatusPending, models.ImportStatusProcessing}).
+		Where("updated_at < ?", before).
+		Updates(map[string]interface{}{
+			"status":        models.ImportStatusFailed,
+			"error_message": message,
+			"completed_at":  time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
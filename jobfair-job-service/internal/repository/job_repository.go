@@ -1,10 +1,13 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"jobfair-job-service/internal/models"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type JobRepository struct {
@@ -51,49 +54,69 @@ func (r *JobRepository) Delete(id uint) error {
 	return r.db.Delete(&models.Job{}, id).Error
 }
 
-// List retrieves jobs with filters and pagination
-func (r *JobRepository) List(filter models.JobListFilter) ([]*models.Job, int64, error) {
-	var jobs []*models.Job
-	var total int64
-
-	query := r.db.Model(&models.Job{})
+// Transaction runs fn with a repository bound to a database transaction
+func (r *JobRepository) Transaction(fn func(txRepo *JobRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&JobRepository{db: tx})
+	})
+}
 
-	// Apply filters
-	if filter.Search != "" {
-		query = query.Where("title ILIKE ? OR description ILIKE ?", "%"+filter.Search+"%", "%"+filter.Search+"%")
-	}
+// Revisions returns a revision repository on the same connection, so
+// revisions written through it join the job repository's transaction
+func (r *JobRepository) Revisions() *JobRevisionRepository {
+	return &JobRevisionRepository{db: r.db}
+}
 
-	if len(filter.EmploymentType) > 0 {
-		query = query.Where("employment_type IN ?", filter.EmploymentType)
-	}
+// WithContext returns a repository whose queries run with ctx, so they are
+// cancelled with the request and traced as part of it
+func (r *JobRepository) WithContext(ctx context.Context) *JobRepository {
+	return &JobRepository{db: r.db.WithContext(ctx)}
+}
 
-	if len(filter.WorkType) > 0 {
-		query = query.Where("work_type IN ?", filter.WorkType)
-	}
+// LockCompanyJobs takes a transaction-scoped advisory lock on the company's
+// jobs. Inside a transaction this serialises quota checks of one company.
+func (r *JobRepository) LockCompanyJobs(companyID uint) error {
+	return r.db.Exec("SELECT pg_advisory_xact_lock(hashtext('jobs'), ?)", companyID).Error
+}
 
-	if len(filter.ExperienceLevel) > 0 {
-		query = query.Where("experience_level IN ?", filter.ExperienceLevel)
-	}
+// CountActiveByCompany counts the company's jobs that take a job slot:
+// published jobs and drafts scheduled for publishing. excludeJobID (0 for
+// none) is left out of the count.
+func (r *JobRepository) CountActiveByCompany(companyID, excludeJobID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Job{}).
+		Where("company_id = ? AND id <> ?", companyID, excludeJobID).
+		Where("status = ? OR (status = ? AND publish_at IS NOT NULL)", models.JobStatusPublished, models.JobStatusDraft).
+		Count(&count).Error
+	return count, err
+}
 
-	if filter.Location != "" {
-		query = query.Where("location ILIKE ? OR city ILIKE ?", "%"+filter.Location+"%", "%"+filter.Location+"%")
+// CountGroupedByStatus counts jobs per status
+func (r *JobRepository) CountGroupedByStatus() (map[models.JobStatus]int64, error) {
+	var results []struct {
+		Status models.JobStatus
+		Count  int64
 	}
-
-	if filter.SalaryMin > 0 {
-		query = query.Where("salary_max >= ?", filter.SalaryMin)
+	if err := r.db.Model(&models.Job{}).
+		Select("status, COUNT(*) as count").
+		Group("status").
+		Scan(&results).Error; err != nil {
+		return nil, err
 	}
 
-	if filter.SalaryMax > 0 {
-		query = query.Where("salary_min <= ?", filter.SalaryMax)
+	counts := make(map[models.JobStatus]int64, len(results))
+	for _, result := range results {
+		counts[result.Status] = result.Count
 	}
+	return counts, nil
+}
 
-	if filter.CompanyID > 0 {
-		query = query.Where("company_id = ?", filter.CompanyID)
-	}
+// List retrieves jobs with filters and pagination
+func (r *JobRepository) List(filter models.JobListFilter) ([]*models.Job, int64, error) {
+	var jobs []*models.Job
+	var total int64
 
-	if filter.Status != "" {
-		query = query.Where("status = ?", filter.Status)
-	}
+	query := r.filtered(filter)
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -129,6 +152,50 @@ func (r *JobRepository) List(filter models.JobListFilter) ([]*models.Job, int64,
 	return jobs, total, nil
 }
 
+// filtered returns a query on jobs matching the listing filters
+func (r *JobRepository) filtered(filter models.JobListFilter) *gorm.DB {
+	query := r.db.Model(&models.Job{})
+
+	// Apply filters
+	if filter.Search != "" {
+		query = query.Where("title ILIKE ? OR description ILIKE ?", "%"+filter.Search+"%", "%"+filter.Search+"%")
+	}
+
+	if len(filter.EmploymentType) > 0 {
+		query = query.Where("employment_type IN ?", filter.EmploymentType)
+	}
+
+	if len(filter.WorkType) > 0 {
+		query = query.Where("work_type IN ?", filter.WorkType)
+	}
+
+	if len(filter.ExperienceLevel) > 0 {
+		query = query.Where("experience_level IN ?", filter.ExperienceLevel)
+	}
+
+	if filter.Location != "" {
+		query = query.Where("location ILIKE ? OR city ILIKE ?", "%"+filter.Location+"%", "%"+filter.Location+"%")
+	}
+
+	if filter.SalaryMin > 0 {
+		query = query.Where("salary_max >= ?", filter.SalaryMin)
+	}
+
+	if filter.SalaryMax > 0 {
+		query = query.Where("salary_min <= ?", filter.SalaryMax)
+	}
+
+	if filter.CompanyID > 0 {
+		query = query.Where("company_id = ?", filter.CompanyID)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	return query
+}
+
 // GetByCompanyID retrieves jobs by company ID
 func (r *JobRepository) GetByCompanyID(companyID uint) ([]*models.Job, error) {
 	var jobs []*models.Job
@@ -152,16 +219,6 @@ func (r *JobRepository) IncrementViews(jobID uint) error {
 	return r.db.Model(&models.Job{}).Where("id = ?", jobID).UpdateColumn("views", gorm.Expr("views + ?", 1)).Error
 }
 
-// IncrementApplications increments job applications count
-func (r *JobRepository) IncrementApplications(jobID uint) error {
-	return r.db.Model(&models.Job{}).Where("id = ?", jobID).UpdateColumn("applications", gorm.Expr("applications + ?", 1)).Error
-}
-
-// DecrementApplications decrements job applications count
-func (r *JobRepository) DecrementApplications(jobID uint) error {
-	return r.db.Model(&models.Job{}).Where("id = ?", jobID).UpdateColumn("applications", gorm.Expr("applications - ?", 1)).Error
-}
-
 // GetPopularJobs retrieves popular jobs (most views)
 func (r *JobRepository) GetPopularJobs(limit int) ([]*models.Job, error) {
 	var jobs []*models.Job
@@ -185,3 +242,150 @@ func (r *JobRepository) GetRecentJobs(limit int) ([]*models.Job, error) {
 	}
 	return jobs, nil
 }
+
+// ListPromoted returns up to limit jobs matching the filter that have a
+// promotion running at the given time, marked as promoted. Pinned jobs come
+// first, then higher priorities; boosts with the fewest impressions come first
+// so promoted slots rotate between them.
+func (r *JobRepository) ListPromoted(filter models.JobListFilter, at time.Time, limit int) ([]*models.Job, error) {
+	var promotions []models.JobPromotion
+	if err := r.db.Where("cancelled_at IS NULL AND starts_at <= ? AND ends_at > ?", at, at).
+		Where("job_id IN (?)", r.filtered(filter).Select("id")).
+		Order("CASE placement WHEN 'pin' THEN 0 ELSE 1 END, priority DESC, impressions ASC, id ASC").
+		Limit(limit).
+		Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+	if len(promotions) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint, len(promotions))
+	for i, promotion := range promotions {
+		ids[i] = promotion.JobID
+	}
+	var found []*models.Job
+	if err := r.db.Where("id IN ?", ids).Find(&found).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*models.Job, len(found))
+	for _, job := range found {
+		byID[job.ID] = job
+	}
+
+	jobs := make([]*models.Job, 0, len(promotions))
+	for _, promotion := range promotions {
+		job, ok := byID[promotion.JobID]
+		if !ok {
+			continue
+		}
+		promotionID := promotion.ID
+		job.Promoted = true
+		job.PromotionID = &promotionID
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// PublishDueJobs publishes scheduled drafts whose publish_at has passed.
+// Due rows are claimed with FOR UPDATE SKIP LOCKED so several replicas can
+// run the scheduler at the same time without publishing a job twice.
+// With verifiedOnly, only jobs of verified companies are published.
+// onPublished runs for each job in the same transaction.
+func (r *JobRepository) PublishDueJobs(now time.Time, limit int, verifiedOnly bool, onPublished func(tx *JobRepository, job *models.Job) error) ([]*models.Job, error) {
+	var jobs []*models.Job
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", models.JobStatusDraft).
+			Where("publish_at IS NOT NULL AND publish_at <= ?", now).
+			Where("deadline IS NULL OR deadline > ?", now)
+		if verifiedOnly {
+			query = query.Where("company_id IN (SELECT company_id FROM company_mappings WHERE is_verified)")
+		}
+
+		if err := query.Order("publish_at ASC").
+			Limit(limit).
+			Find(&jobs).Error; err != nil {
+			return err
+		}
+
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&models.Job{}).
+			Where("id IN ?", jobIDs(jobs)).
+			Updates(map[string]interface{}{
+				"status":       models.JobStatusPublished,
+				"published_at": now,
+				"publish_at":   nil,
+			}).Error; err != nil {
+			return err
+		}
+
+		txRepo := &JobRepository{db: tx}
+		for _, job := range jobs {
+			job.Status = models.JobStatusPublished
+			job.PublishedAt = &now
+			job.PublishAt = nil
+			if onPublished != nil {
+				if err := onPublished(txRepo, job); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// CloseExpiredJobs closes published jobs whose deadline has passed.
+// Like PublishDueJobs it claims rows with FOR UPDATE SKIP LOCKED.
+func (r *JobRepository) CloseExpiredJobs(now time.Time, limit int) ([]*models.Job, error) {
+	var jobs []*models.Job
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", models.JobStatusPublished).
+			Where("deadline IS NOT NULL AND deadline <= ?", now).
+			Order("deadline ASC").
+			Limit(limit).
+			Find(&jobs).Error; err != nil {
+			return err
+		}
+
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		return tx.Model(&models.Job{}).
+			Where("id IN ?", jobIDs(jobs)).
+			Updates(map[string]interface{}{
+				"status":    models.JobStatusClosed,
+				"closed_at": now,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, job := range jobs {
+		job.Status = models.JobStatusClosed
+		job.ClosedAt = &now
+	}
+
+	return jobs, nil
+}
+
+func jobIDs(jobs []*models.Job) []uint {
+	ids := make([]uint, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.ID
+	}
+	return ids
+}
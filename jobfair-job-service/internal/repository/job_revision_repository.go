@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"jobfair-job-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JobRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRevisionRepository(db *gorm.DB) *JobRevisionRepository {
+	return &JobRevisionRepository{db: db}
+}
+
+// Create stores a new revision with the next revision number for the job.
+// The job row is locked while numbering so concurrent updates get distinct numbers.
+func (r *JobRevisionRepository) Create(revision *models.JobRevision) (*models.JobRevision, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var job models.Job
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			First(&job, revision.JobID).Error; err != nil {
+			return err
+		}
+
+		var current int
+		if err := tx.Model(&models.JobRevision{}).
+			Where("job_id = ?", revision.JobID).
+			Select("COALESCE(MAX(revision_number), 0)").
+			Scan(&current).Error; err != nil {
+			return err
+		}
+
+		revision.RevisionNumber = current + 1
+		return tx.Create(revision).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// GetLatest retrieves the most recent revision of a job
+func (r *JobRevisionRepository) GetLatest(jobID uint) (*models.JobRevision, error) {
+	var revision models.JobRevision
+	if err := r.db.Where("job_id = ?", jobID).Order("revision_number DESC").First(&revision).Error; err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// GetByNumber retrieves a specific revision of a job
+func (r *JobRevisionRepository) GetByNumber(jobID uint, revisionNumber int) (*models.JobRevision, error) {
+	var revision models.JobRevision
+	if err := r.db.Where("job_id = ? AND revision_number = ?", jobID, revisionNumber).First(&revision).Error; err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// ListByJobID retrieves all revisions of a job, newest first
+func (r *JobRevisionRepository) ListByJobID(jobID uint) ([]*models.JobRevision, error) {
+	var revisions []*models.JobRevision
+	if err := r.db.Where("job_id = ?", jobID).Order("revision_number DESC").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
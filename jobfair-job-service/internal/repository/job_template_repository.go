@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"jobfair-job-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type JobTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewJobTemplateRepository(db *gorm.DB) *JobTemplateRepository {
+	return &JobTemplateRepository{db: db}
+}
+
+// Create creates a new job template
+func (r *JobTemplateRepository) Create(template *models.JobTemplate) (*models.JobTemplate, error) {
+	if err := r.db.Create(template).Error; err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// GetByID retrieves a job template by ID
+func (r *JobTemplateRepository) GetByID(id uint) (*models.JobTemplate, error) {
+	var template models.JobTemplate
+	if err := r.db.First(&template, id).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetByCompanyID retrieves all templates of a company
+func (r *JobTemplateRepository) GetByCompanyID(companyID uint) ([]*models.JobTemplate, error) {
+	var templates []*models.JobTemplate
+	if err := r.db.Where("company_id = ?", companyID).Order("name ASC").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// Update updates a job template
+func (r *JobTemplateRepository) Update(template *models.JobTemplate) error {
+	return r.db.Save(template).Error
+}
+
+// Delete soft deletes a job template
+func (r *JobTemplateRepository) Delete(id uint) error {
+	return r.db.Delete(&models.JobTemplate{}, id).Error
+}
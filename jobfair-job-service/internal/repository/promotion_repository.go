@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"time"
+
+	"jobfair-job-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type PromotionRepository struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepository(db *gorm.DB) *PromotionRepository {
+	return &PromotionRepository{db: db}
+}
+
+// Transaction runs fn with a repository bound to a single transaction
+func (r *PromotionRepository) Transaction(fn func(txRepo *PromotionRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&PromotionRepository{db: tx})
+	})
+}
+
+// LockCompanyPromotions serializes promotion changes of a company until the
+// surrounding transaction ends
+func (r *PromotionRepository) LockCompanyPromotions(companyID uint) error {
+	return r.db.Exec("SELECT pg_advisory_xact_lock(hashtext('job_promotions'), ?)", companyID).Error
+}
+
+func (r *PromotionRepository) Create(promotion *models.JobPromotion) error {
+	return r.db.Create(promotion).Error
+}
+
+func (r *PromotionRepository) GetByID(id uint) (*models.JobPromotion, error) {
+	var promotion models.JobPromotion
+	if err := r.db.First(&promotion, id).Error; err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// ListByJob returns the promotions of a job, newest first
+func (r *PromotionRepository) ListByJob(jobID uint) ([]*models.JobPromotion, error) {
+	var promotions []*models.JobPromotion
+	if err := r.db.Where("job_id = ?", jobID).
+		Order("starts_at DESC, id DESC").
+		Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+// HasOverlap reports whether the job has a promotion that is not cancelled
+// and overlaps the period
+func (r *PromotionRepository) HasOverlap(jobID uint, start, end time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.JobPromotion{}).
+		Where("job_id = ? AND cancelled_at IS NULL", jobID).
+		Where("starts_at < ? AND ends_at > ?", end, start).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CountOverlappingByCompany counts the company's promotions of the source that
+// are not cancelled and overlap the period
+func (r *PromotionRepository) CountOverlappingByCompany(companyID uint, source models.PromotionSource, start, end time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.JobPromotion{}).
+		Where("company_id = ? AND source = ? AND cancelled_at IS NULL", companyID, source).
+		Where("starts_at < ? AND ends_at > ?", end, start).
+		Count(&count).Error
+	return count, err
+}
+
+// Cancel ends the promotion at the given time
+func (r *PromotionRepository) Cancel(id uint, at time.Time) error {
+	return r.db.Model(&models.JobPromotion{}).
+		Where("id = ? AND cancelled_at IS NULL", id).
+		Update("cancelled_at", at).Error
+}
+
+// IncrementImpressions counts by impressions for each promotion
+func (r *PromotionRepository) IncrementImpressions(ids []uint, by int) error {
+	return r.db.Model(&models.JobPromotion{}).
+		Where("id IN ?", ids).
+		UpdateColumn("impressions", gorm.Expr("impressions + ?", by)).Error
+}
+
+// IncrementClicks counts a click on the promotion if it is a promotion of the
+// job running at the given time. It reports whether a click was counted.
+func (r *PromotionRepository) IncrementClicks(id, jobID uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.JobPromotion{}).
+		Where("id = ? AND job_id = ?", id, jobID).
+		Where("cancelled_at IS NULL AND starts_at <= ? AND ends_at > ?", at, at).
+		UpdateColumn("clicks", gorm.Expr("clicks + ?", 1))
+	return result.RowsAffected > 0, result.Error
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"jobfair-job-service/internal/models"
 
 	"gorm.io/gorm"
@@ -14,6 +15,11 @@ func NewSavedJobRepository(db *gorm.DB) *SavedJobRepository {
 	return &SavedJobRepository{db: db}
 }
 
+// WithContext returns a repository whose queries run with ctx
+func (r *SavedJobRepository) WithContext(ctx context.Context) *SavedJobRepository {
+	return &SavedJobRepository{db: r.db.WithContext(ctx)}
+}
+
 // Create saves a job
 func (r *SavedJobRepository) Create(savedJob *models.SavedJob) (*models.SavedJob, error) {
 	if err := r.db.Create(savedJob).Error; err != nil {
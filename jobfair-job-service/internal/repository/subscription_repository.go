@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jobfair/shared/plans"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CompanySubscription is a subscription period of a company, replicated from
+// company-service
+type CompanySubscription struct {
+	ID             uint       `gorm:"primaryKey"`
+	SubscriptionID uint       `gorm:"not null;uniqueIndex"`
+	CompanyID      uint       `gorm:"not null"`
+	Tier           plans.Tier `gorm:"type:varchar(50);not null"`
+	ActiveJobSlots int        `gorm:"not null"`
+	FeaturedJobs   int        `gorm:"not null"`
+	ApplicantViews int        `gorm:"not null"`
+	TeamSeats      int        `gorm:"not null"`
+	EffectiveFrom  time.Time  `gorm:"not null"`
+	EffectiveUntil *time.Time
+	CreatedAt      time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt      time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName specifies the table name for CompanySubscription
+func (CompanySubscription) TableName() string {
+	return "company_subscriptions"
+}
+
+// Limits returns the plan limits of the subscription
+func (s *CompanySubscription) Limits() plans.Limits {
+	return plans.Limits{
+		ActiveJobSlots: s.ActiveJobSlots,
+		FeaturedJobs:   s.FeaturedJobs,
+		ApplicantViews: s.ApplicantViews,
+		TeamSeats:      s.TeamSeats,
+	}
+}
+
+type SubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionRepository(db *gorm.DB) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db}
+}
+
+// Upsert stores a subscription period; redelivered events update the same row
+func (r *SubscriptionRepository) Upsert(subscription *CompanySubscription) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "subscription_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"company_id", "tier", "active_job_slots", "featured_jobs",
+			"applicant_views", "team_seats", "effective_from", "effective_until", "updated_at",
+		}),
+	}).Create(subscription).Error
+}
+
+// Exists reports whether the company has any subscription period, current or not
+func (r *SubscriptionRepository) Exists(companyID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&CompanySubscription{}).Where("company_id = ?", companyID).Count(&count).Error
+	return count > 0, err
+}
+
+// GetEffective returns the company's subscription in effect at the given
+// time: the latest period that has started and not ended. It returns nil if
+// the company has none.
+func (r *SubscriptionRepository) GetEffective(companyID uint, at time.Time) (*CompanySubscription, error) {
+	var subscription CompanySubscription
+	err := r.db.Where("company_id = ? AND effective_from <= ?", companyID, at).
+		Where("effective_until IS NULL OR effective_until > ?", at).
+		Order("effective_from DESC, subscription_id DESC").
+		First(&subscription).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"jobfair-job-service/internal/services"
+)
+
+// JobScheduler periodically publishes scheduled drafts and closes jobs whose
+// deadline has passed. Every replica may run a scheduler; due jobs are claimed
+// with row locks in the repository so each job is transitioned exactly once.
+type JobScheduler struct {
+	jobService *services.JobService
+	interval   time.Duration
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewJobScheduler(jobService *services.JobService, interval time.Duration) *JobScheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	return &JobScheduler{
+		jobService: jobService,
+		interval:   interval,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Start runs the scheduler loop in the background
+func (s *JobScheduler) Start() {
+	slog.Info("Job scheduler started", "interval", s.interval)
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.run()
+		for {
+			select {
+			case <-ticker.C:
+				s.run()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the scheduler and waits for the current pass to finish
+func (s *JobScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	<-s.done
+	slog.Info("Job scheduler stopped")
+}
+
+// run executes a single scheduler pass
+func (s *JobScheduler) run() {
+	published, err := s.jobService.PublishScheduledJobs()
+	if err != nil {
+		slog.Error("Failed to publish scheduled jobs", "error", err)
+	} else if published > 0 {
+		slog.Info("Scheduled jobs published", "jobs", published)
+	}
+
+	closed, err := s.jobService.CloseExpiredJobs()
+	if err != nil {
+		slog.Error("Failed to close expired jobs", "error", err)
+	} else if closed > 0 {
+		slog.Info("Expired jobs closed", "jobs", closed)
+	}
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"jobfair-job-service/internal/models"
+
+	"github.com/jobfair/shared/events"
+)
+
+// AnalyticsTracker publishes job interactions (views, saves, applies, hires)
+// for company analytics. A nil tracker or publisher tracks nothing.
+type AnalyticsTracker struct {
+	publisher *events.Publisher
+}
+
+func NewAnalyticsTracker(publisher *events.Publisher) *AnalyticsTracker {
+	return &AnalyticsTracker{publisher: publisher}
+}
+
+// Track publishes the event in the background; failures are logged.
+// userID is 0 for anonymous visitors.
+func (t *AnalyticsTracker) Track(eventType string, job *models.Job, userID uint) {
+	if !t.enabled() || job == nil {
+		return
+	}
+
+	data := events.AnalyticsData{
+		CompanyID:  job.CompanyID,
+		JobID:      job.ID,
+		JobTitle:   job.Title,
+		UserID:     userID,
+		OccurredAt: time.Now(),
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := t.publisher.PublishAnalytics(ctx, eventType, data); err != nil {
+			slog.Warn("Failed to publish analytics event", "event_type", eventType, "job_id", data.JobID, "error", err)
+		}
+	}()
+}
+
+func (t *AnalyticsTracker) enabled() bool {
+	return t != nil && t.publisher != nil
+}
@@ -1,30 +1,42 @@
 package services
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
-	"fmt"
-	"io"
 	"jobfair-job-service/internal/models"
 	"jobfair-job-service/internal/repository"
-	"net/http"
+	"log/slog"
 	"time"
+
+	"github.com/jobfair/shared/events"
+	"github.com/jobfair/shared/plans"
 )
 
 type ApplicationService struct {
-	applicationRepo   *repository.ApplicationRepository
-	jobRepo           *repository.JobRepository
-	companyServiceURL string
+	applicationRepo *repository.ApplicationRepository
+	jobRepo         *repository.JobRepository
+	viewRepo        *repository.ApplicantViewRepository
+	jobService      *JobService
+	companies       *CompanyClient
+	analytics       *AnalyticsTracker
 }
 
+// NewApplicationService creates an application service. Applications shown
+// to companies count against the applicant_views limit of their plan when
+// jobService enforces plan quotas.
 func NewApplicationService(
 	applicationRepo *repository.ApplicationRepository,
-	jobRepo *repository.JobRepository, companyServiceURL string,
+	jobRepo *repository.JobRepository, viewRepo *repository.ApplicantViewRepository,
+	jobService *JobService, companies *CompanyClient,
+	analytics *AnalyticsTracker,
 ) *ApplicationService {
 	return &ApplicationService{
-		applicationRepo:   applicationRepo,
-		jobRepo:           jobRepo,
-		companyServiceURL: companyServiceURL,
+		applicationRepo: applicationRepo,
+		jobRepo:         jobRepo,
+		viewRepo:        viewRepo,
+		jobService:      jobService,
+		companies:       companies,
+		analytics:       analytics,
 	}
 }
 
@@ -48,6 +60,9 @@ func (s *ApplicationService) GetApplicationByID(applicationID, userID uint, isCo
 		if job.UserID != userID {
 			return nil, errors.New("unauthorized to view this application")
 		}
+		if err := s.recordApplicantViews(job.CompanyID, []*models.JobApplication{application}); err != nil {
+			return nil, err
+		}
 	}
 
 	return application, nil
@@ -91,6 +106,9 @@ func (s *ApplicationService) GetApplicationsByJobID(jobID, userID uint, status m
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := s.recordApplicantViews(job.CompanyID, applications); err != nil {
+		return nil, nil, err
+	}
 
 	totalPages := int(total) / limit
 	if int(total)%limit > 0 {
@@ -147,6 +165,7 @@ func (s *ApplicationService) UpdateApplicationStatus(applicationID, userID uint,
 	}
 
 	// Update status
+	wasHired := application.Status == models.ApplicationStatusHired
 	application.Status = req.Status
 	application.StatusNote = req.StatusNote
 
@@ -168,6 +187,10 @@ func (s *ApplicationService) UpdateApplicationStatus(applicationID, userID uint,
 		return nil, err
 	}
 
+	if !wasHired && application.Status == models.ApplicationStatusHired {
+		s.analytics.Track(events.EventTypeCandidateHired, job, application.UserID)
+	}
+
 	return application, nil
 }
 
@@ -183,15 +206,8 @@ func (s *ApplicationService) WithdrawApplication(applicationID, userID uint) err
 		return errors.New("unauthorized to withdraw this application")
 	}
 
-	// Delete application
-	if err := s.applicationRepo.Delete(applicationID); err != nil {
-		return err
-	}
-
-	// Decrement job applications count
-	go s.jobRepo.DecrementApplications(application.JobID)
-
-	return nil
+	// Delete application and decrement job applications count atomically
+	return s.applicationRepo.DeleteAndCount(application)
 }
 
 // GetApplicationStats retrieves application statistics for a company
@@ -204,8 +220,18 @@ func (s *ApplicationService) GetUserApplicationsCount(userID uint) (int64, error
 	return s.applicationRepo.CountByUserID(userID)
 }
 
+// HasAppliedToEmployer reports whether a user applied to any job posted by the company user
+func (s *ApplicationService) HasAppliedToEmployer(userID, employerUserID uint) (bool, error) {
+	return s.applicationRepo.HasAppliedToEmployer(userID, employerUserID)
+}
+
+// GetApplicantUserIDs returns the users who applied to any job posted by the company user
+func (s *ApplicationService) GetApplicantUserIDs(employerUserID uint) ([]uint, error) {
+	return s.applicationRepo.ApplicantUserIDsByEmployer(employerUserID)
+}
+
 // EnrichApplicationsWithCompanyData enriches applications with company data
-func (s *ApplicationService) EnrichApplicationsWithCompanyData(applications []*models.JobApplication) ([]models.ApplicationWithCompany, error) {
+func (s *ApplicationService) EnrichApplicationsWithCompanyData(ctx context.Context, applications []*models.JobApplication) ([]models.ApplicationWithCompany, error) {
 	if len(applications) == 0 {
 		return []models.ApplicationWithCompany{}, nil
 	}
@@ -221,9 +247,9 @@ func (s *ApplicationService) EnrichApplicationsWithCompanyData(applications []*m
 	// Fetch company data for all unique IDs
 	companyDataMap := make(map[uint]map[string]interface{})
 	for companyID := range companyIDs {
-		companyData, err := s.fetchCompanyData(companyID)
+		companyData, err := s.fetchCompanyData(ctx, companyID)
 		if err != nil {
-			fmt.Printf("[WARNING] Failed to fetch company %d: %v\n", companyID, err)
+			slog.Warn("Failed to fetch company", "company_id", companyID, "error", err)
 			// Use fallback company data if fetch fails
 			companyDataMap[companyID] = map[string]interface{}{
 				"id":   companyID,
@@ -253,35 +279,68 @@ func (s *ApplicationService) EnrichApplicationsWithCompanyData(applications []*m
 }
 
 // fetchCompanyData fetches company data from company service
-func (s *ApplicationService) fetchCompanyData(companyID uint) (map[string]interface{}, error) {
-	// Get company service URL from job service
-	// We need to add this as a dependency
-	client := &http.Client{Timeout: 5 * time.Second}
-	url := fmt.Sprintf("%s/api/v1/companies/%d", s.companyServiceURL, companyID)
+func (s *ApplicationService) fetchCompanyData(ctx context.Context, companyID uint) (map[string]interface{}, error) {
+	return s.companies.Get(ctx, companyID)
+}
 
-	resp, err := client.Get(url)
-	if err != nil {
-		return nil, err
+// recordApplicantViews counts the applications shown to the company against
+// the applicant_views limit of its plan. Each application counts once per
+// calendar month (UTC); a QuotaError is returned, and nothing recorded, when
+// the applications not yet viewed this month don't fit in the limit.
+func (s *ApplicationService) recordApplicantViews(companyID uint, applications []*models.JobApplication) error {
+	if s.jobService == nil || !s.jobService.planQuotasEnforced() || len(applications) == 0 {
+		return nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("company service returned status %d: %s", resp.StatusCode, string(body))
+	tier, limits, err := s.jobService.PlanLimits(companyID)
+	if err != nil {
+		return err
 	}
-
-	var result struct {
-		Success bool                   `json:"success"`
-		Data    map[string]interface{} `json:"data"`
+	if limits.ApplicantViews == plans.Unlimited {
+		return nil
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+	now := time.Now().UTC()
+	period := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	ids := make([]uint, 0, len(applications))
+	for _, application := range applications {
+		ids = append(ids, application.ID)
 	}
 
-	if !result.Success {
-		return nil, errors.New("company service returned unsuccessful response")
-	}
+	// The check and the insert share a transaction holding the company's
+	// lock, so concurrent requests can't both take the last views
+	return s.viewRepo.Transaction(func(tx *repository.ApplicantViewRepository) error {
+		if err := tx.LockCompany(companyID); err != nil {
+			return err
+		}
+
+		viewed, err := tx.Viewed(companyID, period, ids)
+		if err != nil {
+			return err
+		}
+		var fresh []uint
+		for _, id := range ids {
+			if !viewed[id] {
+				fresh = append(fresh, id)
+			}
+		}
+		if len(fresh) == 0 {
+			return nil
+		}
+
+		used, err := tx.Count(companyID, period)
+		if err != nil {
+			return err
+		}
+		if int(used)+len(fresh) > limits.ApplicantViews {
+			return &QuotaError{
+				Resource: "applicant_views",
+				Tier:     tier,
+				Limit:    limits.ApplicantViews,
+				Used:     int(used),
+			}
+		}
 
-	return result.Data, nil
+		return tx.Record(companyID, period, fresh)
+	})
 }
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jobfair/shared/identity"
+)
+
+// maxCachedCompanies bounds the companies kept as fallback data
+const maxCachedCompanies = 5000
+
+// ErrCompanyNotFound is returned when company-service doesn't know the company
+var ErrCompanyNotFound = errors.New("company not found in company service")
+
+// CompanyClient fetches companies from company-service. The last response for
+// each company is kept, and served when company-service fails or its circuit
+// breaker is open, so listings keep real company names during an outage.
+type CompanyClient struct {
+	baseURL        string
+	client         *http.Client
+	identitySecret string // Signs the marker that keeps lookups out of profile views
+
+	mu        sync.RWMutex
+	lastKnown map[uint]map[string]interface{}
+}
+
+// NewCompanyClient creates a client for the company-service at baseURL.
+// client should be a resilient client from the shared httpclient package.
+// identitySecret is the gateway identity secret shared with company-service.
+func NewCompanyClient(baseURL string, client *http.Client, identitySecret string) *CompanyClient {
+	return &CompanyClient{
+		baseURL:        baseURL,
+		client:         client,
+		identitySecret: identitySecret,
+		lastKnown:      make(map[uint]map[string]interface{}),
+	}
+}
+
+// Get returns the company's public data, or its last known data when
+// company-service is unavailable. The call is traced as part of ctx.
+func (c *CompanyClient) Get(ctx context.Context, companyID uint) (map[string]interface{}, error) {
+	data, err := c.fetch(ctx, companyID)
+	if err == nil {
+		c.remember(companyID, data)
+		return data, nil
+	}
+	if errors.Is(err, ErrCompanyNotFound) {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	stale, ok := c.lastKnown[companyID]
+	c.mu.RUnlock()
+	if ok {
+		slog.Warn("Serving last known company data", "company_id", companyID, "error", err)
+		return stale, nil
+	}
+	return nil, err
+}
+
+func (c *CompanyClient) fetch(ctx context.Context, companyID uint) (map[string]interface{}, error) {
+	url := fmt.Sprintf("%s/api/v1/companies/%d", c.baseURL, companyID)
+
+	// Internal lookups are not counted as profile views in company analytics
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	identity.SetSkipAnalytics(req, c.identitySecret, time.Now())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrCompanyNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("company service returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Success bool                   `json:"success"`
+		Data    map[string]interface{} `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	if !result.Success {
+		return nil, errors.New("company service returned unsuccessful response")
+	}
+
+	return result.Data, nil
+}
+
+func (c *CompanyClient) remember(companyID uint, data map[string]interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.lastKnown[companyID]; !ok && len(c.lastKnown) >= maxCachedCompanies {
+		// Make room by dropping an arbitrary company
+		for id := range c.lastKnown {
+			delete(c.lastKnown, id)
+			break
+		}
+	}
+	c.lastKnown[companyID] = data
+}
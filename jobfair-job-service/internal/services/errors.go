@@ -0,0 +1,51 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jobfair/shared/plans"
+)
+
+// ErrCompanyNotVerified is returned when publishing requires a verified company
+var ErrCompanyNotVerified = errors.New("only verified companies can publish jobs")
+
+// Errors of the job revision history
+var (
+	ErrRevisionsForbidden = errors.New("unauthorized to view revisions of this job")
+	ErrRevisionNotFound   = errors.New("revision not found")
+)
+
+// ValidationError is returned when a request is rejected before any work is done
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// RateLimitError is returned when a user exceeds a per-user rate limit
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+// QuotaError is returned when an action would exceed a limit of the
+// company's subscription plan
+type QuotaError struct {
+	Resource string     `json:"resource"` // e.g. active_job_slots
+	Tier     plans.Tier `json:"tier"`
+	Limit    int        `json:"limit"`
+	Used     int        `json:"used"`
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%s limit of the %s plan reached (%d of %d used), upgrade your plan to continue",
+		strings.ReplaceAll(e.Resource, "_", " "), e.Tier, e.Used, e.Limit)
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"jobfair-job-service/internal/jobio"
+	"jobfair-job-service/internal/models"
+	"jobfair-job-service/internal/repository"
+)
+
+const (
+	// MaxImportRows is the maximum number of rows accepted in a single import file
+	MaxImportRows = 1000
+
+	// importProgressInterval controls how often progress is persisted while importing
+	importProgressInterval = 25
+
+	// importHeartbeat is the longest a running import goes without saving
+	// its progress, and importStaleAfter how long an unfinished import may go
+	// without an update before it is considered abandoned by a crashed replica
+	importHeartbeat  = 30 * time.Second
+	importStaleAfter = 2 * time.Minute
+)
+
+// ErrImportsStopped is returned when an import is started during shutdown
+var ErrImportsStopped = errors.New("job imports are stopped, retry later")
+
+type JobImportService struct {
+	importRepo *repository.JobImportRepository
+	jobService *JobService
+
+	// Running imports stop when ctx is cancelled by Stop
+	ctx     context.Context
+	cancel  context.CancelFunc
+	mu      sync.Mutex
+	stopped bool
+	running sync.WaitGroup
+}
+
+func NewJobImportService(importRepo *repository.JobImportRepository, jobService *JobService) *JobImportService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &JobImportService{
+		importRepo: importRepo,
+		jobService: jobService,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start marks imports abandoned by a crash or restart as failed, now and
+// periodically until Stop, since their rows only lived in memory
+func (s *JobImportService) Start() {
+	s.failStale()
+
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+
+		ticker := time.NewTicker(importStaleAfter / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.failStale()
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop interrupts running imports, marking them failed, and waits for them
+func (s *JobImportService) Stop() {
+	s.mu.Lock()
+	s.stopped = true
+	s.mu.Unlock()
+
+	s.cancel()
+	s.running.Wait()
+}
+
+func (s *JobImportService) failStale() {
+	failed, err := s.importRepo.FailStale(time.Now().Add(-importStaleAfter), "import interrupted by a service restart, upload the file again")
+	if err != nil {
+		slog.Warn("Failed to mark interrupted job imports as failed", "error", err)
+	} else if failed > 0 {
+		slog.Warn("Interrupted job imports marked as failed", "imports", failed)
+	}
+}
+
+// StartImport parses the uploaded file and processes its rows in the background.
+// File-level problems (unreadable file, missing header, too many rows) are
+// returned immediately; row-level problems are collected in the import report.
+func (s *JobImportService) StartImport(userID, companyID uint, format jobio.Format, fileName string, data []byte, dryRun bool) (*models.JobImport, error) {
+	rows, err := jobio.Decode(format, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) > MaxImportRows {
+		return nil, fmt.Errorf("import file has %d rows, maximum is %d", len(rows), MaxImportRows)
+	}
+
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return nil, ErrImportsStopped
+	}
+	s.running.Add(1)
+	s.mu.Unlock()
+
+	jobImport := &models.JobImport{
+		CompanyID: companyID,
+		UserID:    userID,
+		Format:    string(format),
+		FileName:  fileName,
+		DryRun:    dryRun,
+		Status:    models.ImportStatusPending,
+		TotalRows: len(rows),
+		RowErrors: models.ImportRowErrors{},
+	}
+
+	if _, err := s.importRepo.Create(jobImport); err != nil {
+		s.running.Done()
+		return nil, err
+	}
+
+	// The goroutine works on its own copy so the caller's response isn't mutated concurrently
+	running := *jobImport
+	go func() {
+		defer s.running.Done()
+		s.process(s.ctx, &running, rows)
+	}()
+
+	return jobImport, nil
+}
+
+// process validates and (unless dry-run) creates a job for every row. When
+// ctx is cancelled it stops between rows and marks the import failed.
+func (s *JobImportService) process(ctx context.Context, jobImport *models.JobImport, rows []jobio.Row) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("Job import panicked", "import_id", jobImport.ID, "panic", r)
+			s.finish(jobImport, models.ImportStatusFailed, fmt.Sprintf("import aborted: %v", r))
+		}
+	}()
+
+	now := time.Now()
+	jobImport.Status = models.ImportStatusProcessing
+	jobImport.StartedAt = &now
+	s.save(jobImport)
+	saved := now
+
+	for i, row := range rows {
+		if ctx.Err() != nil {
+			s.finish(jobImport, models.ImportStatusFailed, fmt.Sprintf(
+				"import interrupted by a service shutdown after %d of %d rows, upload the remaining rows again",
+				jobImport.ProcessedRows, jobImport.TotalRows))
+			slog.Warn("Job import interrupted", "import_id", jobImport.ID, "processed", jobImport.ProcessedRows)
+			return
+		}
+
+		if err := s.processRow(jobImport, row); err != nil {
+			jobImport.ErrorCount++
+			jobImport.RowErrors = append(jobImport.RowErrors, models.ImportRowError{
+				Row:     row.Number,
+				Message: err.Error(),
+			})
+		} else {
+			jobImport.SuccessCount++
+		}
+		jobImport.ProcessedRows++
+
+		if (i+1)%importProgressInterval == 0 || time.Since(saved) >= importHeartbeat {
+			s.save(jobImport)
+			saved = time.Now()
+		}
+	}
+
+	s.finish(jobImport, models.ImportStatusCompleted, "")
+	slog.Info("Job import finished", "import_id", jobImport.ID, "succeeded", jobImport.SuccessCount,
+		"failed", jobImport.ErrorCount, "dry_run", jobImport.DryRun)
+}
+
+func (s *JobImportService) processRow(jobImport *models.JobImport, row jobio.Row) error {
+	if row.Err != nil {
+		return row.Err
+	}
+
+	if jobImport.DryRun {
+		return s.jobService.PrepareCreateJobRequest(jobImport.CompanyID, row.Request)
+	}
+
+	job, err := s.jobService.CreateJob(jobImport.UserID, jobImport.CompanyID, row.Request)
+	if err != nil {
+		return err
+	}
+
+	jobImport.CreatedJobIDs = append(jobImport.CreatedJobIDs, int64(job.ID))
+	return nil
+}
+
+func (s *JobImportService) finish(jobImport *models.JobImport, status models.ImportStatus, message string) {
+	now := time.Now()
+	jobImport.Status = status
+	jobImport.ErrorMessage = message
+	jobImport.CompletedAt = &now
+	s.save(jobImport)
+}
+
+func (s *JobImportService) save(jobImport *models.JobImport) {
+	if err := s.importRepo.Update(jobImport); err != nil {
+		slog.Warn("Failed to save job import progress", "import_id", jobImport.ID, "error", err)
+	}
+}
+
+// GetImport retrieves an import owned by the company
+func (s *JobImportService) GetImport(importID, companyID uint) (*models.JobImport, error) {
+	jobImport, err := s.importRepo.GetByID(importID)
+	if err != nil {
+		return nil, err
+	}
+
+	if jobImport.CompanyID != companyID {
+		return nil, errors.New("import not found")
+	}
+
+	return jobImport, nil
+}
+
+// ListImports lists the most recent imports of a company
+func (s *JobImportService) ListImports(companyID uint) ([]*models.JobImport, error) {
+	return s.importRepo.GetByCompanyID(companyID, 50)
+}
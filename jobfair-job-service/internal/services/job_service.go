@@ -1,41 +1,85 @@
 package services
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
+	"log/slog"
+	"sort"
+	"sync"
 	"time"
 
 	"jobfair-job-service/internal/models"
 	"jobfair-job-service/internal/repository"
 	"jobfair-job-service/internal/utils"
 
+	"github.com/jobfair/shared/events"
+	"github.com/jobfair/shared/plans"
+	"github.com/jobfair/shared/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"gorm.io/gorm"
 )
 
+// schedulerBatchSize is the maximum number of jobs claimed per scheduler pass
+const schedulerBatchSize = 100
+
 type JobService struct {
-	jobRepo           *repository.JobRepository
-	applicationRepo   *repository.ApplicationRepository
-	savedJobRepo      *repository.SavedJobRepository
-	companyRepo       *repository.CompanyRepository
-	companyServiceURL string
+	jobRepo          *repository.JobRepository
+	applicationRepo  *repository.ApplicationRepository
+	savedJobRepo     *repository.SavedJobRepository
+	companyRepo      *repository.CompanyRepository
+	revisionRepo     *repository.JobRevisionRepository
+	templateRepo     *repository.JobTemplateRepository
+	subscriptionRepo *repository.SubscriptionRepository
+	companies        *CompanyClient
+	eventPublisher   *events.Publisher
+	analytics        *AnalyticsTracker
+	publish          PublishOptions
+	bulkApply        BulkApplyOptions
+}
+
+// PublishOptions configures who may publish jobs
+type PublishOptions struct {
+	RequireVerifiedCompany bool // Only companies verified by an admin can publish
+	EnforcePlanQuotas      bool // Limit job slots, featured jobs and applicant views to the company's plan
+}
+
+// BulkApplyOptions configures limits for BulkApply
+type BulkApplyOptions struct {
+	MaxBatchSize int                // Maximum number of jobs per request (0 = unlimited)
+	Concurrency  int                // Parallel applications in best_effort mode
+	Limiter      *utils.RateLimiter // Per-user limit on bulk apply requests (nil = unlimited)
 }
 
+// NewJobService creates a job service. eventPublisher and analytics may be
+// nil, in which case job lifecycle and analytics events are not published.
 func NewJobService(
 	jobRepo *repository.JobRepository,
 	applicationRepo *repository.ApplicationRepository,
 	savedJobRepo *repository.SavedJobRepository,
 	companyRepo *repository.CompanyRepository,
-	companyServiceURL string,
+	revisionRepo *repository.JobRevisionRepository,
+	templateRepo *repository.JobTemplateRepository,
+	subscriptionRepo *repository.SubscriptionRepository,
+	companies *CompanyClient,
+	eventPublisher *events.Publisher,
+	analytics *AnalyticsTracker,
+	publish PublishOptions,
+	bulkApply BulkApplyOptions,
 ) *JobService {
 	return &JobService{
-		jobRepo:           jobRepo,
-		applicationRepo:   applicationRepo,
-		savedJobRepo:      savedJobRepo,
-		companyRepo:       companyRepo,
-		companyServiceURL: companyServiceURL,
+		jobRepo:          jobRepo,
+		applicationRepo:  applicationRepo,
+		savedJobRepo:     savedJobRepo,
+		companyRepo:      companyRepo,
+		revisionRepo:     revisionRepo,
+		templateRepo:     templateRepo,
+		subscriptionRepo: subscriptionRepo,
+		companies:        companies,
+		eventPublisher:   eventPublisher,
+		analytics:        analytics,
+		publish:          publish,
+		bulkApply:        bulkApply,
 	}
 }
 
@@ -44,8 +88,42 @@ func (s *JobService) GetCompanyIDByUserID(userID uint) (uint, error) {
 	return s.companyRepo.GetCompanyIDByUserID(userID)
 }
 
+// PrepareCreateJobRequest fills the request from its template (if any) and
+// validates it. It is used by CreateJob and by bulk import dry-runs.
+func (s *JobService) PrepareCreateJobRequest(companyID uint, req *models.CreateJobRequest) error {
+	if req.TemplateID != nil {
+		template, err := s.templateRepo.GetByID(*req.TemplateID)
+		if err != nil || template.CompanyID != companyID {
+			return errors.New("job template not found")
+		}
+		req.ApplyTemplate(template)
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	if req.PublishAt != nil && *req.PublishAt != "" {
+		var deadline *time.Time
+		if req.Deadline != nil && *req.Deadline != "" {
+			if d, err := time.Parse(time.RFC3339, *req.Deadline); err == nil {
+				deadline = &d
+			}
+		}
+		if _, err := parsePublishAt(*req.PublishAt, deadline); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // CreateJob creates a new job posting
 func (s *JobService) CreateJob(userID, companyID uint, req *models.CreateJobRequest) (*models.Job, error) {
+	if err := s.PrepareCreateJobRequest(companyID, req); err != nil {
+		return nil, err
+	}
+
 	// Generate slug from title
 	slug := utils.GenerateSlug(req.Title)
 
@@ -80,7 +158,28 @@ func (s *JobService) CreateJob(userID, companyID uint, req *models.CreateJobRequ
 		}
 	}
 
-	return s.jobRepo.Create(job)
+	// Parse scheduled publish time
+	if req.PublishAt != nil && *req.PublishAt != "" {
+		publishAt, err := parsePublishAt(*req.PublishAt, job.Deadline)
+		if err != nil {
+			return nil, err
+		}
+		job.PublishAt = publishAt
+	}
+
+	if job.PublishAt == nil {
+		return s.jobRepo.Create(job)
+	}
+
+	// Scheduled jobs take a job slot of the company's plan
+	err := s.withJobSlot(companyID, 0, func(tx *repository.JobRepository) error {
+		_, err := tx.Create(job)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
 }
 
 // GetJobByID retrieves a job by ID with additional context
@@ -106,12 +205,38 @@ func (s *JobService) GetJobByID(jobID uint, userID *uint) (*models.JobDetailResp
 		}
 	}
 
-	// Increment views
-	go s.jobRepo.IncrementViews(jobID)
+	s.recordView(job, userID)
 
 	return response, nil
 }
 
+// RecordView counts an anonymous view of a job whose detail was served from
+// the response cache, like GetJobByID does for the views it serves
+func (s *JobService) RecordView(jobID uint) error {
+	job, err := s.jobRepo.GetByID(jobID)
+	if err != nil {
+		return err
+	}
+	s.recordView(job, nil)
+	return nil
+}
+
+// recordView increments the job's views and tracks the view for company
+// analytics
+func (s *JobService) recordView(job *models.Job, userID *uint) {
+	// Increment views
+	go s.jobRepo.IncrementViews(job.ID)
+
+	// Views of the job's own poster are not company analytics
+	var viewerID uint
+	if userID != nil {
+		viewerID = *userID
+	}
+	if viewerID == 0 || viewerID != job.UserID {
+		s.analytics.Track(events.EventTypeJobViewed, job, viewerID)
+	}
+}
+
 // GetJobBySlug retrieves a job by slug
 func (s *JobService) GetJobBySlug(slug string, userID *uint) (*models.JobDetailResponse, error) {
 	job, err := s.jobRepo.GetBySlug(slug)
@@ -190,14 +315,98 @@ func (s *JobService) UpdateJob(jobID, userID uint, req *models.UpdateJobRequest)
 			job.Deadline = &deadline
 		}
 	}
+	wasScheduled := job.PublishAt != nil
+	if req.PublishAt != nil {
+		if *req.PublishAt == "" {
+			// Empty string cancels a scheduled publish
+			job.PublishAt = nil
+		} else {
+			if job.Status != models.JobStatusDraft {
+				return nil, errors.New("only draft jobs can be scheduled for publishing")
+			}
+			publishAt, err := parsePublishAt(*req.PublishAt, job.Deadline)
+			if err != nil {
+				return nil, err
+			}
+			job.PublishAt = publishAt
+		}
+	}
 
-	if err := s.jobRepo.Update(job); err != nil {
+	save := func(tx *repository.JobRepository) error {
+		if err := tx.Update(job); err != nil {
+			return err
+		}
+		// Keep a revision history of live postings so changes stay visible
+		// to the company and to applicants who applied to an earlier version
+		if job.Status == models.JobStatusPublished {
+			return s.recordRevision(tx, job, userID)
+		}
+		return nil
+	}
+
+	if !wasScheduled && job.PublishAt != nil {
+		// Scheduling a draft takes a job slot of the company's plan
+		err = s.withJobSlot(job.CompanyID, job.ID, save)
+	} else {
+		err = s.jobRepo.Transaction(save)
+	}
+	if err != nil {
 		return nil, err
 	}
 
+	s.publishLifecycleEvent(events.EventTypeJobUpdated, job, "manual")
+
 	return job, nil
 }
 
+// DuplicateJob creates a draft copy of a job with a fresh slug
+func (s *JobService) DuplicateJob(jobID, userID uint) (*models.Job, error) {
+	original, err := s.jobRepo.GetByID(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check ownership
+	if original.UserID != userID {
+		return nil, errors.New("unauthorized to duplicate this job")
+	}
+
+	job := &models.Job{
+		CompanyID:        original.CompanyID,
+		UserID:           userID,
+		Title:            original.Title,
+		Description:      original.Description,
+		Slug:             utils.GenerateSlug(original.Title),
+		EmploymentType:   original.EmploymentType,
+		WorkType:         original.WorkType,
+		ExperienceLevel:  original.ExperienceLevel,
+		Location:         original.Location,
+		City:             original.City,
+		Country:          original.Country,
+		IsRemote:         original.IsRemote,
+		Latitude:         original.Latitude,
+		Longitude:        original.Longitude,
+		SalaryMin:        original.SalaryMin,
+		SalaryMax:        original.SalaryMax,
+		SalaryCurrency:   original.SalaryCurrency,
+		SalaryPeriod:     original.SalaryPeriod,
+		Requirements:     original.Requirements,
+		Responsibilities: original.Responsibilities,
+		Skills:           original.Skills,
+		Benefits:         original.Benefits,
+		ReceiveMethod:    original.ReceiveMethod,
+		ContactEmail:     original.ContactEmail,
+		ExternalURL:      original.ExternalURL,
+		MetaTitle:        original.MetaTitle,
+		MetaDescription:  original.MetaDescription,
+		Tags:             original.Tags,
+		Status:           models.JobStatusDraft,
+		// Deadline, schedule and counters are intentionally not copied
+	}
+
+	return s.jobRepo.Create(job)
+}
+
 // DeleteJob soft deletes a job
 func (s *JobService) DeleteJob(jobID, userID uint) error {
 	job, err := s.jobRepo.GetByID(jobID)
@@ -210,7 +419,12 @@ func (s *JobService) DeleteJob(jobID, userID uint) error {
 		return errors.New("unauthorized to delete this job")
 	}
 
-	return s.jobRepo.Delete(jobID)
+	if err := s.jobRepo.Delete(jobID); err != nil {
+		return err
+	}
+
+	s.publishLifecycleEvent(events.EventTypeJobDeleted, job, "manual")
+	return nil
 }
 
 // PublishJob publishes a draft job
@@ -230,13 +444,36 @@ func (s *JobService) PublishJob(jobID, userID uint) (*models.Job, error) {
 	}
 
 	now := time.Now()
+	if job.IsPastDeadline(now) {
+		return nil, errors.New("cannot publish a job whose deadline has passed")
+	}
+
+	if s.publish.RequireVerifiedCompany {
+		verified, err := s.companyRepo.IsCompanyVerified(job.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+		if !verified {
+			return nil, ErrCompanyNotVerified
+		}
+	}
+
 	job.Status = models.JobStatusPublished
 	job.PublishedAt = &now
+	job.PublishAt = nil
 
-	if err := s.jobRepo.Update(job); err != nil {
+	err = s.withJobSlot(job.CompanyID, job.ID, func(tx *repository.JobRepository) error {
+		if err := tx.Update(job); err != nil {
+			return err
+		}
+		return s.recordRevision(tx, job, userID)
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	s.publishLifecycleEvent(events.EventTypeJobPublished, job, "manual")
+
 	return job, nil
 }
 
@@ -264,17 +501,280 @@ func (s *JobService) CloseJob(jobID, userID uint) (*models.Job, error) {
 		return nil, err
 	}
 
+	s.publishLifecycleEvent(events.EventTypeJobClosed, job, "manual")
+
 	return job, nil
 }
 
+// PublishScheduledJobs publishes all drafts whose publish_at has passed.
+// It is called periodically by the job scheduler and returns the number of
+// jobs published. When publishing requires verification, drafts of
+// unverified companies stay scheduled until the company is verified.
+func (s *JobService) PublishScheduledJobs() (int, error) {
+	total := 0
+	for {
+		jobs, err := s.jobRepo.PublishDueJobs(time.Now(), schedulerBatchSize, s.publish.RequireVerifiedCompany, func(tx *repository.JobRepository, job *models.Job) error {
+			return s.recordRevision(tx, job, job.UserID)
+		})
+		if err != nil {
+			return total, err
+		}
+
+		for _, job := range jobs {
+			s.publishLifecycleEvent(events.EventTypeJobPublished, job, "scheduled")
+		}
+
+		total += len(jobs)
+		if len(jobs) < schedulerBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// CloseExpiredJobs closes all published jobs whose deadline has passed.
+// It is called periodically by the job scheduler and returns the number of
+// jobs closed.
+func (s *JobService) CloseExpiredJobs() (int, error) {
+	total := 0
+	for {
+		jobs, err := s.jobRepo.CloseExpiredJobs(time.Now(), schedulerBatchSize)
+		if err != nil {
+			return total, err
+		}
+
+		for _, job := range jobs {
+			s.publishLifecycleEvent(events.EventTypeJobClosed, job, "deadline")
+		}
+
+		total += len(jobs)
+		if len(jobs) < schedulerBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// PlanLimits returns the subscription tier and plan limits in effect for the
+// company, from data replicated by company events. Companies whose
+// subscription periods have all ended get the free plan; companies that never
+// had one get the plan of the tier stored on the company (free if unknown),
+// as company-service does.
+func (s *JobService) PlanLimits(companyID uint) (plans.Tier, plans.Limits, error) {
+	subscription, err := s.subscriptionRepo.GetEffective(companyID, time.Now())
+	if err != nil {
+		return "", plans.Limits{}, err
+	}
+	if subscription != nil {
+		return subscription.Tier, subscription.Limits(), nil
+	}
+
+	tier := plans.TierFree
+	hadSubscription, err := s.subscriptionRepo.Exists(companyID)
+	if err != nil {
+		return "", plans.Limits{}, err
+	}
+	if !hadSubscription {
+		tier, err = s.companyRepo.GetSubscriptionTier(companyID)
+		if err != nil {
+			return "", plans.Limits{}, err
+		}
+	}
+
+	plan := plans.Lookup(tier)
+	return plan.Tier, plan.Limits, nil
+}
+
+// planQuotasEnforced reports whether the limits of the companies' plans are enforced
+func (s *JobService) planQuotasEnforced() bool {
+	return s.publish.EnforcePlanQuotas
+}
+
+// withJobSlot runs fn if the company has a free job slot for the job (jobID 0
+// for a new job), and returns a QuotaError otherwise. The check and fn share a
+// transaction holding the company's lock, so concurrent requests can't both
+// take the last slot.
+func (s *JobService) withJobSlot(companyID, jobID uint, fn func(tx *repository.JobRepository) error) error {
+	if !s.planQuotasEnforced() {
+		return s.jobRepo.Transaction(fn)
+	}
+
+	tier, limits, err := s.PlanLimits(companyID)
+	if err != nil {
+		return err
+	}
+
+	return s.jobRepo.Transaction(func(tx *repository.JobRepository) error {
+		if err := tx.LockCompanyJobs(companyID); err != nil {
+			return err
+		}
+
+		used, err := tx.CountActiveByCompany(companyID, jobID)
+		if err != nil {
+			return err
+		}
+		if !plans.Allows(limits.ActiveJobSlots, int(used)) {
+			return &QuotaError{
+				Resource: "active_job_slots",
+				Tier:     tier,
+				Limit:    limits.ActiveJobSlots,
+				Used:     int(used),
+			}
+		}
+
+		return fn(tx)
+	})
+}
+
+// publishLifecycleEvent publishes a job lifecycle event. Failures are logged
+// and do not fail the state change that triggered them.
+func (s *JobService) publishLifecycleEvent(eventType string, job *models.Job, trigger string) {
+	if s.eventPublisher == nil {
+		return
+	}
+
+	data := events.JobLifecycleData{
+		JobID:     job.ID,
+		CompanyID: job.CompanyID,
+		UserID:    job.UserID,
+		Title:     job.Title,
+		Status:    string(job.Status),
+		Trigger:   trigger,
+		ChangedAt: time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var err error
+	switch eventType {
+	case events.EventTypeJobPublished:
+		err = s.eventPublisher.PublishJobPublished(ctx, data)
+	case events.EventTypeJobUpdated:
+		err = s.eventPublisher.PublishJobUpdated(ctx, data)
+	case events.EventTypeJobClosed:
+		err = s.eventPublisher.PublishJobClosed(ctx, data)
+	case events.EventTypeJobDeleted:
+		err = s.eventPublisher.PublishJobDeleted(ctx, data)
+	}
+	if err != nil {
+		slog.Warn("Failed to publish job event", "event_type", eventType, "job_id", job.ID, "error", err)
+	}
+}
+
+// recordRevision stores a snapshot of the job if its content differs from the
+// latest revision. It runs in the transaction of the job update (tx), so the
+// update fails when its revision can't be stored.
+func (s *JobService) recordRevision(tx *repository.JobRepository, job *models.Job, userID uint) error {
+	revisions := tx.Revisions()
+	snapshot := models.NewJobSnapshot(job)
+
+	latest, err := revisions.GetLatest(job.ID)
+	if err == nil && len(latest.Snapshot.Diff(snapshot)) == 0 {
+		return nil
+	} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to load latest job revision: %w", err)
+	}
+
+	revision := &models.JobRevision{
+		JobID:     job.ID,
+		Snapshot:  snapshot,
+		ChangedBy: userID,
+	}
+	if _, err := revisions.Create(revision); err != nil {
+		return fmt.Errorf("failed to record job revision: %w", err)
+	}
+	return nil
+}
+
+// ListRevisions lists all revisions of a job (job owner only)
+func (s *JobService) ListRevisions(jobID, userID uint) ([]*models.JobRevision, error) {
+	job, err := s.jobRepo.GetByID(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.UserID != userID {
+		return nil, ErrRevisionsForbidden
+	}
+
+	return s.revisionRepo.ListByJobID(jobID)
+}
+
+// GetRevision retrieves a single revision. It is visible to the job owner and
+// to applicants of the job, so they can see what they applied to.
+func (s *JobService) GetRevision(jobID, userID uint, revisionNumber int) (*models.JobRevision, error) {
+	job, err := s.jobRepo.GetByID(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.UserID != userID {
+		if _, err := s.applicationRepo.GetByJobIDAndUserID(jobID, userID); err != nil {
+			return nil, ErrRevisionsForbidden
+		}
+	}
+
+	revision, err := s.revisionRepo.GetByNumber(jobID, revisionNumber)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("revision %d: %w", revisionNumber, ErrRevisionNotFound)
+	}
+	return revision, err
+}
+
+// DiffRevisions compares two revisions of a job field by field (job owner only)
+func (s *JobService) DiffRevisions(jobID, userID uint, fromNumber, toNumber int) (*models.JobRevisionDiff, error) {
+	job, err := s.jobRepo.GetByID(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.UserID != userID {
+		return nil, ErrRevisionsForbidden
+	}
+
+	from, err := s.revisionRepo.GetByNumber(jobID, fromNumber)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("revision %d: %w", fromNumber, ErrRevisionNotFound)
+	} else if err != nil {
+		return nil, err
+	}
+
+	to, err := s.revisionRepo.GetByNumber(jobID, toNumber)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("revision %d: %w", toNumber, ErrRevisionNotFound)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &models.JobRevisionDiff{
+		JobID:        jobID,
+		FromRevision: from.RevisionNumber,
+		ToRevision:   to.RevisionNumber,
+		Changes:      from.Snapshot.Diff(to.Snapshot),
+	}, nil
+}
+
+// parsePublishAt parses a scheduled publish time and checks it against the deadline
+func parsePublishAt(value string, deadline *time.Time) (*time.Time, error) {
+	publishAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.New("invalid publish_at, expected RFC3339 format")
+	}
+
+	if deadline != nil && !publishAt.Before(*deadline) {
+		return nil, errors.New("publish_at must be before the deadline")
+	}
+
+	return &publishAt, nil
+}
+
 // ListJobs retrieves jobs with filters
-func (s *JobService) ListJobs(filter models.JobListFilter) ([]*models.Job, *models.PaginationMeta, error) {
+func (s *JobService) ListJobs(ctx context.Context, filter models.JobListFilter) ([]*models.Job, *models.PaginationMeta, error) {
 	// Default to published jobs for public listing
 	if filter.Status == "" {
 		filter.Status = models.JobStatusPublished
 	}
 
-	jobs, total, err := s.jobRepo.List(filter)
+	jobs, total, err := s.jobRepo.WithContext(ctx).List(filter)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -294,6 +794,26 @@ func (s *JobService) ListJobs(filter models.JobListFilter) ([]*models.Job, *mode
 	return jobs, meta, nil
 }
 
+// GetCompanyJobs retrieves all jobs of a company, optionally filtered by status
+func (s *JobService) GetCompanyJobs(companyID uint, status models.JobStatus) ([]*models.Job, error) {
+	jobs, err := s.jobRepo.GetByCompanyID(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == "" {
+		return jobs, nil
+	}
+
+	filtered := make([]*models.Job, 0, len(jobs))
+	for _, job := range jobs {
+		if job.Status == status {
+			filtered = append(filtered, job)
+		}
+	}
+	return filtered, nil
+}
+
 // GetMyJobs retrieves jobs posted by the user
 func (s *JobService) GetMyJobs(userID uint) ([]*models.Job, error) {
 	return s.jobRepo.GetByUserID(userID)
@@ -301,71 +821,276 @@ func (s *JobService) GetMyJobs(userID uint) ([]*models.Job, error) {
 
 // ApplyToJob applies to a job
 func (s *JobService) ApplyToJob(jobID, userID uint, req *models.ApplyJobRequest) (*models.JobApplication, error) {
-	// Check if job exists
-	job, err := s.jobRepo.GetByID(jobID)
+	var result models.BulkApplyResult
+	var application *models.JobApplication
+	err := s.applicationRepo.Transaction(func(tx *repository.ApplicationRepository) error {
+		var err error
+		result, application, err = s.applyInTx(tx, jobID, userID, req)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	switch result.Result {
+	case models.ApplyResultApplied:
+		s.trackApplied(jobID, userID)
+		return application, nil
+	case models.ApplyResultNotFound:
+		return nil, gorm.ErrRecordNotFound
+	default:
+		return nil, errors.New(result.Message)
+	}
+}
+
+// applyInTx applies to a single job inside a transaction. The job row is
+// locked first, so concurrent applications to the same job are serialised and
+// the applications count stays in step with the inserted rows. Business
+// outcomes (closed, already applied, ...) are reported in the result; only
+// database failures are returned as errors.
+func (s *JobService) applyInTx(tx *repository.ApplicationRepository, jobID, userID uint, req *models.ApplyJobRequest) (models.BulkApplyResult, *models.JobApplication, error) {
+	result := models.BulkApplyResult{JobID: jobID}
+
+	job, err := tx.LockJob(jobID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		result.Result = models.ApplyResultNotFound
+		result.Message = "job not found"
+		return result, nil, nil
+	} else if err != nil {
+		return result, nil, err
+	}
+
 	// Check if job is accepting applications
 	if job.Status != models.JobStatusPublished {
-		return nil, errors.New("job is not accepting applications")
+		result.Result = models.ApplyResultClosed
+		result.Message = "job is not accepting applications"
+		return result, nil, nil
+	}
+	if job.IsPastDeadline(time.Now()) {
+		result.Result = models.ApplyResultClosed
+		result.Message = "application deadline has passed"
+		return result, nil, nil
 	}
 
 	// Check if already applied
-	_, err = s.applicationRepo.GetByJobIDAndUserID(jobID, userID)
+	_, err = tx.GetByJobIDAndUserID(jobID, userID)
 	if err == nil {
-		return nil, errors.New("already applied to this job")
+		result.Result = models.ApplyResultAlreadyApplied
+		result.Message = "already applied to this job"
+		return result, nil, nil
 	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, err
+		return result, nil, err
+	}
+
+	// Read under the job lock: job edits store their revision in the
+	// transaction updating the job, so this is the version the applicant saw
+	var revisionID *uint
+	revision, err := tx.Revisions().GetLatest(jobID)
+	if err == nil {
+		revisionID = &revision.ID
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return result, nil, err
 	}
 
-	// Create application
 	application := &models.JobApplication{
 		JobID:       jobID,
 		UserID:      userID,
 		CVURL:       req.CVURL,
 		CoverLetter: req.CoverLetter,
 		Status:      models.ApplicationStatusApplied,
+		RevisionID:  revisionID, // Which version of the posting the applicant saw
 	}
 
-	createdApp, err := s.applicationRepo.Create(application)
+	createdApp, err := tx.CreateAndCount(application)
 	if err != nil {
-		return nil, err
+		return result, nil, err
 	}
 
-	// Increment applications count
-	go s.jobRepo.IncrementApplications(jobID)
+	result.Result = models.ApplyResultApplied
+	result.ApplicationID = &createdApp.ID
+	return result, createdApp, nil
+}
 
-	return createdApp, nil
+// trackApplied publishes the analytics event of a committed application
+func (s *JobService) trackApplied(jobID, userID uint) {
+	if !s.analytics.enabled() {
+		return
+	}
+	job, err := s.jobRepo.GetByID(jobID)
+	if err != nil {
+		return
+	}
+	s.analytics.Track(events.EventTypeJobApplied, job, userID)
 }
 
-// BulkApply applies to multiple jobs at once
-func (s *JobService) BulkApply(userID uint, req *models.BulkApplyRequest) ([]uint, []error) {
-	var successIDs []uint
-	var errors []error
+// errBulkApplyRollback aborts an all-or-nothing bulk apply transaction
+var errBulkApplyRollback = errors.New("bulk apply rolled back")
 
-	for _, jobID := range req.JobIDs {
-		applyReq := &models.ApplyJobRequest{
-			CVURL:       req.CVURL,
-			CoverLetter: req.CoverLetter,
-		}
+// BulkApply applies to multiple jobs at once.
+//
+// In best_effort mode every job is applied to in its own transaction (several
+// in parallel) and failures don't affect the others. In all_or_nothing mode all
+// jobs are applied to in one transaction that is rolled back unless every job
+// succeeds. Results are returned in request order.
+func (s *JobService) BulkApply(userID uint, req *models.BulkApplyRequest) (*models.BulkApplyResponse, error) {
+	jobIDs := uniqueJobIDs(req.JobIDs)
+	if len(jobIDs) == 0 {
+		return nil, &ValidationError{Message: "job_ids must not be empty"}
+	}
+	if s.bulkApply.MaxBatchSize > 0 && len(jobIDs) > s.bulkApply.MaxBatchSize {
+		return nil, &ValidationError{Message: fmt.Sprintf("cannot apply to more than %d jobs at once", s.bulkApply.MaxBatchSize)}
+	}
+
+	mode := req.Mode
+	if mode == "" {
+		mode = models.BulkApplyModeBestEffort
+	}
+	if mode != models.BulkApplyModeBestEffort && mode != models.BulkApplyModeAllOrNothing {
+		return nil, &ValidationError{Message: fmt.Sprintf("invalid mode %q, expected best_effort or all_or_nothing", mode)}
+	}
+
+	if allowed, retryAfter := s.bulkApply.Limiter.Allow(userID); !allowed {
+		return nil, &RateLimitError{RetryAfter: retryAfter}
+	}
+
+	applyReq := &models.ApplyJobRequest{
+		CVURL:       req.CVURL,
+		CoverLetter: req.CoverLetter,
+	}
 
-		_, err := s.ApplyToJob(jobID, userID, applyReq)
+	var results []models.BulkApplyResult
+	var committed bool
+	var err error
+	if mode == models.BulkApplyModeAllOrNothing {
+		results, committed, err = s.bulkApplyAllOrNothing(userID, jobIDs, applyReq)
 		if err != nil {
-			errors = append(errors, fmt.Errorf("job %d: %w", jobID, err))
+			return nil, err
+		}
+	} else {
+		results = s.bulkApplyBestEffort(userID, jobIDs, applyReq)
+		committed = true
+	}
+
+	response := &models.BulkApplyResponse{
+		Mode:      mode,
+		Committed: committed,
+		Results:   results,
+	}
+	for _, result := range results {
+		if result.Result == models.ApplyResultApplied {
+			response.AppliedCount++
+			s.trackApplied(result.JobID, userID)
 		} else {
-			successIDs = append(successIDs, jobID)
+			response.FailedCount++
+		}
+	}
+
+	return response, nil
+}
+
+func (s *JobService) bulkApplyAllOrNothing(userID uint, jobIDs []uint, req *models.ApplyJobRequest) ([]models.BulkApplyResult, bool, error) {
+	// Lock jobs in ascending ID order so concurrent bulk applies can't deadlock
+	lockOrder := make([]uint, len(jobIDs))
+	copy(lockOrder, jobIDs)
+	sort.Slice(lockOrder, func(i, j int) bool { return lockOrder[i] < lockOrder[j] })
+
+	resultsByJob := make(map[uint]models.BulkApplyResult, len(jobIDs))
+	err := s.applicationRepo.Transaction(func(tx *repository.ApplicationRepository) error {
+		failed := false
+		for _, jobID := range lockOrder {
+			result, _, err := s.applyInTx(tx, jobID, userID, req)
+			if err != nil {
+				return err
+			}
+			resultsByJob[jobID] = result
+			if result.Result != models.ApplyResultApplied {
+				failed = true
+			}
+		}
+
+		if failed {
+			return errBulkApplyRollback
+		}
+		return nil
+	})
+
+	committed := err == nil
+	if err != nil && !errors.Is(err, errBulkApplyRollback) {
+		return nil, false, err
+	}
+
+	results := make([]models.BulkApplyResult, len(jobIDs))
+	for i, jobID := range jobIDs {
+		result := resultsByJob[jobID]
+		if !committed && result.Result == models.ApplyResultApplied {
+			result.Result = models.ApplyResultRolledBack
+			result.ApplicationID = nil
+			result.Message = "not applied because another job in the batch failed"
 		}
+		results[i] = result
 	}
 
-	return successIDs, errors
+	return results, committed, nil
+}
+
+func (s *JobService) bulkApplyBestEffort(userID uint, jobIDs []uint, req *models.ApplyJobRequest) []models.BulkApplyResult {
+	concurrency := s.bulkApply.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]models.BulkApplyResult, len(jobIDs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, jobID := range jobIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, jobID uint) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var result models.BulkApplyResult
+			err := s.applicationRepo.Transaction(func(tx *repository.ApplicationRepository) error {
+				var err error
+				result, _, err = s.applyInTx(tx, jobID, userID, req)
+				return err
+			})
+			if err != nil {
+				slog.Error("Bulk apply failed", "job_id", jobID, "user_id", userID, "error", err)
+				result = models.BulkApplyResult{
+					JobID:   jobID,
+					Result:  models.ApplyResultError,
+					Message: "failed to submit application",
+				}
+			}
+			results[i] = result
+		}(i, jobID)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// uniqueJobIDs removes duplicate and zero IDs while keeping request order
+func uniqueJobIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
 }
 
 // SaveJob bookmarks a job
 func (s *JobService) SaveJob(jobID, userID uint) error {
 	// Check if job exists
-	_, err := s.jobRepo.GetByID(jobID)
+	job, err := s.jobRepo.GetByID(jobID)
 	if err != nil {
 		return err
 	}
@@ -381,8 +1106,12 @@ func (s *JobService) SaveJob(jobID, userID uint) error {
 		UserID: userID,
 	}
 
-	_, err = s.savedJobRepo.Create(savedJob)
-	return err
+	if _, err := s.savedJobRepo.Create(savedJob); err != nil {
+		return err
+	}
+
+	s.analytics.Track(events.EventTypeJobSaved, job, userID)
+	return nil
 }
 
 // UnsaveJob removes bookmark
@@ -418,43 +1147,20 @@ func (s *JobService) GetUserSavedCount(userID uint) (int64, error) {
 }
 
 // fetchCompanyData fetches company data from company service
-func (s *JobService) fetchCompanyData(companyID uint) (map[string]interface{}, error) {
-	client := &http.Client{Timeout: 5 * time.Second}
-	url := fmt.Sprintf("%s/api/v1/companies/%d", s.companyServiceURL, companyID)
-
-	resp, err := client.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("company service returned status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var result struct {
-		Success bool                   `json:"success"`
-		Data    map[string]interface{} `json:"data"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-
-	if !result.Success {
-		return nil, errors.New("company service returned unsuccessful response")
-	}
-
-	return result.Data, nil
+func (s *JobService) fetchCompanyData(ctx context.Context, companyID uint) (map[string]interface{}, error) {
+	return s.companies.Get(ctx, companyID)
 }
 
 // EnrichJobsWithCompanyData enriches jobs with company data
-func (s *JobService) EnrichJobsWithCompanyData(jobs []*models.Job) ([]models.JobWithCompany, error) {
+func (s *JobService) EnrichJobsWithCompanyData(ctx context.Context, jobs []*models.Job) ([]models.JobWithCompany, error) {
 	if len(jobs) == 0 {
 		return []models.JobWithCompany{}, nil
 	}
 
+	// One span groups the company lookups of a listing
+	ctx, span := tracing.Start(ctx, "JobService.EnrichJobsWithCompanyData")
+	defer span.End()
+
 	// Collect unique company IDs
 	companyIDs := make(map[uint]bool)
 	for _, job := range jobs {
@@ -464,9 +1170,9 @@ func (s *JobService) EnrichJobsWithCompanyData(jobs []*models.Job) ([]models.Job
 	// Fetch company data for all unique IDs
 	companyDataMap := make(map[uint]map[string]interface{})
 	for companyID := range companyIDs {
-		companyData, err := s.fetchCompanyData(companyID)
+		companyData, err := s.fetchCompanyData(ctx, companyID)
 		if err != nil {
-			fmt.Printf("[WARNING] Failed to fetch company %d: %v\n", companyID, err)
+			slog.Warn("Failed to fetch company", "company_id", companyID, "error", err)
 			// Use fallback company data if fetch fails
 			companyDataMap[companyID] = map[string]interface{}{
 				"id":   companyID,
@@ -477,14 +1183,26 @@ func (s *JobService) EnrichJobsWithCompanyData(jobs []*models.Job) ([]models.Job
 		}
 	}
 
+	// Verified badges come from company.verified events
+	ids := make([]uint, 0, len(companyIDs))
+	for companyID := range companyIDs {
+		ids = append(ids, companyID)
+	}
+	span.SetAttributes(attribute.Int("companies", len(ids)))
+	verified, err := s.companyRepo.WithContext(ctx).GetVerifiedCompanies(ids)
+	if err != nil {
+		slog.Warn("Failed to load company verification", "error", err)
+	}
+
 	// Enrich jobs with company data
 	result := make([]models.JobWithCompany, len(jobs))
 	for i, job := range jobs {
 		result[i] = models.JobWithCompany{
-			Job:        job,
-			Company:    companyDataMap[job.CompanyID],
-			IsSaved:    false, // Default values, will be updated if userID provided
-			HasApplied: false,
+			Job:             job,
+			Company:         companyDataMap[job.CompanyID],
+			CompanyVerified: verified[job.CompanyID],
+			IsSaved:         false, // Default values, will be updated if userID provided
+			HasApplied:      false,
 		}
 	}
 
@@ -492,7 +1210,7 @@ func (s *JobService) EnrichJobsWithCompanyData(jobs []*models.Job) ([]models.Job
 }
 
 // EnrichJobsWithUserContext enriches jobs with user-specific data (saved, applied status)
-func (s *JobService) EnrichJobsWithUserContext(jobs []models.JobWithCompany, userID *uint) []models.JobWithCompany {
+func (s *JobService) EnrichJobsWithUserContext(ctx context.Context, jobs []models.JobWithCompany, userID *uint) []models.JobWithCompany {
 	if userID == nil || len(jobs) == 0 {
 		return jobs
 	}
@@ -505,7 +1223,7 @@ func (s *JobService) EnrichJobsWithUserContext(jobs []models.JobWithCompany, use
 
 	// Batch check saved jobs
 	savedJobsMap := make(map[uint]bool)
-	savedJobs, err := s.savedJobRepo.GetByUserIDAndJobIDs(*userID, jobIDs)
+	savedJobs, err := s.savedJobRepo.WithContext(ctx).GetByUserIDAndJobIDs(*userID, jobIDs)
 	if err == nil {
 		for _, saved := range savedJobs {
 			savedJobsMap[saved.JobID] = true
@@ -514,7 +1232,7 @@ func (s *JobService) EnrichJobsWithUserContext(jobs []models.JobWithCompany, use
 
 	// Batch check applied jobs
 	appliedJobsMap := make(map[uint]bool)
-	applications, err := s.applicationRepo.GetByUserIDAndJobIDs(*userID, jobIDs)
+	applications, err := s.applicationRepo.WithContext(ctx).GetByUserIDAndJobIDs(*userID, jobIDs)
 	if err == nil {
 		for _, app := range applications {
 			appliedJobsMap[app.JobID] = true
@@ -531,15 +1249,15 @@ func (s *JobService) EnrichJobsWithUserContext(jobs []models.JobWithCompany, use
 }
 
 // EnrichJobDetailWithCompanyData enriches job detail with company data
-func (s *JobService) EnrichJobDetailWithCompanyData(jobDetail *models.JobDetailResponse) (*models.JobDetailResponse, error) {
+func (s *JobService) EnrichJobDetailWithCompanyData(ctx context.Context, jobDetail *models.JobDetailResponse) (*models.JobDetailResponse, error) {
 	if jobDetail == nil || jobDetail.Job == nil {
 		return jobDetail, nil
 	}
 
 	// Fetch company data
-	companyData, err := s.fetchCompanyData(jobDetail.Job.CompanyID)
+	companyData, err := s.fetchCompanyData(ctx, jobDetail.Job.CompanyID)
 	if err != nil {
-		fmt.Printf("[WARNING] Failed to fetch company %d: %v\n", jobDetail.Job.CompanyID, err)
+		slog.Warn("Failed to fetch company", "company_id", jobDetail.Job.CompanyID, "error", err)
 		// Use fallback company data if fetch fails
 		companyData = map[string]interface{}{
 			"id":   jobDetail.Job.CompanyID,
@@ -550,6 +1268,12 @@ func (s *JobService) EnrichJobDetailWithCompanyData(jobDetail *models.JobDetailR
 	// Add company data to response
 	jobDetail.Company = companyData
 
+	verified, err := s.companyRepo.WithContext(ctx).IsCompanyVerified(jobDetail.Job.CompanyID)
+	if err != nil {
+		slog.Warn("Failed to load company verification", "company_id", jobDetail.Job.CompanyID, "error", err)
+	}
+	jobDetail.CompanyVerified = verified
+
 	return jobDetail, nil
 }
 
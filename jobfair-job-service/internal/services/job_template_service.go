@@ -0,0 +1,128 @@
+package services
+
+import (
+	"errors"
+
+	"jobfair-job-service/internal/models"
+	"jobfair-job-service/internal/repository"
+)
+
+type JobTemplateService struct {
+	templateRepo *repository.JobTemplateRepository
+	companyRepo  *repository.CompanyRepository
+}
+
+func NewJobTemplateService(
+	templateRepo *repository.JobTemplateRepository,
+	companyRepo *repository.CompanyRepository,
+) *JobTemplateService {
+	return &JobTemplateService{
+		templateRepo: templateRepo,
+		companyRepo:  companyRepo,
+	}
+}
+
+// GetCompanyIDByUserID gets company ID for a user
+func (s *JobTemplateService) GetCompanyIDByUserID(userID uint) (uint, error) {
+	return s.companyRepo.GetCompanyIDByUserID(userID)
+}
+
+// CreateTemplate creates a job template for a company
+func (s *JobTemplateService) CreateTemplate(userID, companyID uint, req *models.JobTemplateRequest) (*models.JobTemplate, error) {
+	template := &models.JobTemplate{
+		CompanyID: companyID,
+		UserID:    userID,
+	}
+	applyTemplateRequest(template, req)
+
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	return s.templateRepo.Create(template)
+}
+
+// GetTemplate retrieves a template owned by the company
+func (s *JobTemplateService) GetTemplate(templateID, companyID uint) (*models.JobTemplate, error) {
+	template, err := s.templateRepo.GetByID(templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if template.CompanyID != companyID {
+		return nil, errors.New("job template not found")
+	}
+
+	return template, nil
+}
+
+// ListTemplates lists all templates of a company
+func (s *JobTemplateService) ListTemplates(companyID uint) ([]*models.JobTemplate, error) {
+	return s.templateRepo.GetByCompanyID(companyID)
+}
+
+// UpdateTemplate replaces the fields of a template owned by the company
+func (s *JobTemplateService) UpdateTemplate(templateID, companyID uint, req *models.JobTemplateRequest) (*models.JobTemplate, error) {
+	template, err := s.GetTemplate(templateID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	applyTemplateRequest(template, req)
+
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Update(template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// DeleteTemplate deletes a template owned by the company
+func (s *JobTemplateService) DeleteTemplate(templateID, companyID uint) error {
+	if _, err := s.GetTemplate(templateID, companyID); err != nil {
+		return err
+	}
+
+	return s.templateRepo.Delete(templateID)
+}
+
+func applyTemplateRequest(template *models.JobTemplate, req *models.JobTemplateRequest) {
+	template.Name = req.Name
+	template.Title = req.Title
+	template.Description = req.Description
+	template.EmploymentType = req.EmploymentType
+	template.WorkType = req.WorkType
+	template.ExperienceLevel = req.ExperienceLevel
+	template.Location = req.Location
+	template.SalaryMin = req.SalaryMin
+	template.SalaryMax = req.SalaryMax
+	template.Requirements = req.Requirements
+	template.Responsibilities = req.Responsibilities
+	template.Skills = req.Skills
+	template.Benefits = req.Benefits
+	template.ReceiveMethod = req.ReceiveMethod
+	template.ContactEmail = req.ContactEmail
+	template.ExternalURL = req.ExternalURL
+	template.Tags = req.Tags
+}
+
+// validateTemplate checks enum values; templates may leave any job field empty
+func validateTemplate(template *models.JobTemplate) error {
+	if template.EmploymentType != "" && !template.EmploymentType.IsValid() {
+		return errors.New("invalid employment_type")
+	}
+	if template.WorkType != "" && !template.WorkType.IsValid() {
+		return errors.New("invalid work_type")
+	}
+	if template.ExperienceLevel != "" && !template.ExperienceLevel.IsValid() {
+		return errors.New("invalid experience_level")
+	}
+	if template.SalaryMax > 0 && template.SalaryMin > template.SalaryMax {
+		return errors.New("salary_min cannot be greater than salary_max")
+	}
+	return nil
+}
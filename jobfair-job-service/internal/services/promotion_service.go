@@ -0,0 +1,460 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"jobfair-job-service/internal/models"
+	"jobfair-job-service/internal/repository"
+
+	"github.com/jobfair/shared/plans"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPromotionNotFound      = errors.New("promotion not found")
+	ErrPromotionOverlap       = errors.New("job already has a promotion in this period")
+	ErrInvalidPromotionPeriod = errors.New("ends_at must be in the future and after starts_at")
+	ErrJobNotPromotable       = errors.New("only draft and published jobs can be promoted")
+	ErrNotJobOwner            = errors.New("unauthorized to manage promotions of this job")
+)
+
+// Homepage feeds
+const (
+	FeedPopular = "popular"
+	FeedRecent  = "recent"
+)
+
+const (
+	// eventQueueSize bounds the promotion clicks and impressions waiting to be
+	// recorded; events arriving while it is full are dropped
+	eventQueueSize = 1024
+	// clickDedupeWindow is how long repeated clicks of a visitor on the same
+	// promotion of a job count once
+	clickDedupeWindow = 30 * time.Minute
+	// maxTrackedClicks caps the visitors remembered for deduplication; clicks
+	// beyond it are dropped until remembered ones expire
+	maxTrackedClicks = 100000
+)
+
+type promotionClick struct {
+	visitor     string
+	jobID       uint
+	promotionID uint
+}
+
+// promotionEvent is queued for the worker: the promotions shown on a page
+// when impressions is set, a click otherwise
+type promotionEvent struct {
+	click       promotionClick
+	impressions []uint
+}
+
+type PromotionService struct {
+	promotionRepo *repository.PromotionRepository
+	jobRepo       *repository.JobRepository
+	jobService    *JobService
+	slotsPerPage  int
+
+	mu         sync.RWMutex
+	events     chan promotionEvent
+	stopped    bool
+	done       chan struct{}
+	seenMu     sync.Mutex
+	seenClicks map[promotionClick]time.Time
+}
+
+// NewPromotionService creates a promotion service. slotsPerPage caps the
+// promoted jobs shown on each listing page and feed; 0 disables promoted slots.
+func NewPromotionService(
+	promotionRepo *repository.PromotionRepository,
+	jobRepo *repository.JobRepository,
+	jobService *JobService,
+	slotsPerPage int,
+) *PromotionService {
+	return &PromotionService{
+		promotionRepo: promotionRepo,
+		jobRepo:       jobRepo,
+		jobService:    jobService,
+		slotsPerPage:  slotsPerPage,
+		events:        make(chan promotionEvent, eventQueueSize),
+		done:          make(chan struct{}),
+		seenClicks:    make(map[promotionClick]time.Time),
+	}
+}
+
+// Start starts the worker recording the promotion clicks queued by
+// TrackClick and the impressions of listings and feeds. Impressions queued
+// together are recorded in a single batch.
+func (s *PromotionService) Start() {
+	go func() {
+		defer close(s.done)
+		for event := range s.events {
+			impressions := make(map[uint]int)
+			s.handle(event, impressions)
+			for pending := len(s.events); pending > 0; pending-- {
+				s.handle(<-s.events, impressions)
+			}
+			s.recordImpressions(impressions)
+		}
+	}()
+}
+
+// Stop stops queueing promotion events and waits for the queued ones to be recorded
+func (s *PromotionService) Stop() {
+	s.mu.Lock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.events)
+	}
+	s.mu.Unlock()
+	<-s.done
+}
+
+// CreatePromotion promotes a job on behalf of its company. Promotions running
+// at the same time are limited by the featured_jobs limit of the company's plan.
+func (s *PromotionService) CreatePromotion(userID, jobID uint, req *models.CreatePromotionRequest) (*models.JobPromotion, error) {
+	job, err := s.jobRepo.GetByID(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.UserID != userID {
+		return nil, ErrNotJobOwner
+	}
+
+	req.Priority = 0
+	return s.createPromotion(job, userID, models.PromotionSourcePurchased, req)
+}
+
+// GrantPromotion promotes a job on behalf of an admin, outside plan limits
+func (s *PromotionService) GrantPromotion(adminID, jobID uint, req *models.CreatePromotionRequest) (*models.JobPromotion, error) {
+	job, err := s.jobRepo.GetByID(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createPromotion(job, adminID, models.PromotionSourceGranted, req)
+}
+
+func (s *PromotionService) createPromotion(job *models.Job, createdBy uint, source models.PromotionSource, req *models.CreatePromotionRequest) (*models.JobPromotion, error) {
+	if job.Status != models.JobStatusDraft && job.Status != models.JobStatusPublished {
+		return nil, ErrJobNotPromotable
+	}
+
+	now := time.Now()
+	startsAt := now
+	if req.StartsAt != nil && req.StartsAt.After(now) {
+		startsAt = *req.StartsAt
+	}
+	if !req.EndsAt.After(startsAt) {
+		return nil, ErrInvalidPromotionPeriod
+	}
+
+	placement := req.Placement
+	if placement == "" {
+		placement = models.PromotionPlacementBoost
+	}
+
+	promotion := &models.JobPromotion{
+		JobID:     job.ID,
+		CompanyID: job.CompanyID,
+		Placement: placement,
+		Source:    source,
+		Priority:  req.Priority,
+		StartsAt:  startsAt,
+		EndsAt:    req.EndsAt,
+		CreatedBy: createdBy,
+	}
+
+	// Purchased promotions are limited by the plan when plan quotas are enforced
+	checkQuota := source == models.PromotionSourcePurchased && s.jobService.planQuotasEnforced()
+	var tier plans.Tier
+	var limits plans.Limits
+	var err error
+	if checkQuota {
+		tier, limits, err = s.jobService.PlanLimits(job.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The checks and the insert share a transaction holding the company's
+	// lock, so concurrent requests can't both take the last featured slot
+	err = s.promotionRepo.Transaction(func(tx *repository.PromotionRepository) error {
+		if err := tx.LockCompanyPromotions(job.CompanyID); err != nil {
+			return err
+		}
+
+		overlap, err := tx.HasOverlap(job.ID, promotion.StartsAt, promotion.EndsAt)
+		if err != nil {
+			return err
+		}
+		if overlap {
+			return ErrPromotionOverlap
+		}
+
+		if checkQuota {
+			used, err := tx.CountOverlappingByCompany(job.CompanyID, source, promotion.StartsAt, promotion.EndsAt)
+			if err != nil {
+				return err
+			}
+			if !plans.Allows(limits.FeaturedJobs, int(used)) {
+				return &QuotaError{
+					Resource: "featured_jobs",
+					Tier:     tier,
+					Limit:    limits.FeaturedJobs,
+					Used:     int(used),
+				}
+			}
+		}
+
+		return tx.Create(promotion)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Job promoted", "job_id", job.ID, "placement", promotion.Placement, "source", source,
+		"starts_at", promotion.StartsAt.Format(time.RFC3339), "ends_at", promotion.EndsAt.Format(time.RFC3339), "created_by", createdBy)
+
+	return promotion, nil
+}
+
+// ListPromotions returns the promotions of a job with their stats. Only the
+// job owner and admins may read them.
+func (s *PromotionService) ListPromotions(userID uint, userType string, jobID uint) ([]models.JobPromotionResponse, error) {
+	job, err := s.jobRepo.GetByID(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if userType != "admin" && job.UserID != userID {
+		return nil, ErrNotJobOwner
+	}
+
+	promotions, err := s.promotionRepo.ListByJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]models.JobPromotionResponse, len(promotions))
+	for i, promotion := range promotions {
+		responses[i] = models.JobPromotionResponse{
+			JobPromotion: promotion,
+			Active:       promotion.IsActive(now),
+			CTR:          promotion.CTR(),
+		}
+	}
+	return responses, nil
+}
+
+// CancelPromotion ends a promotion of a job. Companies may only cancel their
+// own purchased promotions; admins may cancel any.
+func (s *PromotionService) CancelPromotion(userID uint, userType string, jobID, promotionID uint) error {
+	promotion, err := s.promotionRepo.GetByID(promotionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && promotion.JobID != jobID) {
+		return ErrPromotionNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if userType != "admin" {
+		job, err := s.jobRepo.GetByID(jobID)
+		if err != nil {
+			return err
+		}
+		if job.UserID != userID || promotion.Source != models.PromotionSourcePurchased {
+			return ErrNotJobOwner
+		}
+	}
+
+	return s.promotionRepo.Cancel(promotionID, time.Now())
+}
+
+// Promote puts the promoted jobs matching the filter in front of the first
+// listing page, at most slotsPerPage of them. Only listings of published jobs
+// are promoted. Promotion failures are logged and leave the organic results
+// unchanged.
+func (s *PromotionService) Promote(filter models.JobListFilter, organic []*models.Job) []*models.Job {
+	if s.slotsPerPage <= 0 || filter.Page > 1 || (filter.Status != "" && filter.Status != models.JobStatusPublished) {
+		return organic
+	}
+	filter.Status = models.JobStatusPublished
+
+	promoted, err := s.jobRepo.ListPromoted(filter, time.Now(), min(s.slotsPerPage, filter.Limit))
+	if err != nil {
+		slog.Warn("Failed to load promoted jobs", "error", err)
+		return organic
+	}
+
+	return s.promotePage(filter, promoted, organic)
+}
+
+// promotePage adds the promoted jobs to the first page as extra slots in front
+// of its organic jobs; a promoted job is dropped from the organic ones so it
+// isn't shown twice. No organic job is displaced, so later pages keep their
+// offsets and every organic job is listed.
+func (s *PromotionService) promotePage(filter models.JobListFilter, promoted, organic []*models.Job) []*models.Job {
+	if filter.Page > 1 {
+		return organic
+	}
+	return s.merge(promoted, organic, len(promoted)+len(organic))
+}
+
+// Feed returns a homepage feed of published jobs with promoted jobs first
+func (s *PromotionService) Feed(feed string, limit int) ([]*models.Job, error) {
+	var promoted []*models.Job
+	if slots := min(s.slotsPerPage, limit); slots > 0 {
+		var err error
+		promoted, err = s.jobRepo.ListPromoted(models.JobListFilter{Status: models.JobStatusPublished}, time.Now(), slots)
+		if err != nil {
+			slog.Warn("Failed to load promoted jobs", "error", err)
+		}
+	}
+
+	// Over-fetch so jobs dropped as duplicates of promoted ones are replaced
+	var organic []*models.Job
+	var err error
+	switch feed {
+	case FeedPopular:
+		organic, err = s.jobRepo.GetPopularJobs(limit + len(promoted))
+	case FeedRecent:
+		organic, err = s.jobRepo.GetRecentJobs(limit + len(promoted))
+	default:
+		return nil, fmt.Errorf("unknown feed: %s", feed)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.merge(promoted, organic, limit), nil
+}
+
+// TrackClick queues a visitor's click on a promoted job to be recorded by the
+// worker started with Start, without blocking the request. Repeated clicks of
+// the visitor on the promotion within clickDedupeWindow count once; clicks are
+// dropped when the queue is full or the service is stopping.
+func (s *PromotionService) TrackClick(visitor string, jobID, promotionID uint) {
+	click := promotionClick{visitor: visitor, jobID: jobID, promotionID: promotionID}
+	if !s.firstClick(click, time.Now()) {
+		return
+	}
+
+	if !s.queue(promotionEvent{click: click}) {
+		slog.Warn("Dropped promotion click: queue is full", "promotion_id", promotionID)
+	}
+}
+
+// queue hands the event to the worker without blocking. It reports false
+// when the queue is full; events are silently dropped while stopping.
+func (s *PromotionService) queue(event promotionEvent) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.stopped {
+		return true
+	}
+	select {
+	case s.events <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+// handle records a queued click, or adds queued impressions to the batch
+func (s *PromotionService) handle(event promotionEvent, impressions map[uint]int) {
+	if event.impressions == nil {
+		s.RecordClick(event.click.jobID, event.click.promotionID)
+		return
+	}
+	for _, id := range event.impressions {
+		impressions[id]++
+	}
+}
+
+// recordImpressions counts a batch of impressions, with one update for the
+// promotions shown the same number of times
+func (s *PromotionService) recordImpressions(impressions map[uint]int) {
+	byCount := make(map[int][]uint)
+	for id, count := range impressions {
+		byCount[count] = append(byCount[count], id)
+	}
+	for count, ids := range byCount {
+		if err := s.promotionRepo.IncrementImpressions(ids, count); err != nil {
+			slog.Warn("Failed to record promotion impressions", "promotions", len(ids), "error", err)
+		}
+	}
+}
+
+// firstClick remembers the click and reports whether it is the visitor's
+// first on the promotion within clickDedupeWindow
+func (s *PromotionService) firstClick(click promotionClick, now time.Time) bool {
+	s.seenMu.Lock()
+	defer s.seenMu.Unlock()
+
+	if at, ok := s.seenClicks[click]; ok && now.Sub(at) < clickDedupeWindow {
+		return false
+	}
+	if len(s.seenClicks) >= maxTrackedClicks {
+		for key, at := range s.seenClicks {
+			if now.Sub(at) >= clickDedupeWindow {
+				delete(s.seenClicks, key)
+			}
+		}
+		if len(s.seenClicks) >= maxTrackedClicks {
+			return false
+		}
+	}
+	s.seenClicks[click] = now
+	return true
+}
+
+// RecordClick counts a click on a promoted job. Clicks on promotions that
+// aren't running for the job are ignored.
+func (s *PromotionService) RecordClick(jobID, promotionID uint) {
+	if _, err := s.promotionRepo.IncrementClicks(promotionID, jobID, time.Now()); err != nil {
+		slog.Warn("Failed to record promotion click", "promotion_id", promotionID, "error", err)
+	}
+}
+
+// merge returns the promoted jobs followed by the organic jobs that aren't
+// promoted, up to limit jobs, and queues an impression for each promotion shown
+func (s *PromotionService) merge(promoted, organic []*models.Job, limit int) []*models.Job {
+	if len(promoted) == 0 {
+		if len(organic) > limit {
+			organic = organic[:limit]
+		}
+		return organic
+	}
+
+	promotedIDs := make(map[uint]bool, len(promoted))
+	promotionIDs := make([]uint, 0, len(promoted))
+	jobs := make([]*models.Job, 0, limit)
+	for _, job := range promoted {
+		if len(jobs) == limit {
+			break
+		}
+		promotedIDs[job.ID] = true
+		promotionIDs = append(promotionIDs, *job.PromotionID)
+		jobs = append(jobs, job)
+	}
+	for _, job := range organic {
+		if len(jobs) == limit {
+			break
+		}
+		if !promotedIDs[job.ID] {
+			jobs = append(jobs, job)
+		}
+	}
+
+	if !s.queue(promotionEvent{impressions: promotionIDs}) {
+		slog.Warn("Dropped promotion impressions: queue is full", "promotions", len(promotionIDs))
+	}
+
+	return jobs
+}
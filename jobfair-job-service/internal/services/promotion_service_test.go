@@ -0,0 +1,77 @@
+package services
+
+import (
+	"testing"
+
+	"jobfair-job-service/internal/models"
+)
+
+// TestPromotePaging pages through a filtered listing with an active promotion
+// and checks promoted jobs never push organic jobs off the listing
+func TestPromotePaging(t *testing.T) {
+	var matching []*models.Job
+	for id := uint(1); id <= 40; id++ {
+		job := &models.Job{CompanyID: 1 + id%2, Status: models.JobStatusPublished}
+		job.ID = id
+		if job.CompanyID == 2 {
+			matching = append(matching, job)
+		}
+	}
+
+	// One promoted job ranks on the first page, the other on the second
+	var promoted []*models.Job
+	for i, index := range []int{2, 13} {
+		job := *matching[index]
+		promotionID := uint(100 + i)
+		job.Promoted = true
+		job.PromotionID = &promotionID
+		promoted = append(promoted, &job)
+	}
+
+	s := NewPromotionService(nil, nil, nil, 3)
+	filter := models.JobListFilter{CompanyID: 2, Limit: 10}
+	listed := make(map[uint]bool)
+
+	for filter.Page = 1; (filter.Page-1)*filter.Limit < len(matching); filter.Page++ {
+		start := (filter.Page - 1) * filter.Limit
+		organic := matching[start:min(start+filter.Limit, len(matching))]
+
+		jobs := s.promotePage(filter, promoted, organic)
+
+		onPage := make(map[uint]bool)
+		for _, job := range jobs {
+			if onPage[job.ID] {
+				t.Errorf("page %d lists job %d twice", filter.Page, job.ID)
+			}
+			onPage[job.ID] = true
+			listed[job.ID] = true
+		}
+
+		if filter.Page == 1 {
+			for i, job := range promoted {
+				if jobs[i] != job {
+					t.Errorf("page 1 slot %d = job %d, want promoted job %d", i, jobs[i].ID, job.ID)
+				}
+			}
+			if want := len(promoted) + len(organic) - 1; len(jobs) != want {
+				t.Errorf("page 1 has %d jobs, want %d (promoted slots on top of the organic page)", len(jobs), want)
+			}
+		} else if len(jobs) != len(organic) {
+			t.Errorf("page %d has %d jobs, want its %d organic jobs", filter.Page, len(jobs), len(organic))
+		}
+	}
+
+	for _, job := range matching {
+		if !listed[job.ID] {
+			t.Errorf("job %d is not listed on any page", job.ID)
+		}
+	}
+
+	// Only the first page shows the promotions and queues their impressions
+	if len(s.events) != 1 {
+		t.Fatalf("queued %d promotion events, want 1", len(s.events))
+	}
+	if event := <-s.events; len(event.impressions) != len(promoted) {
+		t.Errorf("queued impressions %v, want one per promoted job", event.impressions)
+	}
+}
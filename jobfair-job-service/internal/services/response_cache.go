@@ -0,0 +1,20 @@
+package services
+
+import "fmt"
+
+// Tags of cached responses (see httpcache). Listings carry CacheTagJobs; job
+// details carry CacheTagJobDetails and the tags of their job and company.
+const (
+	CacheTagJobs       = "jobs"
+	CacheTagJobDetails = "job_details"
+)
+
+// JobCacheTag tags the cached responses showing the job
+func JobCacheTag(jobID uint) string {
+	return fmt.Sprintf("job:%d", jobID)
+}
+
+// CompanyCacheTag tags the cached job details showing the company
+func CompanyCacheTag(companyID uint) string {
+	return fmt.Sprintf("company:%d", companyID)
+}
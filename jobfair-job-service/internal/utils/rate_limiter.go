@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter is a fixed-window, per-key request limiter kept in memory.
+// Limits are per replica; the API gateway enforces global limits.
+type RateLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu      sync.Mutex
+	windows map[uint]*rateWindow
+}
+
+// cleanupThreshold is the number of tracked keys above which expired windows are purged
+const cleanupThreshold = 1024
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// NewRateLimiter allows up to limit requests per key within each window.
+// A limit of zero or less disables limiting.
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:   limit,
+		window:  window,
+		windows: make(map[uint]*rateWindow),
+	}
+}
+
+// Allow records a request for key and reports whether it is within the limit.
+// When it is not, retryAfter is the time until the current window resets.
+func (l *RateLimiter) Allow(key uint) (allowed bool, retryAfter time.Duration) {
+	if l == nil || l.limit <= 0 {
+		return true, 0
+	}
+
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		if len(l.windows) >= cleanupThreshold {
+			l.cleanup(now)
+		}
+		l.windows[key] = &rateWindow{start: now, count: 1}
+		return true, 0
+	}
+
+	if w.count >= l.limit {
+		return false, w.start.Add(l.window).Sub(now)
+	}
+
+	w.count++
+	return true, 0
+}
+
+// cleanup drops expired windows so the map doesn't grow without bound
+func (l *RateLimiter) cleanup(now time.Time) {
+	for key, w := range l.windows {
+		if now.Sub(w.start) >= l.window {
+			delete(l.windows, key)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -26,9 +27,10 @@ func GenerateSlug(text string) string {
 	// Trim hyphens from start and end
 	slug = strings.Trim(slug, "-")
 
-	// Add timestamp to ensure uniqueness
-	timestamp := time.Now().Unix()
-	slug = fmt.Sprintf("%s-%d", slug, timestamp)
+	// Add timestamp to ensure uniqueness. Nanosecond precision (base36 to
+	// keep it short) so copies created within the same second don't collide.
+	timestamp := strconv.FormatInt(time.Now().UnixNano(), 36)
+	slug = fmt.Sprintf("%s-%s", slug, timestamp)
 
 	return slug
 }
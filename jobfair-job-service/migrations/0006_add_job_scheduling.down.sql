@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_jobs_status_deadline;
+DROP INDEX IF EXISTS idx_jobs_status_publish_at;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS publish_at;
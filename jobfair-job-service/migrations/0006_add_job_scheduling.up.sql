@@ -0,0 +1,9 @@
+-- Add scheduled publishing support to jobs
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP;
+
+-- Indexes used by the job scheduler to find due jobs
+CREATE INDEX IF NOT EXISTS idx_jobs_status_publish_at ON jobs(status, publish_at) WHERE publish_at IS NOT NULL AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_jobs_status_deadline ON jobs(status, deadline) WHERE deadline IS NOT NULL AND deleted_at IS NULL;
+
+COMMENT ON COLUMN jobs.publish_at IS 'When a draft job should be published automatically by the scheduler';
+COMMENT ON COLUMN jobs.deadline IS 'Application deadline; published jobs are closed automatically once it passes';
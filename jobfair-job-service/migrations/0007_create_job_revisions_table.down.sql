@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_job_applications_revision_id;
+ALTER TABLE job_applications DROP COLUMN IF EXISTS revision_id;
+
+DROP TRIGGER IF EXISTS prevent_job_revisions_update ON job_revisions;
+DROP FUNCTION IF EXISTS prevent_job_revision_update();
+DROP TABLE IF EXISTS job_revisions;
@@ -0,0 +1,73 @@
+-- Create job_revisions table
+CREATE TABLE IF NOT EXISTS job_revisions (
+    id SERIAL PRIMARY KEY,
+    job_id INTEGER NOT NULL,
+    revision_number INTEGER NOT NULL,
+    snapshot JSONB NOT NULL,
+    changed_by INTEGER,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT job_revisions_job_id_fkey FOREIGN KEY (job_id) REFERENCES jobs(id) ON DELETE CASCADE,
+    CONSTRAINT unique_job_revision_number UNIQUE (job_id, revision_number)
+);
+
+-- Create indexes
+CREATE INDEX idx_job_revisions_job_id ON job_revisions(job_id);
+
+-- Revisions are immutable once written
+CREATE OR REPLACE FUNCTION prevent_job_revision_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'job revisions are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER prevent_job_revisions_update
+BEFORE UPDATE ON job_revisions
+FOR EACH ROW
+EXECUTE FUNCTION prevent_job_revision_update();
+
+-- Record the revision each application was submitted against
+ALTER TABLE job_applications ADD COLUMN IF NOT EXISTS revision_id INTEGER REFERENCES job_revisions(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_job_applications_revision_id ON job_applications(revision_id);
+
+-- Backfill a first revision for jobs that are already live
+INSERT INTO job_revisions (job_id, revision_number, snapshot, changed_by, created_at)
+SELECT
+    id,
+    1,
+    jsonb_build_object(
+        'title', title,
+        'description', COALESCE(description, ''),
+        'employment_type', COALESCE(employment_type, ''),
+        'work_type', COALESCE(work_type, ''),
+        'experience_level', COALESCE(experience_level, ''),
+        'location', COALESCE(location, ''),
+        'city', COALESCE(city, ''),
+        'country', COALESCE(country, ''),
+        'is_remote', COALESCE(is_remote, FALSE),
+        'salary_min', COALESCE(salary_min, 0),
+        'salary_max', COALESCE(salary_max, 0),
+        'salary_currency', COALESCE(salary_currency, ''),
+        'salary_period', COALESCE(salary_period, ''),
+        'requirements', COALESCE(to_jsonb(requirements), '[]'::jsonb),
+        'responsibilities', COALESCE(to_jsonb(responsibilities), '[]'::jsonb),
+        'skills', COALESCE(to_jsonb(skills), '[]'::jsonb),
+        'benefits', COALESCE(to_jsonb(benefits), '[]'::jsonb),
+        'receive_method', COALESCE(receive_method, ''),
+        'contact_email', COALESCE(contact_email, ''),
+        'external_url', external_url,
+        'deadline', to_char(deadline, 'YYYY-MM-DD"T"HH24:MI:SS"Z"'),
+        'tags', COALESCE(to_jsonb(tags), '[]'::jsonb)
+    ),
+    user_id,
+    COALESCE(published_at, created_at)
+FROM jobs
+WHERE status IN ('published', 'closed') AND deleted_at IS NULL
+ON CONFLICT (job_id, revision_number) DO NOTHING;
+
+-- Comments
+COMMENT ON TABLE job_revisions IS 'Immutable snapshots of published job postings';
+COMMENT ON COLUMN job_revisions.snapshot IS 'Applicant-facing job content at the time of the revision';
+COMMENT ON COLUMN job_applications.revision_id IS 'Job revision the applicant applied against';
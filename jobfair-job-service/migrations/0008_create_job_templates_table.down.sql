@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_job_templates_updated_at ON job_templates;
+DROP TABLE IF EXISTS job_templates;
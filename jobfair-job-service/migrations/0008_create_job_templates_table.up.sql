@@ -0,0 +1,44 @@
+-- Create job_templates table
+CREATE TABLE IF NOT EXISTS job_templates (
+    id SERIAL PRIMARY KEY,
+    company_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+
+    -- Job fields
+    title VARCHAR(255),
+    description TEXT,
+    employment_type VARCHAR(50) CHECK (employment_type IN ('', 'fulltime', 'parttime', 'contract', 'freelance', 'intern')),
+    work_type VARCHAR(50) CHECK (work_type IN ('', 'onsite', 'remote', 'hybrid')),
+    experience_level VARCHAR(50) CHECK (experience_level IN ('', 'entry', 'junior', 'mid', 'senior')),
+    location VARCHAR(255),
+    salary_min INTEGER,
+    salary_max INTEGER,
+    requirements TEXT[],
+    responsibilities TEXT[],
+    skills TEXT[],
+    benefits TEXT[],
+    receive_method VARCHAR(50),
+    contact_email VARCHAR(255),
+    external_url VARCHAR(500),
+    tags TEXT[],
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_job_templates_company_id ON job_templates(company_id);
+CREATE INDEX idx_job_templates_deleted_at ON job_templates(deleted_at);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_job_templates_updated_at
+BEFORE UPDATE ON job_templates
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Comments
+COMMENT ON TABLE job_templates IS 'Reusable company-scoped job posting templates';
+COMMENT ON COLUMN job_templates.company_id IS 'Owning company; templates are shared by all users of the company';
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_job_imports_updated_at ON job_imports;
+DROP TABLE IF EXISTS job_imports;
@@ -0,0 +1,39 @@
+-- Create job_imports table
+CREATE TABLE IF NOT EXISTS job_imports (
+    id SERIAL PRIMARY KEY,
+    company_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    format VARCHAR(20) NOT NULL CHECK (format IN ('csv', 'ndjson')),
+    file_name VARCHAR(255),
+    dry_run BOOLEAN DEFAULT FALSE,
+    status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+
+    -- Progress
+    total_rows INTEGER DEFAULT 0,
+    processed_rows INTEGER DEFAULT 0,
+    success_count INTEGER DEFAULT 0,
+    error_count INTEGER DEFAULT 0,
+    row_errors JSONB DEFAULT '[]'::jsonb,
+    created_job_ids INTEGER[],
+    error_message TEXT,
+
+    -- Timestamps
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_job_imports_company_id ON job_imports(company_id);
+CREATE INDEX idx_job_imports_created_at ON job_imports(created_at);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_job_imports_updated_at
+BEFORE UPDATE ON job_imports
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Comments
+COMMENT ON TABLE job_imports IS 'Asynchronous bulk job imports (CSV/NDJSON) and their row-level error reports';
+COMMENT ON COLUMN job_imports.dry_run IS 'Validate rows only, without creating jobs';
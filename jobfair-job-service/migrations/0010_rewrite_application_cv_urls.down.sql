@@ -0,0 +1,3 @@
+-- The original public file paths cannot be reconstructed; the rewritten
+-- download paths stay valid, so there is nothing to undo.
+COMMENT ON COLUMN job_applications.cv_url IS NULL;
@@ -0,0 +1,7 @@
+-- CVs are no longer served publicly from /uploads/cv; point existing
+-- applications at the authorized CV download endpoint of the applicant
+UPDATE job_applications
+SET cv_url = '/api/v1/cv/users/' || user_id || '/download'
+WHERE cv_url LIKE '/uploads/cv/%';
+
+COMMENT ON COLUMN job_applications.cv_url IS 'Authorized CV download path in the user profile service';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_company_mappings_verified;
+
+ALTER TABLE company_mappings DROP COLUMN IF EXISTS verified_at;
+ALTER TABLE company_mappings DROP COLUMN IF EXISTS is_verified;
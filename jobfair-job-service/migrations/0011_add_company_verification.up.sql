@@ -0,0 +1,7 @@
+-- Company verification status, kept in sync from company.verified events
+ALTER TABLE company_mappings ADD COLUMN IF NOT EXISTS is_verified BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE company_mappings ADD COLUMN IF NOT EXISTS verified_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_company_mappings_verified ON company_mappings(company_id) WHERE is_verified;
+
+COMMENT ON COLUMN company_mappings.is_verified IS 'Whether an admin verified the company; shown as a badge on its jobs';
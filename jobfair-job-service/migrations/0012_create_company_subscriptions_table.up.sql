@@ -0,0 +1,28 @@
+-- Company subscription periods, kept in sync from company.subscription_changed events
+CREATE TABLE IF NOT EXISTS company_subscriptions (
+    id SERIAL PRIMARY KEY,
+    subscription_id INTEGER NOT NULL UNIQUE, -- id in company-service
+    company_id INTEGER NOT NULL,
+    tier VARCHAR(50) NOT NULL,
+
+    -- Plan limits at the time of the change (-1 = unlimited)
+    active_job_slots INTEGER NOT NULL,
+    featured_jobs INTEGER NOT NULL,
+    applicant_views INTEGER NOT NULL,
+    team_seats INTEGER NOT NULL,
+
+    effective_from TIMESTAMP NOT NULL,
+    effective_until TIMESTAMP,
+
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_company_subscriptions_company ON company_subscriptions(company_id, effective_from DESC);
+
+CREATE TRIGGER update_company_subscriptions_updated_at
+BEFORE UPDATE ON company_subscriptions
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE company_subscriptions IS 'Subscription tiers and plan limits per company; companies without a current period use the free plan';
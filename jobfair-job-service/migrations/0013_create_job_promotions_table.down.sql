@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_job_promotions_updated_at ON job_promotions;
+DROP TABLE IF EXISTS job_promotions;
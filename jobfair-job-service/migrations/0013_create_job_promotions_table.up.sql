@@ -0,0 +1,35 @@
+-- Time-boxed promotions that pin or boost jobs in listings and homepage feeds
+CREATE TABLE IF NOT EXISTS job_promotions (
+    id SERIAL PRIMARY KEY,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    company_id INTEGER NOT NULL,
+
+    placement VARCHAR(20) NOT NULL CHECK (placement IN ('pin', 'boost')),
+    source VARCHAR(20) NOT NULL CHECK (source IN ('purchased', 'granted')),
+    priority INTEGER NOT NULL DEFAULT 0, -- higher first within a placement
+
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    cancelled_at TIMESTAMP,
+
+    -- Tracking
+    impressions BIGINT NOT NULL DEFAULT 0,
+    clicks BIGINT NOT NULL DEFAULT 0,
+
+    created_by INTEGER NOT NULL, -- company user or admin
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_job_promotions_job ON job_promotions(job_id, starts_at DESC);
+CREATE INDEX idx_job_promotions_company ON job_promotions(company_id, starts_at DESC);
+CREATE INDEX idx_job_promotions_active ON job_promotions(starts_at, ends_at) WHERE cancelled_at IS NULL;
+
+CREATE TRIGGER update_job_promotions_updated_at
+BEFORE UPDATE ON job_promotions
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE job_promotions IS 'Featured job placements: purchased within the plan featured_jobs limit or granted by an admin';
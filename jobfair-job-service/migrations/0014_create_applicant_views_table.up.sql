@@ -0,0 +1,12 @@
+-- Applications viewed by each company per month, counted against the
+-- applicant_views limit of its plan
+CREATE TABLE IF NOT EXISTS applicant_views (
+    company_id INTEGER NOT NULL,
+    application_id INTEGER NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    period DATE NOT NULL, -- first day of the month (UTC)
+    viewed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (company_id, period, application_id)
+);
+
+COMMENT ON TABLE applicant_views IS 'Applications a company viewed in a month; each counts once against the plan applicant_views limit';
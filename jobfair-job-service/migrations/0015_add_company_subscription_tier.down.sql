@@ -0,0 +1 @@
+ALTER TABLE company_mappings DROP COLUMN IF EXISTS subscription_tier;
@@ -0,0 +1,5 @@
+-- Subscription tier stored on the company, kept in sync from company.registered
+-- and company.updated events; the plan of companies without subscription periods
+ALTER TABLE company_mappings ADD COLUMN IF NOT EXISTS subscription_tier VARCHAR(50);
+
+COMMENT ON COLUMN company_mappings.subscription_tier IS 'Tier stored on the company in company-service; NULL until an event carries it (free plan)';
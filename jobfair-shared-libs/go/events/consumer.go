@@ -5,7 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -43,7 +43,7 @@ func NewConsumer(rabbitmqURL string) (*Consumer, error) {
 					nil,              // arguments
 				)
 				if err == nil {
-					log.Println("✅ Connected to RabbitMQ successfully")
+					slog.Info("Connected to RabbitMQ", "role", "consumer")
 					return &Consumer{
 						conn:    conn,
 						channel: channel,
@@ -55,8 +55,8 @@ func NewConsumer(rabbitmqURL string) (*Consumer, error) {
 		}
 
 		waitTime := time.Duration(i+1) * 2 * time.Second
-		log.Printf("⏳ Failed to connect to RabbitMQ (attempt %d/%d). Retrying in %v... Error: %v",
-			i+1, maxRetries, waitTime, err)
+		slog.Warn("Failed to connect to RabbitMQ, retrying", "role", "consumer",
+			"attempt", i+1, "max_attempts", maxRetries, "retry_in", waitTime.String(), "error", err)
 		time.Sleep(waitTime)
 	}
 
@@ -78,10 +78,68 @@ func (c *Consumer) Subscribe(queueName string, routingKeys []string, handler Eve
 		return fmt.Errorf("failed to declare queue: %w", err)
 	}
 
-	// Bind queue to exchange for each routing key
+	// Declare the dead letter queue for events that keep failing
+	deadLetterQueue := DeadLetterQueue(queue.Name)
+	_, err = c.channel.QueueDeclare(
+		deadLetterQueue, // name
+		true,            // durable
+		false,           // delete when unused
+		false,           // exclusive
+		false,           // no-wait
+		nil,             // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare dead letter queue: %w", err)
+	}
+
+	if err := c.bind(queue.Name, routingKeys); err != nil {
+		return err
+	}
+	if err := c.consume(queue.Name, queue.Name, deadLetterQueue, handler); err != nil {
+		return err
+	}
+
+	queueDepths.add(c.conn, queue.Name, deadLetterQueue)
+
+	slog.Info("Consumer started", "queue", queueName)
+	return nil
+}
+
+// SubscribeExclusive processes events with the provided handler from a queue
+// of this consumer only, which RabbitMQ names and deletes when the connection
+// closes. Every replica subscribing this way receives every event, e.g. to
+// drop entries of a per-replica cache. Events missed while disconnected are
+// lost, and events whose handler fails twice are dropped, not dead lettered.
+// Metrics report the queue as name.
+func (c *Consumer) SubscribeExclusive(name string, routingKeys []string, handler EventHandler) error {
+	queue, err := c.channel.QueueDeclare(
+		"",    // name, generated by the server
+		false, // durable
+		true,  // delete when unused
+		true,  // exclusive
+		false, // no-wait
+		nil,   // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	if err := c.bind(queue.Name, routingKeys); err != nil {
+		return err
+	}
+	if err := c.consume(queue.Name, name, "", handler); err != nil {
+		return err
+	}
+
+	slog.Info("Consumer started", "queue", name, "exclusive_queue", queue.Name)
+	return nil
+}
+
+// bind binds the queue to the events exchange for each routing key
+func (c *Consumer) bind(queueName string, routingKeys []string) error {
 	for _, routingKey := range routingKeys {
-		err = c.channel.QueueBind(
-			queue.Name,       // queue name
+		err := c.channel.QueueBind(
+			queueName,        // queue name
 			routingKey,       // routing key
 			"jobfair.events", // exchange
 			false,
@@ -90,11 +148,17 @@ func (c *Consumer) Subscribe(queueName string, routingKeys []string, handler Eve
 		if err != nil {
 			return fmt.Errorf("failed to bind queue: %w", err)
 		}
-		log.Printf("📥 Subscribed to: %s", routingKey)
+		slog.Info("Subscribed to events", "queue", queueName, "routing_key", routingKey)
 	}
+	return nil
+}
 
+// consume processes the queue's events with handler in the background,
+// reporting them in metrics under metricsQueue. Events that fail twice go to
+// deadLetterQueue, or are dropped if it is empty.
+func (c *Consumer) consume(queueName, metricsQueue, deadLetterQueue string, handler EventHandler) error {
 	// Set QoS - process one message at a time
-	err = c.channel.Qos(
+	err := c.channel.Qos(
 		1,     // prefetch count
 		0,     // prefetch size
 		false, // global
@@ -105,13 +169,13 @@ func (c *Consumer) Subscribe(queueName string, routingKeys []string, handler Eve
 
 	// Start consuming
 	messages, err := c.channel.Consume(
-		queue.Name, // queue
-		"",         // consumer
-		false,      // auto-ack
-		false,      // exclusive
-		false,      // no-local
-		false,      // no-wait
-		nil,        // args
+		queueName, // queue
+		"",        // consumer
+		false,     // auto-ack
+		false,     // exclusive
+		false,     // no-local
+		false,     // no-wait
+		nil,       // args
 	)
 	if err != nil {
 		return fmt.Errorf("failed to register consumer: %w", err)
@@ -119,28 +183,83 @@ func (c *Consumer) Subscribe(queueName string, routingKeys []string, handler Eve
 
 	// Process messages
 	go func() {
-		ctx := context.Background()
 		for msg := range messages {
-			log.Printf("📨 Received event: %s", msg.RoutingKey)
-
-			// Process event
+			// Process event within the trace of the request that published it
+			start := time.Now()
+			ctx, span := startProcessSpan(msg)
+			slog.DebugContext(ctx, "Event received", "queue", queueName, "routing_key", msg.RoutingKey)
 			err := handler(ctx, msg.Body)
-			if err != nil {
-				log.Printf("❌ Error processing event: %v", err)
-				// Reject and requeue the message
-				msg.Nack(false, true)
-			} else {
-				log.Printf("✅ Event processed successfully: %s", msg.RoutingKey)
+			endSpan(span, err)
+			eventHandleDuration.WithLabelValues(metricsQueue).Observe(time.Since(start).Seconds())
+
+			switch {
+			case err == nil:
+				slog.InfoContext(ctx, "Event processed", "queue", queueName, "routing_key", msg.RoutingKey)
+				eventsConsumed.WithLabelValues(metricsQueue, msg.RoutingKey, resultOK).Inc()
 				// Acknowledge the message
 				msg.Ack(false)
+			case !msg.Redelivered:
+				slog.WarnContext(ctx, "Event failed, requeued", "queue", queueName, "routing_key", msg.RoutingKey, "error", err)
+				eventsConsumed.WithLabelValues(metricsQueue, msg.RoutingKey, resultRetried).Inc()
+				// Reject and requeue the message for another try
+				msg.Nack(false, true)
+			case deadLetterQueue == "":
+				slog.ErrorContext(ctx, "Redelivered event failed, dropping it", "queue", queueName, "routing_key", msg.RoutingKey, "error", err)
+				eventsConsumed.WithLabelValues(metricsQueue, msg.RoutingKey, resultError).Inc()
+				msg.Nack(false, false)
+			default:
+				slog.ErrorContext(ctx, "Redelivered event failed, dead lettering it", "queue", queueName, "routing_key", msg.RoutingKey, "dead_letter_queue", deadLetterQueue, "error", err)
+				if dlErr := c.deadLetter(deadLetterQueue, msg, err); dlErr != nil {
+					slog.ErrorContext(ctx, "Failed to dead letter event", "queue", queueName, "routing_key", msg.RoutingKey, "error", dlErr)
+					eventsConsumed.WithLabelValues(metricsQueue, msg.RoutingKey, resultError).Inc()
+					msg.Nack(false, true)
+					continue
+				}
+				eventsConsumed.WithLabelValues(metricsQueue, msg.RoutingKey, resultDeadLettered).Inc()
+				msg.Ack(false)
 			}
 		}
 	}()
 
-	log.Printf("🚀 Consumer started for queue: %s", queueName)
 	return nil
 }
 
+// DeadLetterQueue returns the name of the queue that keeps events of queue
+// whose handler failed twice
+func DeadLetterQueue(queue string) string {
+	return queue + ".dlq"
+}
+
+// deadLetter copies a failed delivery to the dead letter queue with its
+// original routing key and the handler's error, to be inspected and replayed
+// by hand
+func (c *Consumer) deadLetter(deadLetterQueue string, msg amqp.Delivery, handlerErr error) error {
+	headers := amqp.Table{}
+	for key, value := range msg.Headers {
+		headers[key] = value
+	}
+	headers["x-original-exchange"] = msg.Exchange
+	headers["x-original-routing-key"] = msg.RoutingKey
+	headers["x-error"] = handlerErr.Error()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return c.channel.PublishWithContext(
+		ctx,
+		"",              // default exchange, routes to the queue by name
+		deadLetterQueue, // routing key
+		false,           // mandatory
+		false,           // immediate
+		amqp.Publishing{
+			ContentType:  msg.ContentType,
+			Headers:      headers,
+			Body:         msg.Body,
+			DeliveryMode: amqp.Persistent,
+			Timestamp:    msg.Timestamp,
+		},
+	)
+}
+
 // SubscribeCompanyEvents is a helper method to subscribe to company events
 func (c *Consumer) SubscribeCompanyEvents(handler EventHandler) error {
 	return c.Subscribe(
@@ -154,8 +273,18 @@ func (c *Consumer) SubscribeCompanyEvents(handler EventHandler) error {
 	)
 }
 
+// SubscribeAnalyticsEvents is a helper method to subscribe to analytics events
+func (c *Consumer) SubscribeAnalyticsEvents(handler EventHandler) error {
+	return c.Subscribe(
+		"company-service.analytics", // queue name
+		[]string{"analytics.*"},
+		handler,
+	)
+}
+
 // Close closes the consumer connection
 func (c *Consumer) Close() error {
+	queueDepths.remove(c.conn)
 	if c.channel != nil {
 		c.channel.Close()
 	}
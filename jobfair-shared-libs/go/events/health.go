@@ -0,0 +1,38 @@
+// File: jobfair-shared-libs/go/events/health.go
+package events
+
+import (
+	"context"
+	"errors"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ErrNotConnected is returned by Check when the RabbitMQ connection or
+// channel is closed, or was never opened; publishing and consuming stop until
+// the service restarts
+var ErrNotConnected = errors.New("events: not connected to RabbitMQ")
+
+// Check reports whether the publisher is connected to RabbitMQ. A nil
+// publisher, left by services that run without one, is not.
+func (p *Publisher) Check(ctx context.Context) error {
+	if p == nil {
+		return ErrNotConnected
+	}
+	return checkConnection(p.conn, p.channel)
+}
+
+// Check reports whether the consumer is connected to RabbitMQ
+func (c *Consumer) Check(ctx context.Context) error {
+	if c == nil {
+		return ErrNotConnected
+	}
+	return checkConnection(c.conn, c.channel)
+}
+
+func checkConnection(conn *amqp.Connection, channel *amqp.Channel) error {
+	if conn == nil || conn.IsClosed() || channel == nil || channel.IsClosed() {
+		return ErrNotConnected
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// File: jobfair-shared-libs/go/events/metrics.go
+package events
+
+import (
+	"log/slog"
+	"sync"
+
+	"github.com/jobfair/shared/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Results of publishing and consuming events
+const (
+	resultOK           = "ok"
+	resultError        = "error"
+	resultRetried      = "retried"       // Handler failed; the message was requeued for one more try
+	resultDeadLettered = "dead_lettered" // Handler failed again; the message was moved to the queue's DLQ
+)
+
+var (
+	eventsPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "events_published_total",
+		Help:      "Events published to RabbitMQ, by routing key and result.",
+	}, []string{"routing_key", "result"})
+
+	eventsConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "events_consumed_total",
+		Help:      "Events handled from RabbitMQ, by queue, routing key and result.",
+	}, []string{"queue", "routing_key", "result"})
+
+	eventHandleDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Name:      "events_handle_duration_seconds",
+		Help:      "Time to handle events, by queue.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"queue"})
+)
+
+func init() {
+	prometheus.MustRegister(queueDepths)
+}
+
+// queueDepths reports the messages waiting in every queue consumed by this
+// process and in its dead letter queue
+var queueDepths = &queueDepthCollector{
+	desc: prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "events", "queue_messages"),
+		"Messages ready in consumed RabbitMQ queues and their dead letter queues (<queue>.dlq).",
+		[]string{"queue"}, nil,
+	),
+	queues: make(map[string]*amqp.Connection),
+}
+
+type queueDepthCollector struct {
+	desc *prometheus.Desc
+
+	mu     sync.Mutex
+	queues map[string]*amqp.Connection // Queue name -> connection to inspect it with
+}
+
+func (q *queueDepthCollector) add(conn *amqp.Connection, queues ...string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, name := range queues {
+		q.queues[name] = conn
+	}
+}
+
+func (q *queueDepthCollector) remove(conn *amqp.Connection) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for name, c := range q.queues {
+		if c == conn {
+			delete(q.queues, name)
+		}
+	}
+}
+
+func (q *queueDepthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- q.desc
+}
+
+func (q *queueDepthCollector) Collect(ch chan<- prometheus.Metric) {
+	q.mu.Lock()
+	queues := make(map[string]*amqp.Connection, len(q.queues))
+	for name, conn := range q.queues {
+		queues[name] = conn
+	}
+	q.mu.Unlock()
+
+	for name, conn := range queues {
+		// A failed passive declare closes its channel, so each queue gets its own
+		channel, err := conn.Channel()
+		if err != nil {
+			slog.Warn("Failed to inspect queue", "queue", name, "error", err)
+			continue
+		}
+		queue, err := channel.QueueDeclarePassive(name, true, false, false, false, nil)
+		channel.Close()
+		if err != nil {
+			slog.Warn("Failed to inspect queue", "queue", name, "error", err)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(q.desc, prometheus.GaugeValue, float64(queue.Messages), name)
+	}
+}
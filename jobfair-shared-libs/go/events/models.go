@@ -1,14 +1,32 @@
 // File: jobfair-shared-libs/go/events/models.go
 package events
 
-import "time"
+import (
+	"time"
+
+	"github.com/jobfair/shared/plans"
+)
 
 // Event Types
 const (
 	EventTypeCompanyRegistered = "company.registered"
 	EventTypeCompanyUpdated    = "company.updated"
 	EventTypeCompanyDeleted    = "company.deleted"
+	EventTypeCompanyVerified   = "company.verified"
+
+	EventTypeCompanySubscriptionChanged = "company.subscription_changed"
 	EventTypeUserRegistered    = "user.registered"
+
+	EventTypeJobPublished = "job.published"
+	EventTypeJobUpdated   = "job.updated"
+	EventTypeJobClosed    = "job.closed"
+	EventTypeJobDeleted   = "job.deleted"
+
+	// Analytics events, aggregated by company-service
+	EventTypeJobViewed      = "analytics.job_viewed"
+	EventTypeJobSaved       = "analytics.job_saved"
+	EventTypeJobApplied     = "analytics.job_applied"
+	EventTypeCandidateHired = "analytics.candidate_hired"
 )
 
 // BaseEvent contains common fields for all events
@@ -37,6 +55,8 @@ type CompanyRegisteredData struct {
 	LogoURL     string   `json:"logo_url"`
 	CountryCode string   `json:"country_code"`
 	ContactName string   `json:"contact_name"` // Added for UI support
+
+	SubscriptionTier plans.Tier `json:"subscription_tier,omitempty"` // Tier stored on the company
 }
 
 // CompanyUpdatedEvent is published when company profile is updated
@@ -60,6 +80,42 @@ type CompanyDeletedData struct {
 	UserID uint `json:"user_id"`
 }
 
+// CompanyVerifiedEvent is published when an admin approves or rejects a
+// company's verification request
+type CompanyVerifiedEvent struct {
+	BaseEvent
+	Data CompanyVerifiedData `json:"data"`
+}
+
+type CompanyVerifiedData struct {
+	CompanyID   uint      `json:"company_id"`
+	UserID      uint      `json:"user_id"`
+	CompanyName string    `json:"company_name"`
+	Verified    bool      `json:"verified"`
+	Status      string    `json:"status"` // approved, rejected
+	Reason      string    `json:"reason,omitempty"`
+	ReviewedAt  time.Time `json:"reviewed_at"`
+}
+
+// CompanySubscriptionChangedEvent is published when an admin changes a
+// company's subscription tier. The change applies between EffectiveFrom and
+// EffectiveUntil; Limits are the plan limits at the time of the change.
+type CompanySubscriptionChangedEvent struct {
+	BaseEvent
+	Data CompanySubscriptionChangedData `json:"data"`
+}
+
+type CompanySubscriptionChangedData struct {
+	SubscriptionID uint         `json:"subscription_id"`
+	CompanyID      uint         `json:"company_id"`
+	UserID         uint         `json:"user_id"`
+	Tier           plans.Tier   `json:"tier"`
+	Limits         plans.Limits `json:"limits"`
+	EffectiveFrom  time.Time    `json:"effective_from"`
+	EffectiveUntil *time.Time   `json:"effective_until,omitempty"` // nil = open-ended
+	ChangedBy      uint         `json:"changed_by"`
+}
+
 // UserRegisteredEvent is published when a user completes registration
 type UserRegisteredEvent struct {
 	BaseEvent
@@ -74,3 +130,36 @@ type UserRegisteredData struct {
 	Role             string `json:"role"`
 	ProfilePhotoURL  string `json:"profile_photo_url"`
 }
+
+// JobLifecycleEvent is published when a job changes lifecycle state
+// (published or closed), either manually or by the job scheduler, and when
+// it is edited or deleted
+type JobLifecycleEvent struct {
+	BaseEvent
+	Data JobLifecycleData `json:"data"`
+}
+
+type JobLifecycleData struct {
+	JobID     uint      `json:"job_id"`
+	CompanyID uint      `json:"company_id"`
+	UserID    uint      `json:"user_id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	Trigger   string    `json:"trigger"` // manual, scheduled, deadline
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// AnalyticsEvent records a single interaction with a company's job
+// (view, save, apply, hire) for company analytics
+type AnalyticsEvent struct {
+	BaseEvent
+	Data AnalyticsData `json:"data"`
+}
+
+type AnalyticsData struct {
+	CompanyID  uint      `json:"company_id"`
+	JobID      uint      `json:"job_id"`
+	JobTitle   string    `json:"job_title"`
+	UserID     uint      `json:"user_id,omitempty"` // 0 for anonymous views
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -5,7 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
@@ -41,7 +41,7 @@ func NewPublisher(rabbitmqURL string) (*Publisher, error) {
 					nil,              // arguments
 				)
 				if err == nil {
-					log.Println("✅ Connected to RabbitMQ (Publisher) successfully")
+					slog.Info("Connected to RabbitMQ", "role", "publisher")
 					return &Publisher{
 						conn:    conn,
 						channel: channel,
@@ -53,8 +53,8 @@ func NewPublisher(rabbitmqURL string) (*Publisher, error) {
 		}
 
 		waitTime := time.Duration(i+1) * 2 * time.Second
-		log.Printf("⏳ Failed to connect to RabbitMQ Publisher (attempt %d/%d). Retrying in %v... Error: %v",
-			i+1, maxRetries, waitTime, err)
+		slog.Warn("Failed to connect to RabbitMQ, retrying", "role", "publisher",
+			"attempt", i+1, "max_attempts", maxRetries, "retry_in", waitTime.String(), "error", err)
 		time.Sleep(waitTime)
 	}
 
@@ -121,13 +121,96 @@ func (p *Publisher) PublishCompanyDeleted(ctx context.Context, data CompanyDelet
 	return p.publish(ctx, EventTypeCompanyDeleted, event)
 }
 
+// PublishCompanyVerified publishes a company verification result
+func (p *Publisher) PublishCompanyVerified(ctx context.Context, data CompanyVerifiedData) error {
+	event := CompanyVerifiedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   uuid.New().String(),
+			EventType: EventTypeCompanyVerified,
+			Timestamp: time.Now(),
+			Version:   "1.0",
+		},
+		Data: data,
+	}
+
+	return p.publish(ctx, EventTypeCompanyVerified, event)
+}
+
+// PublishCompanySubscriptionChanged publishes a company subscription change
+func (p *Publisher) PublishCompanySubscriptionChanged(ctx context.Context, data CompanySubscriptionChangedData) error {
+	event := CompanySubscriptionChangedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   uuid.New().String(),
+			EventType: EventTypeCompanySubscriptionChanged,
+			Timestamp: time.Now(),
+			Version:   "1.0",
+		},
+		Data: data,
+	}
+
+	return p.publish(ctx, EventTypeCompanySubscriptionChanged, event)
+}
+
+// PublishAnalytics publishes an analytics event; eventType is one of the
+// analytics.* event types
+func (p *Publisher) PublishAnalytics(ctx context.Context, eventType string, data AnalyticsData) error {
+	event := AnalyticsEvent{
+		BaseEvent: BaseEvent{
+			EventID:   uuid.New().String(),
+			EventType: eventType,
+			Timestamp: time.Now(),
+			Version:   "1.0",
+		},
+		Data: data,
+	}
+
+	return p.publish(ctx, eventType, event)
+}
+
+// PublishJobPublished publishes a job published event
+func (p *Publisher) PublishJobPublished(ctx context.Context, data JobLifecycleData) error {
+	return p.publishJobLifecycle(ctx, EventTypeJobPublished, data)
+}
+
+// PublishJobUpdated publishes a job updated event
+func (p *Publisher) PublishJobUpdated(ctx context.Context, data JobLifecycleData) error {
+	return p.publishJobLifecycle(ctx, EventTypeJobUpdated, data)
+}
+
+// PublishJobClosed publishes a job closed event
+func (p *Publisher) PublishJobClosed(ctx context.Context, data JobLifecycleData) error {
+	return p.publishJobLifecycle(ctx, EventTypeJobClosed, data)
+}
+
+// PublishJobDeleted publishes a job deleted event
+func (p *Publisher) PublishJobDeleted(ctx context.Context, data JobLifecycleData) error {
+	return p.publishJobLifecycle(ctx, EventTypeJobDeleted, data)
+}
+
+func (p *Publisher) publishJobLifecycle(ctx context.Context, eventType string, data JobLifecycleData) error {
+	event := JobLifecycleEvent{
+		BaseEvent: BaseEvent{
+			EventID:   uuid.New().String(),
+			EventType: eventType,
+			Timestamp: time.Now(),
+			Version:   "1.0",
+		},
+		Data: data,
+	}
+
+	return p.publish(ctx, eventType, event)
+}
+
 // publish is the internal method to publish events
-func (p *Publisher) publish(ctx context.Context, routingKey string, event interface{}) error {
+func (p *Publisher) publish(ctx context.Context, routingKey string, event interface{}) (err error) {
 	body, err := json.Marshal(event)
 	if err != nil {
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
+	ctx, span, headers := startPublishSpan(ctx, routingKey)
+	defer func() { endSpan(span, err) }()
+
 	err = p.channel.PublishWithContext(
 		ctx,
 		"jobfair.events", // exchange
@@ -136,6 +219,7 @@ func (p *Publisher) publish(ctx context.Context, routingKey string, event interf
 		false,            // immediate
 		amqp.Publishing{
 			ContentType:  "application/json",
+			Headers:      headers, // Trace context and request ID
 			Body:         body,
 			DeliveryMode: amqp.Persistent, // persistent messages
 			Timestamp:    time.Now(),
@@ -143,10 +227,12 @@ func (p *Publisher) publish(ctx context.Context, routingKey string, event interf
 	)
 
 	if err != nil {
+		eventsPublished.WithLabelValues(routingKey, resultError).Inc()
 		return fmt.Errorf("failed to publish event: %w", err)
 	}
 
-	log.Printf("✅ Event published: %s", routingKey)
+	eventsPublished.WithLabelValues(routingKey, resultOK).Inc()
+	slog.InfoContext(ctx, "Event published", "routing_key", routingKey)
 	return nil
 }
 
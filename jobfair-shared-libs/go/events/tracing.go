@@ -0,0 +1,86 @@
+// File: jobfair-shared-libs/go/events/tracing.go
+package events
+
+import (
+	"context"
+
+	"github.com/jobfair/shared/tracing"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// headerCarrier lets the OpenTelemetry propagators read and write trace
+// context in AMQP message headers
+type headerCarrier amqp.Table
+
+func (c headerCarrier) Get(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+func (c headerCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// startPublishSpan starts the producer span of a message and returns the
+// headers carrying its trace context and the request ID of ctx
+func startPublishSpan(ctx context.Context, routingKey string) (context.Context, trace.Span, amqp.Table) {
+	ctx, span := tracing.Start(ctx, "publish "+routingKey,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemRabbitMQ,
+			semconv.MessagingOperationTypeSend,
+			semconv.MessagingDestinationName("jobfair.events"),
+			semconv.MessagingRabbitMQDestinationRoutingKey(routingKey),
+		),
+	)
+
+	headers := amqp.Table{}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(headers))
+	if id := tracing.RequestIDFromContext(ctx); id != "" {
+		headers[tracing.HeaderRequestID] = id
+	}
+	return ctx, span, headers
+}
+
+// startProcessSpan starts the consumer span of a delivery, continuing the
+// trace of the request that published it
+func startProcessSpan(msg amqp.Delivery) (context.Context, trace.Span) {
+	ctx := context.Background()
+	if msg.Headers != nil {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier(msg.Headers))
+		if id, ok := msg.Headers[tracing.HeaderRequestID].(string); ok && tracing.ValidRequestID(id) {
+			ctx = tracing.WithRequestID(ctx, id)
+		}
+	}
+
+	return tracing.Start(ctx, "process "+msg.RoutingKey,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemRabbitMQ,
+			semconv.MessagingOperationTypeProcess,
+			semconv.MessagingDestinationName(msg.Exchange),
+			semconv.MessagingRabbitMQDestinationRoutingKey(msg.RoutingKey),
+		),
+	)
+}
+
+// endSpan ends span, marking it failed when err is set
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
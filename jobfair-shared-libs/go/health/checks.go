@@ -0,0 +1,68 @@
+// File: jobfair-shared-libs/go/health/checks.go
+package health
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// probeContent is written by the Storage check
+const probeContent = "ok"
+
+// Database checks that db can reach the database server
+func Database(db *sql.DB) CheckFunc {
+	return db.PingContext
+}
+
+// ObjectStore is the part of storage.Store the Storage check uses
+type ObjectStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Storage checks that objects can be written to and deleted from store by
+// putting and deleting a small object under .health/
+func Storage(store ObjectStore) CheckFunc {
+	return func(ctx context.Context) error {
+		b := make([]byte, 8)
+		rand.Read(b)
+		key := path.Join(".health", "probe-"+hex.EncodeToString(b))
+
+		if _, err := store.Put(ctx, key, strings.NewReader(probeContent), int64(len(probeContent)), "text/plain"); err != nil {
+			return fmt.Errorf("write: %w", err)
+		}
+		if err := store.Delete(ctx, key); err != nil {
+			return fmt.Errorf("delete: %w", err)
+		}
+		return nil
+	}
+}
+
+// HTTP checks that GET url answers with a 2xx status. Point it at an
+// upstream's /livez, so a service's readiness doesn't depend on the
+// upstream's own dependencies.
+func HTTP(url string) CheckFunc {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("GET %s: %s", url, resp.Status)
+		}
+		return nil
+	}
+}
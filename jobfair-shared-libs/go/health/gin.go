@@ -0,0 +1,35 @@
+// File: jobfair-shared-libs/go/health/gin.go
+package health
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Register adds the probe endpoints:
+//
+//	GET /livez   200 while the process serves requests; no dependency is checked
+//	GET /readyz  runs the checks; 503 when a critical one fails
+//	GET /health  same as /readyz, for existing monitors
+func (h *Checker) Register(router gin.IRoutes) {
+	router.GET("/livez", Live)
+	router.GET("/readyz", h.Ready)
+	router.GET("/health", h.Ready)
+}
+
+// Live answers liveness probes
+func Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": StatusUp})
+}
+
+// Ready runs the checks and answers with the report
+func (h *Checker) Ready(c *gin.Context) {
+	report := h.Run(c.Request.Context())
+
+	status := http.StatusOK
+	if report.Status == StatusDown {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
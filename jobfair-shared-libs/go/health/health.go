@@ -0,0 +1,129 @@
+// File: jobfair-shared-libs/go/health/health.go
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Statuses of checks and reports
+const (
+	StatusUp       = "up"
+	StatusDegraded = "degraded" // Only optional checks failed
+	StatusDown     = "down"
+)
+
+// DefaultTimeout bounds each check
+const DefaultTimeout = 2 * time.Second
+
+// CheckFunc reports whether a dependency is usable; nil means up
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name     string
+	critical bool
+	run      CheckFunc
+}
+
+// Result is the outcome of one check
+type Result struct {
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the outcome of all checks of a service
+type Report struct {
+	Status    string            `json:"status"`
+	Service   string            `json:"service"`
+	Timestamp string            `json:"timestamp"`
+	Checks    map[string]Result `json:"checks"`
+}
+
+// Checker runs the dependency checks of a service. Checks are added at
+// startup, before the checker serves requests.
+type Checker struct {
+	service string
+	timeout time.Duration
+	checks  []check
+}
+
+// NewChecker creates a checker for service whose checks time out after
+// DefaultTimeout
+func NewChecker(service string) *Checker {
+	return &Checker{service: service, timeout: DefaultTimeout}
+}
+
+// Add adds a check the service can't work without; the service is down
+// while it fails
+func (h *Checker) Add(name string, fn CheckFunc) *Checker {
+	h.checks = append(h.checks, check{name: name, critical: true, run: fn})
+	return h
+}
+
+// AddOptional adds a check the service can work without, e.g. an upstream
+// it has a fallback for; the service is degraded while it fails
+func (h *Checker) AddOptional(name string, fn CheckFunc) *Checker {
+	h.checks = append(h.checks, check{name: name, run: fn})
+	return h
+}
+
+// Run runs all checks concurrently
+func (h *Checker) Run(ctx context.Context) Report {
+	results := make([]Result, len(h.checks))
+
+	var wg sync.WaitGroup
+	for i, c := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = h.run(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{
+		Status:    StatusUp,
+		Service:   h.service,
+		Timestamp: time.Now().Format(time.RFC3339),
+		Checks:    make(map[string]Result, len(h.checks)),
+	}
+	for i, c := range h.checks {
+		result := results[i]
+		report.Checks[c.name] = result
+		if result.Status == StatusUp {
+			continue
+		}
+		if c.critical {
+			report.Status = StatusDown
+		} else if report.Status == StatusUp {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+func (h *Checker) run(ctx context.Context, c check) Result {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.run(ctx)
+	result := Result{
+		Status:    StatusUp,
+		Critical:  c.critical,
+		LatencyMS: Milliseconds(time.Since(start)),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Milliseconds converts d to milliseconds with microsecond precision
+func Milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
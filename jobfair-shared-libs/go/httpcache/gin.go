@@ -0,0 +1,260 @@
+// File: jobfair-shared-libs/go/httpcache/gin.go
+package httpcache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Gin context keys set by handlers of cached routes
+const (
+	contextKeyTags = "httpcache_tags"
+	contextKeySkip = "httpcache_skip"
+)
+
+// storeTimeout bounds writing an entry after the response is computed
+const storeTimeout = time.Second
+
+// Options configures the caching of a route
+type Options struct {
+	// Tags of every entry of the route, e.g. "jobs" for a listing; handlers
+	// add tags that depend on the response with Tag
+	Tags []string
+
+	// Ignore lists query parameters that don't change the response, left out
+	// of the key (e.g. tracking parameters)
+	Ignore []string
+
+	// OnHit replays side effects of the handler, such as counting a view,
+	// when the response is served from the store
+	OnHit gin.HandlerFunc
+}
+
+// Tag adds tags to the response being cached, e.g. the company of a job, so
+// invalidating any of them drops it
+func Tag(c *gin.Context, tags ...string) {
+	c.Set(contextKeyTags, append(c.GetStringSlice(contextKeyTags), tags...))
+}
+
+// Skip keeps the response being computed out of the store, e.g. when it
+// shows promotions whose impressions must be counted on every request. It
+// still gets an ETag.
+func Skip(c *gin.Context) {
+	c.Set(contextKeySkip, true)
+}
+
+// Handler caches the 200 responses of a GET route. Every response gets an
+// ETag and conditional requests matching it get a 304. Responses to anonymous
+// requests are stored under the path and normalized query (sorted, without
+// empty and ignored parameters) and served from the store with a
+// Last-Modified of when they were computed; requests with an Authorization
+// header or an identity may be personalized and are always computed.
+func (c *Cache) Handler(opts Options) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodGet {
+			ctx.Next()
+			return
+		}
+
+		route := ctx.FullPath()
+		shared := anonymous(ctx)
+
+		// Personalized responses are never stored
+		result := resultBypass
+		var key string
+		if shared && c.store != nil {
+			key = normalizedKey(ctx.Request.URL, opts.Ignore)
+			entry, err := c.store.Get(ctx.Request.Context(), key)
+			switch {
+			case err != nil:
+				slog.WarnContext(ctx.Request.Context(), "Failed to read cached response", "route", route, "error", err)
+				result = resultError
+			case entry != nil:
+				cacheRequests.WithLabelValues(route, resultHit).Inc()
+				if opts.OnHit != nil {
+					opts.OnHit(ctx)
+				}
+				ctx.Header("X-Cache", "HIT")
+				serve(ctx, entry, shared)
+				ctx.Abort()
+				return
+			default:
+				result = resultMiss
+			}
+		}
+
+		started := time.Now()
+		recorder := &bufferedWriter{ResponseWriter: ctx.Writer, status: http.StatusOK}
+		ctx.Writer = recorder
+		// Also restored on panics, so gin.Recovery's 500 reaches the client
+		defer func() { ctx.Writer = recorder.ResponseWriter }()
+		ctx.Next()
+		ctx.Writer = recorder.ResponseWriter
+
+		if result == resultMiss && (recorder.status != http.StatusOK || ctx.GetBool(contextKeySkip)) {
+			result = resultBypass
+		}
+		if c.store != nil {
+			cacheRequests.WithLabelValues(route, result).Inc()
+			ctx.Header("X-Cache", strings.ToUpper(result))
+		}
+
+		if recorder.status != http.StatusOK {
+			ctx.Writer.WriteHeader(recorder.status)
+			ctx.Writer.Write(recorder.body.Bytes())
+			return
+		}
+
+		entry := &Entry{
+			Body:        recorder.body.Bytes(),
+			ContentType: ctx.Writer.Header().Get("Content-Type"),
+			ETag:        etag(recorder.body.Bytes()),
+			StoredAt:    started,
+		}
+		if result == resultMiss {
+			tags := append(append([]string{}, opts.Tags...), ctx.GetStringSlice(contextKeyTags)...)
+
+			// Stored even when the client went away, bounded by storeTimeout
+			storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx.Request.Context()), storeTimeout)
+			if err := c.store.Set(storeCtx, key, entry, tags, c.ttl); err != nil {
+				slog.WarnContext(ctx.Request.Context(), "Failed to store response", "route", route, "error", err)
+			}
+			cancel()
+		}
+		serve(ctx, entry, shared)
+	}
+}
+
+// anonymous reports whether the response can't depend on who asks
+func anonymous(c *gin.Context) bool {
+	if c.GetHeader("Authorization") != "" {
+		return false
+	}
+	_, identified := c.Get("user_id")
+	return !identified
+}
+
+// serve writes the entry, or a 304 when the request's validators match it
+func serve(c *gin.Context, entry *Entry, shared bool) {
+	header := c.Writer.Header()
+	header.Set("ETag", entry.ETag)
+	header.Add("Vary", "Authorization")
+	if shared {
+		// Clients revalidate every time, so invalidated responses are never reused
+		header.Set("Cache-Control", "public, no-cache")
+		header.Set("Last-Modified", entry.StoredAt.UTC().Format(http.TimeFormat))
+	} else {
+		header.Set("Cache-Control", "private, no-cache")
+	}
+
+	if isNotModified(c.Request, entry, shared) {
+		notModified.WithLabelValues(c.FullPath()).Inc()
+		header.Del("Content-Type")
+		header.Del("Content-Length")
+		c.Writer.WriteHeader(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+
+	if entry.ContentType != "" {
+		header.Set("Content-Type", entry.ContentType)
+	}
+	c.Writer.WriteHeader(http.StatusOK)
+	c.Writer.Write(entry.Body)
+}
+
+// isNotModified evaluates If-None-Match, or If-Modified-Since when there is
+// none and the entry has a Last-Modified
+func isNotModified(r *http.Request, entry *Entry, shared bool) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == entry.ETag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !shared {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	return err == nil && !entry.StoredAt.Truncate(time.Second).After(since)
+}
+
+// normalizedKey is the path and the query sorted by parameter, without
+// empty values and ignored parameters
+func normalizedKey(u *url.URL, ignore []string) string {
+	query := u.Query()
+	for _, name := range ignore {
+		query.Del(name)
+	}
+	for name, values := range query {
+		kept := values[:0]
+		for _, value := range values {
+			if value != "" {
+				kept = append(kept, value)
+			}
+		}
+		if len(kept) == 0 {
+			query.Del(name)
+		} else {
+			query[name] = kept
+		}
+	}
+
+	if len(query) == 0 {
+		return u.Path
+	}
+	return u.Path + "?" + query.Encode()
+}
+
+// etag is a strong validator of the body
+func etag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// bufferedWriter holds the handler's response so it can be stored and
+// answered with a 304 instead
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.body.Len() > 0
+}
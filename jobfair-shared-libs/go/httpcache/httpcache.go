@@ -0,0 +1,132 @@
+// File: jobfair-shared-libs/go/httpcache/httpcache.go
+package httpcache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Backend names
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+	BackendNone   = "none"
+)
+
+// Config selects where cached responses are kept
+type Config struct {
+	Backend    string        // memory, redis or none (ETags and 304s only)
+	RedisURL   string        // redis://[:password@]host:port/db
+	Namespace  string        // Prefixes the service's keys in Redis, e.g. job-service
+	TTL        time.Duration // How long an entry is served when no event invalidates it
+	MaxEntries int           // Entries kept by the memory backend
+}
+
+// DefaultConfig returns an in-memory cache of 10000 entries kept 5 minutes
+func DefaultConfig(namespace string) Config {
+	return Config{
+		Backend:    BackendMemory,
+		RedisURL:   "redis://localhost:6379/0",
+		Namespace:  namespace,
+		TTL:        5 * time.Minute,
+		MaxEntries: 10000,
+	}
+}
+
+// ConfigFromEnv reads HTTP_CACHE_BACKEND, HTTP_CACHE_REDIS_URL (default
+// REDIS_URL), HTTP_CACHE_TTL and HTTP_CACHE_MAX_ENTRIES over the defaults.
+// Invalid values keep the default.
+func ConfigFromEnv(namespace string) Config {
+	cfg := DefaultConfig(namespace)
+	if backend := os.Getenv("HTTP_CACHE_BACKEND"); backend != "" {
+		cfg.Backend = strings.ToLower(backend)
+	}
+	if url := os.Getenv("HTTP_CACHE_REDIS_URL"); url != "" {
+		cfg.RedisURL = url
+	} else if url := os.Getenv("REDIS_URL"); url != "" {
+		cfg.RedisURL = url
+	}
+	if d, err := time.ParseDuration(os.Getenv("HTTP_CACHE_TTL")); err == nil && d > 0 {
+		cfg.TTL = d
+	}
+	if n, err := strconv.Atoi(os.Getenv("HTTP_CACHE_MAX_ENTRIES")); err == nil && n > 0 {
+		cfg.MaxEntries = n
+	}
+	return cfg
+}
+
+// Cache serves GET responses with ETags and answers conditional requests
+// with 304. Responses to anonymous requests are also kept in a store, keyed
+// by path and normalized query, until their TTL passes or one of their tags
+// is invalidated (see Invalidate).
+type Cache struct {
+	store Store // nil when responses are not stored
+	ttl   time.Duration
+}
+
+// New creates the cache selected by cfg.Backend. The Redis backend checks
+// that Redis responds.
+func New(ctx context.Context, cfg Config) (*Cache, error) {
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultConfig(cfg.Namespace).TTL
+	}
+
+	switch cfg.Backend {
+	case "", BackendMemory:
+		return NewWithStore(NewMemoryStore(cfg.MaxEntries), cfg.TTL), nil
+	case BackendRedis:
+		store, err := NewRedisStoreFromURL(ctx, cfg.RedisURL, cfg.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		return NewWithStore(store, cfg.TTL), nil
+	case BackendNone:
+		return NewWithStore(nil, cfg.TTL), nil
+	}
+	return nil, fmt.Errorf("httpcache: unknown backend %q", cfg.Backend)
+}
+
+// NewWithStore creates a cache keeping entries in store for ttl. A nil store
+// only adds ETags and 304s.
+func NewWithStore(store Store, ttl time.Duration) *Cache {
+	return &Cache{store: store, ttl: ttl}
+}
+
+// Invalidate drops the entries carrying any of the tags, e.g. after an event
+// says the job or company they show changed
+func (c *Cache) Invalidate(ctx context.Context, tags ...string) error {
+	if c == nil || c.store == nil || len(tags) == 0 {
+		return nil
+	}
+	return c.store.Invalidate(ctx, tags...)
+}
+
+// Shared reports whether stored entries are shared by every replica (the
+// Redis store), so one replica's invalidation drops them for all
+func (c *Cache) Shared() bool {
+	if c == nil {
+		return false
+	}
+	_, ok := c.store.(*RedisStore)
+	return ok
+}
+
+// Check reports whether the store responds
+func (c *Cache) Check(ctx context.Context) error {
+	if c == nil || c.store == nil {
+		return nil
+	}
+	return c.store.Ping(ctx)
+}
+
+// Close releases the store's connections
+func (c *Cache) Close() error {
+	if c == nil || c.store == nil {
+		return nil
+	}
+	return c.store.Close()
+}
@@ -0,0 +1,141 @@
+// File: jobfair-shared-libs/go/httpcache/memory.go
+package httpcache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryItem struct {
+	key     string
+	entry   *Entry
+	tags    []string
+	expires time.Time
+}
+
+// MemoryStore keeps entries in the service's memory, evicting the least
+// recently used ones beyond its size. Every replica has its own entries and
+// only drops them on the events it consumes; use RedisStore to share them.
+type MemoryStore struct {
+	maxEntries int
+
+	mu          sync.Mutex
+	items       map[string]*list.Element // key -> *memoryItem
+	order       *list.List               // Most recently used first
+	tags        map[string]map[string]struct{}
+	invalidated map[string]time.Time
+}
+
+// NewMemoryStore creates a store of at most maxEntries entries
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	if maxEntries < 1 {
+		maxEntries = 1
+	}
+	return &MemoryStore{
+		maxEntries:  maxEntries,
+		items:       make(map[string]*list.Element),
+		order:       list.New(),
+		tags:        make(map[string]map[string]struct{}),
+		invalidated: make(map[string]time.Time),
+	}
+}
+
+// Get returns the entry under key, or nil when there is none
+func (s *MemoryStore) Get(ctx context.Context, key string) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.items[key]
+	if !ok {
+		return nil, nil
+	}
+	item := element.Value.(*memoryItem)
+	if time.Now().After(item.expires) {
+		s.remove(element)
+		return nil, nil
+	}
+	s.order.MoveToFront(element)
+	return item.entry, nil
+}
+
+// Set stores the entry under key for ttl, unless one of its tags was
+// invalidated since the entry's request started
+func (s *MemoryStore) Set(ctx context.Context, key string, entry *Entry, tags []string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tag := range tags {
+		if at, ok := s.invalidated[tag]; ok && !at.Before(entry.StoredAt) {
+			return nil
+		}
+	}
+
+	if element, ok := s.items[key]; ok {
+		s.remove(element)
+	}
+	for s.order.Len() >= s.maxEntries {
+		s.remove(s.order.Back())
+	}
+
+	item := &memoryItem{key: key, entry: entry, tags: tags, expires: time.Now().Add(ttl)}
+	s.items[key] = s.order.PushFront(item)
+	for _, tag := range tags {
+		keys, ok := s.tags[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			s.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+	return nil
+}
+
+// Invalidate drops the entries carrying any of the tags
+func (s *MemoryStore) Invalidate(ctx context.Context, tags ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for tag, at := range s.invalidated {
+		if now.Sub(at) > invalidationWindow {
+			delete(s.invalidated, tag)
+		}
+	}
+
+	for _, tag := range tags {
+		s.invalidated[tag] = now
+		for key := range s.tags[tag] {
+			if element, ok := s.items[key]; ok {
+				s.remove(element)
+			}
+		}
+		delete(s.tags, tag)
+	}
+	return nil
+}
+
+// remove drops an entry and its tag references
+func (s *MemoryStore) remove(element *list.Element) {
+	item := s.order.Remove(element).(*memoryItem)
+	delete(s.items, item.key)
+	for _, tag := range item.tags {
+		if keys, ok := s.tags[tag]; ok {
+			delete(keys, item.key)
+			if len(keys) == 0 {
+				delete(s.tags, tag)
+			}
+		}
+	}
+}
+
+// Ping always succeeds
+func (s *MemoryStore) Ping(ctx context.Context) error {
+	return nil
+}
+
+// Close does nothing
+func (s *MemoryStore) Close() error {
+	return nil
+}
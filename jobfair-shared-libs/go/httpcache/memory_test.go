@@ -0,0 +1,101 @@
+package httpcache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func testEntry(body string, storedAt time.Time) *Entry {
+	return &Entry{Body: []byte(body), ContentType: "application/json", ETag: `"` + body + `"`, StoredAt: storedAt}
+}
+
+func TestMemoryStoreInvalidateByTag(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(10)
+	now := time.Now()
+
+	store.Set(ctx, "/jobs?page=1", testEntry("list", now), []string{"jobs"}, time.Minute)
+	store.Set(ctx, "/jobs/7", testEntry("job 7", now), []string{"jobs", "job:7"}, time.Minute)
+	store.Set(ctx, "/jobs/8", testEntry("job 8", now), []string{"jobs", "job:8"}, time.Minute)
+	store.Set(ctx, "/companies/3", testEntry("company 3", now), []string{"company:3"}, time.Minute)
+
+	if err := store.Invalidate(ctx, "job:7"); err != nil {
+		t.Fatalf("Invalidate: %v", err)
+	}
+	if entry, _ := store.Get(ctx, "/jobs/7"); entry != nil {
+		t.Error("entry tagged job:7 survived its invalidation")
+	}
+	for _, key := range []string{"/jobs?page=1", "/jobs/8", "/companies/3"} {
+		if entry, _ := store.Get(ctx, key); entry == nil {
+			t.Errorf("%s was dropped by another tag's invalidation", key)
+		}
+	}
+
+	store.Invalidate(ctx, "jobs")
+	for _, key := range []string{"/jobs?page=1", "/jobs/8"} {
+		if entry, _ := store.Get(ctx, key); entry != nil {
+			t.Errorf("%s survived the invalidation of jobs", key)
+		}
+	}
+	if entry, _ := store.Get(ctx, "/companies/3"); entry == nil {
+		t.Error("untagged entry was dropped")
+	}
+	if len(store.tags["jobs"]) != 0 || len(store.tags["job:8"]) != 0 {
+		t.Errorf("tag references left after the invalidation: %v", store.tags)
+	}
+}
+
+func TestMemoryStoreSkipsEntriesOlderThanInvalidation(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(10)
+
+	// The response was computed before the job changed, so it may be stale
+	started := time.Now()
+	store.Invalidate(ctx, "job:7")
+	store.Set(ctx, "/jobs/7", testEntry("stale", started), []string{"job:7"}, time.Minute)
+	if entry, _ := store.Get(ctx, "/jobs/7"); entry != nil {
+		t.Error("entry computed before the invalidation was stored")
+	}
+
+	store.Set(ctx, "/jobs/7", testEntry("fresh", time.Now().Add(time.Millisecond)), []string{"job:7"}, time.Minute)
+	if entry, _ := store.Get(ctx, "/jobs/7"); entry == nil || string(entry.Body) != "fresh" {
+		t.Errorf("entry computed after the invalidation = %v, want it stored", entry)
+	}
+}
+
+func TestMemoryStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(2)
+	now := time.Now()
+
+	store.Set(ctx, "a", testEntry("a", now), []string{"t"}, time.Minute)
+	store.Set(ctx, "b", testEntry("b", now), []string{"t"}, time.Minute)
+	store.Get(ctx, "a")
+	store.Set(ctx, "c", testEntry("c", now), []string{"t"}, time.Minute)
+
+	if entry, _ := store.Get(ctx, "b"); entry != nil {
+		t.Error("least recently used entry was kept")
+	}
+	for _, key := range []string{"a", "c"} {
+		if entry, _ := store.Get(ctx, key); entry == nil {
+			t.Errorf("%s was evicted", key)
+		}
+	}
+	if _, ok := store.tags["t"]["b"]; ok {
+		t.Error("evicted entry is still referenced by its tag")
+	}
+}
+
+func TestMemoryStoreExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(10)
+
+	store.Set(ctx, "/jobs", testEntry("list", time.Now()), []string{"jobs"}, -time.Second)
+	if entry, _ := store.Get(ctx, "/jobs"); entry != nil {
+		t.Error("expired entry was served")
+	}
+	if _, ok := store.items["/jobs"]; ok {
+		t.Error("expired entry was kept")
+	}
+}
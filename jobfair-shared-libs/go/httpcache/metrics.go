@@ -0,0 +1,28 @@
+// File: jobfair-shared-libs/go/httpcache/metrics.go
+package httpcache
+
+import (
+	"github.com/jobfair/shared/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Results of looking up a response
+const (
+	resultHit    = "hit"    // Served from the store
+	resultMiss   = "miss"   // Computed and stored
+	resultBypass = "bypass" // Computed and not stored: personalized, not a 200 or skipped by the handler
+	resultError  = "error"  // The store failed; computed and not stored
+)
+
+var cacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Name:      "http_cache_requests_total",
+	Help:      "Requests to cached routes, by route and result (hit, miss, bypass, error).",
+}, []string{"route", "result"})
+
+var notModified = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Name:      "http_cache_not_modified_total",
+	Help:      "Conditional requests to cached routes answered with 304 Not Modified, by route.",
+}, []string{"route"})
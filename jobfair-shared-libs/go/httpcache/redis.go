@@ -0,0 +1,169 @@
+// File: jobfair-shared-libs/go/httpcache/redis.go
+package httpcache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix namespaces cached responses in Redis
+const redisKeyPrefix = "httpcache:"
+
+// setScript stores an entry unless one of its tags was invalidated since its
+// request started, and adds it to the set of each tag. Tag sets live as long
+// as their newest entry.
+//
+// KEYS[1] entry, KEYS[2..n+1] tag sets, KEYS[n+2..2n+1] tag invalidation times
+// ARGV[1] entry JSON, ARGV[2] ttl (ms), ARGV[3] request start (ms)
+// Returns 1 when stored
+var setScript = redis.NewScript(`
+local n = (#KEYS - 1) / 2
+local started = tonumber(ARGV[3])
+for i = 1, n do
+  local invalidated = tonumber(redis.call('GET', KEYS[n + 1 + i]))
+  if invalidated ~= nil and invalidated >= started then
+    return 0
+  end
+end
+
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+for i = 1, n do
+  redis.call('SADD', KEYS[1 + i], KEYS[1])
+  if redis.call('PTTL', KEYS[1 + i]) < tonumber(ARGV[2]) then
+    redis.call('PEXPIRE', KEYS[1 + i], ARGV[2])
+  end
+end
+return 1
+`)
+
+// invalidateScript drops the entries of each tag set and records when the
+// tag was invalidated.
+//
+// KEYS[1..n] tag sets, KEYS[n+1..2n] tag invalidation times
+// ARGV[1] now (ms), ARGV[2] how long the time is kept (ms)
+var invalidateScript = redis.NewScript(`
+local n = #KEYS / 2
+for i = 1, n do
+  local entries = redis.call('SMEMBERS', KEYS[i])
+  for _, entry in ipairs(entries) do
+    redis.call('DEL', entry)
+  end
+  redis.call('DEL', KEYS[i])
+  redis.call('SET', KEYS[n + i], ARGV[1], 'PX', ARGV[2])
+end
+return n
+`)
+
+// RedisStore keeps entries in Redis so every replica of a service shares
+// them and sees the invalidations of the others
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisStore creates a store using client; namespace separates the
+// entries of different services
+func NewRedisStore(client redis.UniversalClient, namespace string) *RedisStore {
+	return &RedisStore{client: client, prefix: redisKeyPrefix + namespace + ":"}
+}
+
+// NewRedisStoreFromURL connects to the Redis server at url
+// (redis://[:password@]host:port/db) and checks it responds
+func NewRedisStoreFromURL(ctx context.Context, url, namespace string) (*RedisStore, error) {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("parse redis url: %w", err)
+	}
+	// A slow Redis should cost a cache miss, not a slow response, unless the
+	// URL sets its own timeouts
+	if options.DialTimeout == 0 {
+		options.DialTimeout = time.Second
+	}
+	if options.ReadTimeout == 0 {
+		options.ReadTimeout = 250 * time.Millisecond
+	}
+	if options.WriteTimeout == 0 {
+		options.WriteTimeout = 250 * time.Millisecond
+	}
+	client := redis.NewClient(options)
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("connect to redis: %w", err)
+	}
+	return NewRedisStore(client, namespace), nil
+}
+
+// Get returns the entry under key, or nil when there is none
+func (s *RedisStore) Get(ctx context.Context, key string) (*Entry, error) {
+	data, err := s.client.Get(ctx, s.entryKey(key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("decode cached response: %w", err)
+	}
+	return &entry, nil
+}
+
+// Set stores the entry under key for ttl, unless one of its tags was
+// invalidated since the entry's request started
+func (s *RedisStore) Set(ctx context.Context, key string, entry *Entry, tags []string, ttl time.Duration) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, 1+2*len(tags))
+	keys = append(keys, s.entryKey(key))
+	for _, tag := range tags {
+		keys = append(keys, s.tagKey(tag))
+	}
+	for _, tag := range tags {
+		keys = append(keys, s.invalidatedKey(tag))
+	}
+	return setScript.Run(ctx, s.client, keys, data, ttl.Milliseconds(), entry.StoredAt.UnixMilli()).Err()
+}
+
+// Invalidate drops the entries carrying any of the tags
+func (s *RedisStore) Invalidate(ctx context.Context, tags ...string) error {
+	keys := make([]string, 0, 2*len(tags))
+	for _, tag := range tags {
+		keys = append(keys, s.tagKey(tag))
+	}
+	for _, tag := range tags {
+		keys = append(keys, s.invalidatedKey(tag))
+	}
+	return invalidateScript.Run(ctx, s.client, keys, time.Now().UnixMilli(), invalidationWindow.Milliseconds()).Err()
+}
+
+func (s *RedisStore) entryKey(key string) string {
+	return s.prefix + "entry:" + key
+}
+
+func (s *RedisStore) tagKey(tag string) string {
+	return s.prefix + "tag:" + tag
+}
+
+func (s *RedisStore) invalidatedKey(tag string) string {
+	return s.prefix + "invalidated:" + tag
+}
+
+// Ping checks that Redis responds
+func (s *RedisStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
+
+// Close closes the Redis connection
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,40 @@
+// File: jobfair-shared-libs/go/httpcache/store.go
+package httpcache
+
+import (
+	"context"
+	"time"
+)
+
+// invalidationWindow is how long stores remember that a tag was invalidated,
+// so responses computed before the invalidation are not stored after it.
+// Requests taking longer than this may store stale data until their TTL.
+const invalidationWindow = 10 * time.Minute
+
+// Entry is a cached 200 response
+type Entry struct {
+	Body        []byte    `json:"body"`
+	ContentType string    `json:"content_type"`
+	ETag        string    `json:"etag"`
+	StoredAt    time.Time `json:"stored_at"` // When the request that produced it started; its Last-Modified
+}
+
+// Store keeps cached responses with the tags they can be invalidated by
+type Store interface {
+	// Get returns the entry under key, or nil when there is none
+	Get(ctx context.Context, key string) (*Entry, error)
+
+	// Set stores the entry under key for ttl. It is not stored when one of
+	// its tags was invalidated after entry.StoredAt, as it may show data
+	// from before the change.
+	Set(ctx context.Context, key string, entry *Entry, tags []string, ttl time.Duration) error
+
+	// Invalidate drops the entries carrying any of the tags
+	Invalidate(ctx context.Context, tags ...string) error
+
+	// Ping checks that the store responds
+	Ping(ctx context.Context) error
+
+	// Close releases the store's connections
+	Close() error
+}
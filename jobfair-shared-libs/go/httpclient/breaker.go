@@ -0,0 +1,103 @@
+// File: jobfair-shared-libs/go/httpclient/breaker.go
+package httpclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the upstream while its circuit
+// breaker is open
+var ErrCircuitOpen = errors.New("httpclient: circuit breaker is open")
+
+// State is a circuit breaker state
+type State string
+
+const (
+	StateClosed   State = "closed"    // Requests flow normally
+	StateOpen     State = "open"      // Requests fail fast until the cooldown ends
+	StateHalfOpen State = "half_open" // One trial request decides whether to close again
+)
+
+// Breaker opens after Threshold consecutive failures and fails requests fast
+// for Cooldown. Then a single trial request is let through: success closes the
+// breaker, failure opens it for another cooldown.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	trial    bool // A half-open trial request is in flight
+}
+
+// NewBreaker creates a closed breaker
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{threshold: threshold, cooldown: cooldown, state: StateClosed}
+}
+
+// Allow reports whether a request may be sent, returning ErrCircuitOpen when
+// not. Every allowed request must be followed by Success or Failure.
+func (b *Breaker) Allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if now.Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = StateHalfOpen
+		b.trial = true
+		return nil
+	case StateHalfOpen:
+		if b.trial {
+			return ErrCircuitOpen
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// Success records a request the upstream handled
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = StateClosed
+	b.failures = 0
+	b.trial = false
+}
+
+// Failure records a request the upstream failed
+func (b *Breaker) Failure(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.threshold {
+		b.state = StateOpen
+		b.openedAt = now
+	}
+	b.trial = false
+}
+
+// Release gives back an allowed request that neither succeeded nor failed,
+// such as one cancelled by its caller
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
+
+// State returns the breaker's current state
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
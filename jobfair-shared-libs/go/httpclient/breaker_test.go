@@ -0,0 +1,108 @@
+package httpclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	b := NewBreaker(3, time.Minute)
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		if err := b.Allow(now); err != nil {
+			t.Fatalf("Allow before the threshold: %v", err)
+		}
+		b.Failure(now)
+	}
+	if b.State() != StateClosed {
+		t.Fatalf("state after 2 failures = %s, want closed", b.State())
+	}
+
+	b.Allow(now)
+	b.Failure(now)
+	if b.State() != StateOpen {
+		t.Fatalf("state after 3 failures = %s, want open", b.State())
+	}
+	if err := b.Allow(now.Add(30 * time.Second)); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Allow during the cooldown: err = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestBreakerSuccessResetsFailures(t *testing.T) {
+	b := NewBreaker(2, time.Minute)
+	now := time.Now()
+
+	b.Allow(now)
+	b.Failure(now)
+	b.Allow(now)
+	b.Success()
+	b.Allow(now)
+	b.Failure(now)
+
+	if b.State() != StateClosed {
+		t.Errorf("state = %s, want closed: failures must be consecutive", b.State())
+	}
+}
+
+func TestBreakerHalfOpenTrial(t *testing.T) {
+	now := time.Now()
+	open := func() *Breaker {
+		b := NewBreaker(1, time.Minute)
+		b.Allow(now)
+		b.Failure(now)
+		return b
+	}
+	afterCooldown := now.Add(time.Minute)
+
+	t.Run("SuccessCloses", func(t *testing.T) {
+		b := open()
+		if err := b.Allow(afterCooldown); err != nil {
+			t.Fatalf("Allow after the cooldown: %v", err)
+		}
+		if b.State() != StateHalfOpen {
+			t.Fatalf("state = %s, want half_open", b.State())
+		}
+		if err := b.Allow(afterCooldown); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("second request during the trial: err = %v, want ErrCircuitOpen", err)
+		}
+
+		b.Success()
+		if b.State() != StateClosed {
+			t.Errorf("state after a successful trial = %s, want closed", b.State())
+		}
+		if err := b.Allow(afterCooldown); err != nil {
+			t.Errorf("Allow after closing: %v", err)
+		}
+	})
+
+	t.Run("FailureReopens", func(t *testing.T) {
+		b := open()
+		b.Allow(afterCooldown)
+		b.Failure(afterCooldown)
+
+		if b.State() != StateOpen {
+			t.Fatalf("state after a failed trial = %s, want open", b.State())
+		}
+		if err := b.Allow(afterCooldown.Add(30 * time.Second)); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("Allow during the new cooldown: err = %v, want ErrCircuitOpen", err)
+		}
+		if err := b.Allow(afterCooldown.Add(time.Minute)); err != nil {
+			t.Errorf("Allow after the new cooldown: %v", err)
+		}
+	})
+
+	t.Run("ReleaseFreesTrial", func(t *testing.T) {
+		b := open()
+		b.Allow(afterCooldown)
+		b.Release()
+
+		if err := b.Allow(afterCooldown); err != nil {
+			t.Errorf("Allow after a released trial: %v", err)
+		}
+		if b.State() != StateHalfOpen {
+			t.Errorf("state = %s, want half_open", b.State())
+		}
+	})
+}
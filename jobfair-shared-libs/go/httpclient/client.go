@@ -0,0 +1,278 @@
+// File: jobfair-shared-libs/go/httpclient/client.go
+package httpclient
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jobfair/shared/metrics"
+	"github.com/jobfair/shared/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Config controls timeouts, retries, circuit breakers and connection pooling
+type Config struct {
+	Timeout          time.Duration // Per attempt; 0 leaves it to the request context
+	TotalTimeout     time.Duration // Whole call including retries, for clients made by New; 0 for none
+	MaxRetries       int           // Extra attempts for idempotent requests
+	RetryBaseDelay   time.Duration // Backoff before the first retry, doubled per retry
+	RetryMaxDelay    time.Duration // Backoff cap
+	BreakerThreshold int           // Consecutive failures that open an upstream's breaker
+	BreakerCooldown  time.Duration // How long an open breaker fails fast
+	MaxIdleConns     int           // Idle connections kept per upstream
+	IdleConnTimeout  time.Duration
+}
+
+// DefaultConfig returns settings suited to calls between services
+func DefaultConfig() Config {
+	return Config{
+		Timeout:          2 * time.Second,
+		TotalTimeout:     5 * time.Second,
+		MaxRetries:       2,
+		RetryBaseDelay:   100 * time.Millisecond,
+		RetryMaxDelay:    time.Second,
+		BreakerThreshold: 5,
+		BreakerCooldown:  30 * time.Second,
+		MaxIdleConns:     32,
+		IdleConnTimeout:  90 * time.Second,
+	}
+}
+
+// ConfigFromEnv reads HTTP_CLIENT_TIMEOUT, HTTP_CLIENT_TOTAL_TIMEOUT,
+// HTTP_CLIENT_MAX_RETRIES, HTTP_CLIENT_BREAKER_THRESHOLD and
+// HTTP_CLIENT_BREAKER_COOLDOWN over the defaults. Invalid values keep the
+// default.
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig()
+	cfg.Timeout = envDuration("HTTP_CLIENT_TIMEOUT", cfg.Timeout)
+	cfg.TotalTimeout = envDuration("HTTP_CLIENT_TOTAL_TIMEOUT", cfg.TotalTimeout)
+	cfg.MaxRetries = envInt("HTTP_CLIENT_MAX_RETRIES", cfg.MaxRetries)
+	cfg.BreakerThreshold = envInt("HTTP_CLIENT_BREAKER_THRESHOLD", cfg.BreakerThreshold)
+	cfg.BreakerCooldown = envDuration("HTTP_CLIENT_BREAKER_COOLDOWN", cfg.BreakerCooldown)
+	return cfg
+}
+
+// Transport is an http.RoundTripper that adds per-attempt timeouts, retries
+// with jittered backoff and a circuit breaker per upstream host on top of a
+// pooled transport. Connection errors, timeouts and 502, 503 and 504
+// responses count as upstream failures; such requests are retried when their
+// method is idempotent and their body can be replayed.
+type Transport struct {
+	cfg  Config
+	base http.RoundTripper
+
+	breakers sync.Map // host -> *Breaker
+}
+
+// NewTransport creates a transport with its own connection pool
+func NewTransport(cfg Config) *Transport {
+	if cfg.BreakerThreshold < 1 {
+		cfg.BreakerThreshold = 1
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.MaxIdleConns = 0 // Limited per host instead
+	if cfg.MaxIdleConns > 0 {
+		base.MaxIdleConnsPerHost = cfg.MaxIdleConns
+	}
+	if cfg.IdleConnTimeout > 0 {
+		base.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+
+	return &Transport{cfg: cfg, base: base}
+}
+
+// New creates an HTTP client using a new Transport. Requests made with a
+// context are traced and carry its request ID.
+func New(cfg Config) *http.Client {
+	return &http.Client{Transport: tracing.Transport(NewTransport(cfg)), Timeout: cfg.TotalTimeout}
+}
+
+// RoundTrip sends the request through the upstream's breaker, retrying it
+// when allowed
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	breaker := t.Breaker(req.URL.Host)
+	retryable := isIdempotent(req.Method) && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+
+	for attempt := 0; ; attempt++ {
+		if err := breaker.Allow(time.Now()); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.attempt(req, attempt)
+		switch {
+		case err != nil && req.Context().Err() == context.Canceled:
+			// The caller gave up; that says nothing about the upstream
+			breaker.Release()
+			return nil, err
+		case isFailure(resp, err):
+			breaker.Failure(time.Now())
+		default:
+			breaker.Success()
+			return resp, nil
+		}
+
+		if !retryable || attempt >= t.cfg.MaxRetries {
+			return resp, err
+		}
+		if resp != nil {
+			// Drain so the connection goes back to the pool
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+		if err := t.wait(req.Context(), attempt); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt sends one try of the request, bounded by the per-attempt timeout
+func (t *Transport) attempt(req *http.Request, n int) (*http.Response, error) {
+	if n > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+	if t.cfg.Timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.cfg.Timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The timeout also covers reading the body; closing it releases the context
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// wait sleeps before retry n+1: a random delay up to the exponential backoff
+func (t *Transport) wait(ctx context.Context, n int) error {
+	backoff := t.cfg.RetryBaseDelay << n
+	if backoff <= 0 || (t.cfg.RetryMaxDelay > 0 && backoff > t.cfg.RetryMaxDelay) {
+		backoff = t.cfg.RetryMaxDelay
+	}
+	if backoff <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(rand.N(backoff) + 1)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Breaker returns the circuit breaker of an upstream host
+func (t *Transport) Breaker(host string) *Breaker {
+	if breaker, ok := t.breakers.Load(host); ok {
+		return breaker.(*Breaker)
+	}
+	breaker, _ := t.breakers.LoadOrStore(host, NewBreaker(t.cfg.BreakerThreshold, t.cfg.BreakerCooldown))
+	return breaker.(*Breaker)
+}
+
+// States returns the breaker state of every upstream host called so far
+func (t *Transport) States() map[string]State {
+	states := make(map[string]State)
+	t.breakers.Range(func(host, breaker any) bool {
+		states[host.(string)] = breaker.(*Breaker).State()
+		return true
+	})
+	return states
+}
+
+// CloseIdleConnections closes pooled connections that are not in use
+func (t *Transport) CloseIdleConnections() {
+	if base, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		base.CloseIdleConnections()
+	}
+}
+
+var circuitStateDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(metrics.Namespace, "http_client", "circuit_state"),
+	"Circuit breaker state per upstream host: 1 for the current state, 0 for the others.",
+	[]string{"upstream", "state"}, nil,
+)
+
+// Describe implements prometheus.Collector
+func (t *Transport) Describe(ch chan<- *prometheus.Desc) {
+	ch <- circuitStateDesc
+}
+
+// Collect implements prometheus.Collector, exporting the breaker state of
+// every upstream host called so far
+func (t *Transport) Collect(ch chan<- prometheus.Metric) {
+	for host, current := range t.States() {
+		for _, state := range []State{StateClosed, StateOpen, StateHalfOpen} {
+			value := 0.0
+			if state == current {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(circuitStateDesc, prometheus.GaugeValue, value, host, string(state))
+		}
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isFailure reports whether an attempt failed because of the upstream, so it
+// may succeed when repeated. Other error responses are the upstream's answer.
+func isFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d >= 0 {
+		return d
+	}
+	return fallback
+}
+
+func envInt(key string, fallback int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n >= 0 {
+		return n
+	}
+	return fallback
+}
@@ -0,0 +1,266 @@
+// File: jobfair-shared-libs/go/identity/identity.go
+package identity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers set by the API gateway on requests it authenticated
+const (
+	HeaderUserID    = "X-User-ID"
+	HeaderUserType  = "X-User-Type"
+	HeaderTimestamp = "X-Identity-Timestamp"
+	HeaderSignature = "X-Identity-Signature"
+)
+
+// HeaderSkipAnalytics marks internal lookups (e.g. a service enriching its
+// responses) that must not be counted as views. Like identities, it is
+// trusted only when signed with the shared secret or sent from a trusted
+// network.
+const HeaderSkipAnalytics = "X-Skip-Analytics"
+
+// HeaderService names the service making an internal call, e.g. to
+// endpoints that only other services may use. It is trusted only when signed
+// with the shared secret or sent from a trusted network.
+const HeaderService = "X-Internal-Service"
+
+// DefaultMaxAge is how long a signed identity is accepted after signing
+const DefaultMaxAge = 2 * time.Minute
+
+var (
+	// ErrNoIdentity is returned for requests without identity headers
+	ErrNoIdentity = errors.New("identity: no identity headers")
+	// ErrSignatureInvalid is returned for missing, forged or malformed identities
+	ErrSignatureInvalid = errors.New("identity: invalid signature")
+	// ErrSignatureExpired is returned for identities signed too long ago
+	ErrSignatureExpired = errors.New("identity: signature has expired")
+	// ErrUntrustedPeer is returned for unsigned identities from outside the trusted networks
+	ErrUntrustedPeer = errors.New("identity: request does not come from a trusted network")
+)
+
+// Identity is the authenticated user of a request
+type Identity struct {
+	UserID   uint
+	UserType string
+}
+
+// Config controls which identity headers a service trusts. Signed identities
+// are required when Secret is set; otherwise unsigned headers are trusted
+// only from TrustedNetworks. With neither, identity headers are ignored.
+type Config struct {
+	Secret          string       // HMAC secret shared with the gateway
+	TrustedNetworks []*net.IPNet // Peers allowed to send unsigned identity headers
+	MaxAge          time.Duration
+}
+
+// ConfigFromEnv reads GATEWAY_IDENTITY_SECRET, GATEWAY_TRUSTED_NETWORKS
+// (comma-separated CIDRs) and GATEWAY_IDENTITY_MAX_AGE. Invalid CIDRs are
+// skipped.
+func ConfigFromEnv() Config {
+	cfg := Config{
+		Secret: os.Getenv("GATEWAY_IDENTITY_SECRET"),
+		MaxAge: DefaultMaxAge,
+	}
+	if value := os.Getenv("GATEWAY_IDENTITY_MAX_AGE"); value != "" {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			cfg.MaxAge = d
+		}
+	}
+	for _, cidr := range strings.Split(os.Getenv("GATEWAY_TRUSTED_NETWORKS"), ",") {
+		if _, network, err := net.ParseCIDR(strings.TrimSpace(cidr)); err == nil {
+			cfg.TrustedNetworks = append(cfg.TrustedNetworks, network)
+		}
+	}
+	return cfg
+}
+
+// Enabled reports whether identity headers are trusted at all
+func (c Config) Enabled() bool {
+	return c.Secret != "" || len(c.TrustedNetworks) > 0
+}
+
+// Strip removes identity headers, the skip-analytics marker and the
+// internal service name, so clients can't pass their own
+func Strip(h http.Header) {
+	h.Del(HeaderUserID)
+	h.Del(HeaderUserType)
+	h.Del(HeaderTimestamp)
+	h.Del(HeaderSignature)
+	h.Del(HeaderSkipAnalytics)
+	h.Del(HeaderService)
+}
+
+// Set writes the identity headers for r. With a secret the identity is
+// signed together with the request method and path, so it can't be replayed
+// on another endpoint.
+func Set(r *http.Request, secret string, id Identity, now time.Time) {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	userID := strconv.FormatUint(uint64(id.UserID), 10)
+
+	r.Header.Set(HeaderUserID, userID)
+	r.Header.Set(HeaderUserType, id.UserType)
+	r.Header.Set(HeaderTimestamp, timestamp)
+	if secret != "" {
+		r.Header.Set(HeaderSignature, signature(secret, r.Method, r.URL.Path, userID, id.UserType, timestamp))
+	} else {
+		r.Header.Del(HeaderSignature)
+	}
+}
+
+// Verify returns the identity of r if it is trusted under cfg. It returns
+// ErrNoIdentity when r carries no identity headers.
+func Verify(r *http.Request, cfg Config, now time.Time) (*Identity, error) {
+	userID := r.Header.Get(HeaderUserID)
+	if userID == "" {
+		return nil, ErrNoIdentity
+	}
+	userType := r.Header.Get(HeaderUserType)
+
+	if cfg.Secret != "" {
+		timestamp := r.Header.Get(HeaderTimestamp)
+		expected := signature(cfg.Secret, r.Method, r.URL.Path, userID, userType, timestamp)
+		if !hmac.Equal([]byte(r.Header.Get(HeaderSignature)), []byte(expected)) {
+			return nil, ErrSignatureInvalid
+		}
+
+		if err := cfg.checkAge(timestamp, now); err != nil {
+			return nil, err
+		}
+	} else if !cfg.trusts(r.RemoteAddr) {
+		return nil, ErrUntrustedPeer
+	}
+
+	id, err := strconv.ParseUint(userID, 10, 32)
+	if err != nil || id == 0 {
+		return nil, ErrSignatureInvalid
+	}
+	return &Identity{UserID: uint(id), UserType: userType}, nil
+}
+
+// SetSkipAnalytics marks r as an internal lookup that is not a view. With a
+// secret the marker is signed together with the request method and path.
+func SetSkipAnalytics(r *http.Request, secret string, now time.Time) {
+	if secret == "" {
+		r.Header.Set(HeaderSkipAnalytics, "true")
+		return
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	r.Header.Set(HeaderSkipAnalytics, timestamp+":"+sign(secret, "skip-analytics", r.Method, r.URL.Path, timestamp))
+}
+
+// SkipAnalytics reports whether r carries a skip-analytics marker trusted
+// under cfg: signed with cfg.Secret, or from cfg.TrustedNetworks when no
+// secret is set. Markers from anyone else are ignored.
+func SkipAnalytics(r *http.Request, cfg Config, now time.Time) bool {
+	value := r.Header.Get(HeaderSkipAnalytics)
+	if value == "" {
+		return false
+	}
+	if cfg.Secret == "" {
+		return cfg.trusts(r.RemoteAddr)
+	}
+
+	timestamp, mac, ok := strings.Cut(value, ":")
+	if !ok || !hmac.Equal([]byte(mac), []byte(sign(cfg.Secret, "skip-analytics", r.Method, r.URL.Path, timestamp))) {
+		return false
+	}
+	return cfg.checkAge(timestamp, now) == nil
+}
+
+// SetService marks r as an internal call by service. With a secret the
+// service name is signed together with the request method and path.
+func SetService(r *http.Request, secret, service string, now time.Time) {
+	if secret == "" {
+		r.Header.Set(HeaderService, service)
+		return
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	r.Header.Set(HeaderService, service+":"+timestamp+":"+sign(secret, "service", r.Method, r.URL.Path, service, timestamp))
+}
+
+// VerifyService returns the name of the service that made r if it is trusted
+// under cfg: signed with cfg.Secret, or from cfg.TrustedNetworks when no
+// secret is set. It returns ErrNoIdentity when r carries no service name.
+func VerifyService(r *http.Request, cfg Config, now time.Time) (string, error) {
+	value := r.Header.Get(HeaderService)
+	if value == "" {
+		return "", ErrNoIdentity
+	}
+	if cfg.Secret == "" {
+		if !cfg.trusts(r.RemoteAddr) {
+			return "", ErrUntrustedPeer
+		}
+		return value, nil
+	}
+
+	parts := strings.SplitN(value, ":", 3)
+	if len(parts) != 3 || parts[0] == "" {
+		return "", ErrSignatureInvalid
+	}
+	service, timestamp, mac := parts[0], parts[1], parts[2]
+	if !hmac.Equal([]byte(mac), []byte(sign(cfg.Secret, "service", r.Method, r.URL.Path, service, timestamp))) {
+		return "", ErrSignatureInvalid
+	}
+	if err := cfg.checkAge(timestamp, now); err != nil {
+		return "", err
+	}
+	return service, nil
+}
+
+// checkAge returns an error unless the Unix timestamp is within cfg.MaxAge of now
+func (c Config) checkAge(timestamp string, now time.Time) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+	maxAge := c.MaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > maxAge || age < -maxAge {
+		return ErrSignatureExpired
+	}
+	return nil
+}
+
+func (c Config) trusts(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range c.TrustedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func signature(secret, method, path, userID, userType, timestamp string) string {
+	return sign(secret, method, path, userID, userType, timestamp)
+}
+
+// sign returns the HMAC of the NUL-separated parts
+func sign(secret string, parts ...string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	for i, part := range parts {
+		if i > 0 {
+			mac.Write([]byte{0})
+		}
+		mac.Write([]byte(part))
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
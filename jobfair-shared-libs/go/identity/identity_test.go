@@ -0,0 +1,399 @@
+package identity
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const (
+	testSecret  = "test-identity-secret"
+	gatewayAddr = "10.0.0.5:41234"
+	outsideAddr = "203.0.113.7:41234"
+)
+
+var signedAt = time.Unix(1760000000, 0)
+
+func trustedNetworks(t *testing.T) []*net.IPNet {
+	t.Helper()
+	_, network, err := net.ParseCIDR("10.0.0.0/24")
+	if err != nil {
+		t.Fatalf("parse CIDR: %v", err)
+	}
+	return []*net.IPNet{network}
+}
+
+func newRequest(method, path, remoteAddr string) *http.Request {
+	r := httptest.NewRequest(method, path, nil)
+	r.RemoteAddr = remoteAddr
+	return r
+}
+
+func TestVerify(t *testing.T) {
+	signed := Config{Secret: testSecret}
+	unsigned := Config{TrustedNetworks: trustedNetworks(t)}
+	user := Identity{UserID: 42, UserType: "company"}
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		request func() *http.Request
+		now     time.Time
+		want    *Identity
+		wantErr error
+	}{
+		{
+			name: "signed",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/jobs/my", outsideAddr)
+				Set(r, testSecret, user, signedAt)
+				return r
+			},
+			now:  signedAt.Add(time.Minute),
+			want: &user,
+		},
+		{
+			name:    "no identity headers",
+			cfg:     signed,
+			request: func() *http.Request { return newRequest(http.MethodGet, "/api/v1/jobs/my", gatewayAddr) },
+			now:     signedAt,
+			wantErr: ErrNoIdentity,
+		},
+		{
+			name: "signed with another secret",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/jobs/my", gatewayAddr)
+				Set(r, "another-secret", user, signedAt)
+				return r
+			},
+			now:     signedAt,
+			wantErr: ErrSignatureInvalid,
+		},
+		{
+			name: "user ID changed after signing",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/jobs/my", gatewayAddr)
+				Set(r, testSecret, user, signedAt)
+				r.Header.Set(HeaderUserID, "1")
+				return r
+			},
+			now:     signedAt,
+			wantErr: ErrSignatureInvalid,
+		},
+		{
+			name: "replayed on another path",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/jobs/my", gatewayAddr)
+				Set(r, testSecret, user, signedAt)
+				replayed := newRequest(http.MethodDelete, "/api/v1/jobs/7", gatewayAddr)
+				replayed.Header = r.Header
+				return replayed
+			},
+			now:     signedAt,
+			wantErr: ErrSignatureInvalid,
+		},
+		{
+			name: "unsigned headers when a secret is set",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/jobs/my", gatewayAddr)
+				Set(r, "", user, signedAt)
+				return r
+			},
+			now:     signedAt,
+			wantErr: ErrSignatureInvalid,
+		},
+		{
+			name: "expired",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/jobs/my", gatewayAddr)
+				Set(r, testSecret, user, signedAt)
+				return r
+			},
+			now:     signedAt.Add(DefaultMaxAge + time.Second),
+			wantErr: ErrSignatureExpired,
+		},
+		{
+			name: "signed too far in the future",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/jobs/my", gatewayAddr)
+				Set(r, testSecret, user, signedAt)
+				return r
+			},
+			now:     signedAt.Add(-DefaultMaxAge - time.Second),
+			wantErr: ErrSignatureExpired,
+		},
+		{
+			name: "unsigned from a trusted network",
+			cfg:  unsigned,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/jobs/my", gatewayAddr)
+				Set(r, "", user, signedAt)
+				return r
+			},
+			now:  signedAt.Add(time.Hour),
+			want: &user,
+		},
+		{
+			name: "unsigned from an untrusted peer",
+			cfg:  unsigned,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/jobs/my", outsideAddr)
+				Set(r, "", user, signedAt)
+				return r
+			},
+			now:     signedAt,
+			wantErr: ErrUntrustedPeer,
+		},
+		{
+			name: "untrusted peer without a secret or trusted networks",
+			cfg:  Config{},
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/jobs/my", gatewayAddr)
+				Set(r, "", user, signedAt)
+				return r
+			},
+			now:     signedAt,
+			wantErr: ErrUntrustedPeer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Verify(tt.request(), tt.cfg, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.want != nil && (got == nil || *got != *tt.want) {
+				t.Errorf("Verify = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipAnalytics(t *testing.T) {
+	signed := Config{Secret: testSecret}
+	unsigned := Config{TrustedNetworks: trustedNetworks(t)}
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		request func() *http.Request
+		now     time.Time
+		want    bool
+	}{
+		{
+			name: "signed",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/companies/3", outsideAddr)
+				SetSkipAnalytics(r, testSecret, signedAt)
+				return r
+			},
+			now:  signedAt,
+			want: true,
+		},
+		{
+			name:    "no marker",
+			cfg:     signed,
+			request: func() *http.Request { return newRequest(http.MethodGet, "/api/v1/companies/3", gatewayAddr) },
+			now:     signedAt,
+		},
+		{
+			name: "signed with another secret",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/companies/3", gatewayAddr)
+				SetSkipAnalytics(r, "another-secret", signedAt)
+				return r
+			},
+			now: signedAt,
+		},
+		{
+			name: "replayed on another company",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/companies/3", gatewayAddr)
+				SetSkipAnalytics(r, testSecret, signedAt)
+				replayed := newRequest(http.MethodGet, "/api/v1/companies/4", gatewayAddr)
+				replayed.Header = r.Header
+				return replayed
+			},
+			now: signedAt,
+		},
+		{
+			name: "unsigned marker when a secret is set",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/companies/3", gatewayAddr)
+				SetSkipAnalytics(r, "", signedAt)
+				return r
+			},
+			now: signedAt,
+		},
+		{
+			name: "expired",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/companies/3", gatewayAddr)
+				SetSkipAnalytics(r, testSecret, signedAt)
+				return r
+			},
+			now: signedAt.Add(DefaultMaxAge + time.Second),
+		},
+		{
+			name: "unsigned from a trusted network",
+			cfg:  unsigned,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/companies/3", gatewayAddr)
+				SetSkipAnalytics(r, "", signedAt)
+				return r
+			},
+			now:  signedAt,
+			want: true,
+		},
+		{
+			name: "unsigned from an untrusted peer",
+			cfg:  unsigned,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/companies/3", outsideAddr)
+				SetSkipAnalytics(r, "", signedAt)
+				return r
+			},
+			now: signedAt,
+		},
+	}
+	for _, tt := range tests {
+		if got := SkipAnalytics(tt.request(), tt.cfg, tt.now); got != tt.want {
+			t.Errorf("%s: SkipAnalytics = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestVerifyService(t *testing.T) {
+	signed := Config{Secret: testSecret}
+	unsigned := Config{TrustedNetworks: trustedNetworks(t)}
+	const service = "user-profile-service"
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		request func() *http.Request
+		now     time.Time
+		want    string
+		wantErr error
+	}{
+		{
+			name: "signed",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/stats/jobs", outsideAddr)
+				SetService(r, testSecret, service, signedAt)
+				return r
+			},
+			now:  signedAt,
+			want: service,
+		},
+		{
+			name:    "no service header",
+			cfg:     signed,
+			request: func() *http.Request { return newRequest(http.MethodGet, "/api/v1/stats/jobs", gatewayAddr) },
+			now:     signedAt,
+			wantErr: ErrNoIdentity,
+		},
+		{
+			name: "service name changed after signing",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/stats/jobs", gatewayAddr)
+				SetService(r, testSecret, service, signedAt)
+				r.Header.Set(HeaderService, "company-service"+r.Header.Get(HeaderService)[len(service):])
+				return r
+			},
+			now:     signedAt,
+			wantErr: ErrSignatureInvalid,
+		},
+		{
+			name: "unsigned name when a secret is set",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/stats/jobs", gatewayAddr)
+				SetService(r, "", service, signedAt)
+				return r
+			},
+			now:     signedAt,
+			wantErr: ErrSignatureInvalid,
+		},
+		{
+			name: "expired",
+			cfg:  signed,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/stats/jobs", gatewayAddr)
+				SetService(r, testSecret, service, signedAt)
+				return r
+			},
+			now:     signedAt.Add(DefaultMaxAge + time.Second),
+			wantErr: ErrSignatureExpired,
+		},
+		{
+			name: "unsigned from a trusted network",
+			cfg:  unsigned,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/stats/jobs", gatewayAddr)
+				SetService(r, "", service, signedAt)
+				return r
+			},
+			now:  signedAt,
+			want: service,
+		},
+		{
+			name: "unsigned from an untrusted peer",
+			cfg:  unsigned,
+			request: func() *http.Request {
+				r := newRequest(http.MethodGet, "/api/v1/stats/jobs", outsideAddr)
+				SetService(r, "", service, signedAt)
+				return r
+			},
+			now:     signedAt,
+			wantErr: ErrUntrustedPeer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifyService(tt.request(), tt.cfg, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyService error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("VerifyService = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrip(t *testing.T) {
+	r := newRequest(http.MethodGet, "/api/v1/jobs/my", outsideAddr)
+	Set(r, testSecret, Identity{UserID: 1, UserType: "admin"}, signedAt)
+	SetSkipAnalytics(r, testSecret, signedAt)
+	SetService(r, testSecret, "user-profile-service", signedAt)
+	r.Header.Set("Authorization", "Bearer token")
+
+	Strip(r.Header)
+
+	for _, header := range []string{HeaderUserID, HeaderUserType, HeaderTimestamp, HeaderSignature, HeaderSkipAnalytics, HeaderService} {
+		if value := r.Header.Get(header); value != "" {
+			t.Errorf("%s = %q after Strip, want it removed", header, value)
+		}
+	}
+	if r.Header.Get("Authorization") == "" {
+		t.Error("Strip removed the Authorization header")
+	}
+}
@@ -0,0 +1,64 @@
+// File: jobfair-shared-libs/go/logging/context.go
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jobfair/shared/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type userIDKey struct{}
+
+// WithUserID returns a context whose log records carry the user ID
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext returns the user ID in ctx, or ""
+func UserIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(userIDKey{}).(string)
+	return id
+}
+
+// contextHandler adds the correlation fields of a record's context
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if id := tracing.RequestIDFromContext(ctx); id != "" {
+			r.AddAttrs(slog.String("request_id", id))
+		}
+		if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+			r.AddAttrs(
+				slog.String("trace_id", span.TraceID().String()),
+				slog.String("span_id", span.SpanID().String()),
+			)
+		}
+		if id := UserIDFromContext(ctx); id != "" && !hasAttr(r, "user_id") {
+			r.AddAttrs(slog.String("user_id", id))
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// hasAttr reports whether r has a top-level attribute named key
+func hasAttr(r slog.Record, key string) bool {
+	found := false
+	r.Attrs(func(a slog.Attr) bool {
+		found = a.Key == key
+		return !found
+	})
+	return found
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,70 @@
+// File: jobfair-shared-libs/go/logging/gin.go
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jobfair/shared/metrics"
+)
+
+// quietPaths are probed often; their requests are logged at debug level
+var quietPaths = map[string]bool{
+	"/health":  true,
+	"/livez":   true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// Middleware logs every request once it is handled, replacing gin's text
+// logger: method, path, route (as labelled in metrics), status, latency,
+// client IP and the user ID set by authentication. Server errors are logged
+// at error level, client errors at warn. Use it after tracing.Middleware so
+// records carry the request and trace IDs.
+//
+//	router := gin.New()
+//	router.Use(gin.Recovery())
+//	router.Use(tracing.Middleware("job-service")...)
+//	router.Use(logging.Middleware())
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		case quietPaths[c.Request.URL.Path]:
+			level = slog.LevelDebug
+		}
+
+		route := c.FullPath()
+		if name := c.GetString(metrics.ContextKeyRoute); name != "" {
+			route = name
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", route),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		ctx := c.Request.Context()
+		if userID, ok := c.Get("user_id"); ok && UserIDFromContext(ctx) == "" {
+			attrs = append(attrs, slog.String("user_id", fmt.Sprint(userID)))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", c.Errors.String()))
+		}
+		slog.LogAttrs(ctx, level, "Request handled", attrs...)
+	}
+}
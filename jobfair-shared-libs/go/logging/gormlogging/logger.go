@@ -0,0 +1,32 @@
+// File: jobfair-shared-libs/go/logging/gormlogging/logger.go
+package gormlogging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+// SlowThreshold is the duration above which statements are logged as slow
+const SlowThreshold = 200 * time.Millisecond
+
+// New returns a GORM logger writing to the default slog logger, so call it
+// after logging.Setup. Failed statements are logged at error level and slow
+// ones at warn; with LOG_LEVEL=debug every statement is logged. Statements
+// keep their placeholders, so parameters such as OTP codes and password
+// hashes never reach the logs.
+func New() logger.Interface {
+	level := logger.Warn
+	if slog.Default().Enabled(context.Background(), slog.LevelDebug) {
+		level = logger.Info
+	}
+
+	return logger.NewSlogLogger(slog.Default(), logger.Config{
+		SlowThreshold:             SlowThreshold,
+		LogLevel:                  level,
+		ParameterizedQueries:      true,
+		IgnoreRecordNotFoundError: true,
+	})
+}
@@ -0,0 +1,73 @@
+// File: jobfair-shared-libs/go/logging/logging.go
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Output formats
+const (
+	FormatJSON = "json" // One JSON object per line, for filebeat
+	FormatText = "text" // key=value lines, for reading locally
+)
+
+// Config selects a service's log level and format
+type Config struct {
+	Service string
+	Level   slog.Level
+	Format  string
+}
+
+// ConfigFromEnv reads LOG_LEVEL (debug, info, warn or error; default info)
+// and LOG_FORMAT (json or text; default json)
+func ConfigFromEnv(service string) Config {
+	cfg := Config{Service: service, Level: slog.LevelInfo, Format: FormatJSON}
+	if level := os.Getenv("LOG_LEVEL"); level != "" {
+		var l slog.Level
+		if err := l.UnmarshalText([]byte(level)); err == nil {
+			cfg.Level = l
+		}
+	}
+	if strings.EqualFold(os.Getenv("LOG_FORMAT"), FormatText) {
+		cfg.Format = FormatText
+	}
+	return cfg
+}
+
+// Setup makes a logger writing to stdout the slog default and returns it.
+// Every record carries the service name, the request ID, trace ID, span ID
+// and user ID found in its context (use the *Context functions, e.g.
+// slog.InfoContext), and has secrets and PII redacted. Output of the standard
+// log package, e.g. from libraries, goes through the same logger at info
+// level.
+func Setup(cfg Config) *slog.Logger {
+	logger := New(os.Stdout, cfg)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// New creates a logger like Setup's writing to w
+func New(w io.Writer, cfg Config) *slog.Logger {
+	options := &slog.HandlerOptions{
+		Level:       cfg.Level,
+		ReplaceAttr: redact,
+	}
+
+	var handler slog.Handler
+	if cfg.Format == FormatText {
+		handler = slog.NewTextHandler(w, options)
+	} else {
+		handler = slog.NewJSONHandler(w, options)
+	}
+
+	return slog.New(contextHandler{handler}).With(slog.String("service", cfg.Service))
+}
+
+// Fatal logs msg at error level and exits, like log.Fatal
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
@@ -0,0 +1,76 @@
+// File: jobfair-shared-libs/go/logging/redact.go
+package logging
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// Redacted replaces the values of secret attributes
+const Redacted = "[REDACTED]"
+
+// secretKeys are attribute keys whose values are never logged
+var secretKeys = map[string]bool{
+	"authorization": true,
+	"code":          true,
+	"cookie":        true,
+	"otp":           true,
+	"otp_code":      true,
+	"pin":           true,
+}
+
+// secretKeyParts mark secret attribute keys by a part of their name, e.g.
+// new_password or refresh_token
+var secretKeyParts = []string{"password", "secret", "token", "api_key"}
+
+// phoneKeys and emailKeys are attribute keys whose values are masked
+var (
+	phoneKeys = map[string]bool{"phone": true, "phone_number": true, "mobile": true}
+	emailKeys = map[string]bool{"email": true, "email_address": true}
+)
+
+// redact is the ReplaceAttr of every handler: secrets are replaced with
+// Redacted, phone numbers and email addresses are masked
+func redact(groups []string, a slog.Attr) slog.Attr {
+	key := strings.ToLower(a.Key)
+	switch {
+	case isSecretKey(key):
+		return slog.String(a.Key, Redacted)
+	case phoneKeys[key]:
+		return slog.String(a.Key, MaskPhone(a.Value.String()))
+	case emailKeys[key]:
+		return slog.String(a.Key, MaskEmail(a.Value.String()))
+	}
+	return a
+}
+
+func isSecretKey(key string) bool {
+	if secretKeys[key] {
+		return true
+	}
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskPhone keeps the last 3 digits of a phone number:
+// "+6281234567890" becomes "***********890"
+func MaskPhone(phone string) string {
+	if len(phone) <= 3 {
+		return strings.Repeat("*", len(phone))
+	}
+	return strings.Repeat("*", len(phone)-3) + phone[len(phone)-3:]
+}
+
+// MaskEmail keeps the first character of the local part and the domain:
+// "jane@example.com" becomes "j***@example.com"
+func MaskEmail(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at < 1 {
+		return Redacted
+	}
+	return email[:1] + "***" + email[at:]
+}
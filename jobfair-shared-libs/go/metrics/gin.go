@@ -0,0 +1,75 @@
+// File: jobfair-shared-libs/go/metrics/gin.go
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ContextKeyRoute overrides the route label of a request. Handlers serving
+// many paths from one gin route, such as the gateway proxy, set it to a
+// name of bounded cardinality.
+const ContextKeyRoute = "metrics_route"
+
+// unmatchedRoute labels requests no route matched, so scanners probing
+// random paths don't create new series
+const unmatchedRoute = "unmatched"
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests handled, by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Time to handle HTTP requests, by method and route.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"method", "route"})
+
+	requestsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "HTTP requests being handled.",
+	})
+)
+
+// Middleware records the rate, errors (by status code) and duration of
+// requests per route. Routes are gin route patterns such as /api/v1/jobs/:id.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.URL.Path == "/metrics" {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		requestsInFlight.Inc()
+		defer requestsInFlight.Dec()
+
+		c.Next()
+
+		route := c.GetString(ContextKeyRoute)
+		if route == "" {
+			route = c.FullPath()
+		}
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		method := c.Request.Method
+		requestsTotal.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		requestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Register adds GET /metrics to router
+func Register(router gin.IRoutes) {
+	router.GET("/metrics", gin.WrapH(Handler()))
+}
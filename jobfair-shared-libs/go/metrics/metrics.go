@@ -0,0 +1,32 @@
+// File: jobfair-shared-libs/go/metrics/metrics.go
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes every JobFair metric
+const Namespace = "jobfair"
+
+// Handler serves the metrics of the default registry, including Go runtime
+// and process metrics, in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// MustRegister registers collectors with the default registry. It panics
+// when a metric is registered twice.
+func MustRegister(cs ...prometheus.Collector) {
+	prometheus.MustRegister(cs...)
+}
+
+// RegisterDB exports the connection pool stats of db (open, in use and idle
+// connections, waits) as go_sql_* metrics labelled with db_name
+func RegisterDB(name string, db *sql.DB) {
+	MustRegister(collectors.NewDBStatsCollector(db, name))
+}
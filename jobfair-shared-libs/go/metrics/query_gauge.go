@@ -0,0 +1,81 @@
+// File: jobfair-shared-libs/go/metrics/query_gauge.go
+package metrics
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Sample is one value of a QueryGauge with its label values
+type Sample struct {
+	Labels []string
+	Value  float64
+}
+
+// QueryFunc computes the current samples of a QueryGauge
+type QueryFunc func() ([]Sample, error)
+
+// QueryGauge is a gauge computed on scrape, typically by a database query
+// such as counting published jobs. Results are cached for a TTL so frequent
+// scrapes and several Prometheus servers don't add load; when the query
+// fails the last samples are reported.
+type QueryGauge struct {
+	name  string
+	desc  *prometheus.Desc
+	ttl   time.Duration
+	query QueryFunc
+
+	mu      sync.Mutex
+	samples []Sample
+	at      time.Time
+}
+
+// NewQueryGauge creates a gauge named jobfair_<name> with the given label
+// names; register it with MustRegister
+func NewQueryGauge(name, help string, labels []string, ttl time.Duration, query QueryFunc) *QueryGauge {
+	name = prometheus.BuildFQName(Namespace, "", name)
+	return &QueryGauge{
+		name:  name,
+		desc:  prometheus.NewDesc(name, help, labels, nil),
+		ttl:   ttl,
+		query: query,
+	}
+}
+
+// Describe implements prometheus.Collector
+func (g *QueryGauge) Describe(ch chan<- *prometheus.Desc) {
+	ch <- g.desc
+}
+
+// Collect implements prometheus.Collector
+func (g *QueryGauge) Collect(ch chan<- prometheus.Metric) {
+	for _, sample := range g.current() {
+		metric, err := prometheus.NewConstMetric(g.desc, prometheus.GaugeValue, sample.Value, sample.Labels...)
+		if err != nil {
+			slog.Warn("Invalid metric sample", "metric", g.name, "error", err)
+			continue
+		}
+		ch <- metric
+	}
+}
+
+func (g *QueryGauge) current() []Sample {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.at.IsZero() && time.Since(g.at) < g.ttl {
+		return g.samples
+	}
+
+	samples, err := g.query()
+	if err != nil {
+		slog.Warn("Failed to compute metric", "metric", g.name, "error", err)
+		return g.samples
+	}
+	g.samples = samples
+	g.at = time.Now()
+	return samples
+}
@@ -0,0 +1,82 @@
+// File: jobfair-shared-libs/go/plans/plans.go
+package plans
+
+import "sort"
+
+// Tier identifies a subscription plan
+type Tier string
+
+const (
+	TierFree    Tier = "free"
+	TierBasic   Tier = "basic"
+	TierPremium Tier = "premium"
+	TierPro     Tier = "pro"
+)
+
+// Unlimited marks a limit without a cap
+const Unlimited = -1
+
+// Limits are the quotas of a plan. A limit of Unlimited has no cap.
+type Limits struct {
+	ActiveJobSlots int `json:"active_job_slots"` // Published and scheduled jobs
+	FeaturedJobs   int `json:"featured_jobs"`    // Jobs promoted at the same time
+	ApplicantViews int `json:"applicant_views"`  // Applications viewed per month
+	TeamSeats      int `json:"team_seats"`       // Users managing the company
+}
+
+// Allows reports whether one more unit fits in limit when used are taken
+func Allows(limit, used int) bool {
+	return limit == Unlimited || used < limit
+}
+
+// Plan is an entry of the plan catalog
+type Plan struct {
+	Tier    Tier   `json:"tier"`
+	Name    string `json:"name"`
+	Rank    int    `json:"rank"` // Higher ranks are higher tiers
+	Premium bool   `json:"premium"`
+	Limits  Limits `json:"limits"`
+}
+
+var catalog = map[Tier]Plan{
+	TierFree: {
+		Tier: TierFree, Name: "Free", Rank: 0,
+		Limits: Limits{ActiveJobSlots: 2, FeaturedJobs: 0, ApplicantViews: 50, TeamSeats: 1},
+	},
+	TierBasic: {
+		Tier: TierBasic, Name: "Basic", Rank: 1,
+		Limits: Limits{ActiveJobSlots: 10, FeaturedJobs: 1, ApplicantViews: 500, TeamSeats: 3},
+	},
+	TierPremium: {
+		Tier: TierPremium, Name: "Premium", Rank: 2, Premium: true,
+		Limits: Limits{ActiveJobSlots: 30, FeaturedJobs: 5, ApplicantViews: 2000, TeamSeats: 10},
+	},
+	TierPro: {
+		Tier: TierPro, Name: "Pro", Rank: 3, Premium: true,
+		Limits: Limits{ActiveJobSlots: Unlimited, FeaturedJobs: 15, ApplicantViews: Unlimited, TeamSeats: 25},
+	},
+}
+
+// Get returns the plan of tier
+func Get(tier Tier) (Plan, bool) {
+	plan, ok := catalog[tier]
+	return plan, ok
+}
+
+// Lookup returns the plan of tier, or the free plan for unknown tiers
+func Lookup(tier Tier) Plan {
+	if plan, ok := catalog[tier]; ok {
+		return plan
+	}
+	return catalog[TierFree]
+}
+
+// All returns the catalog ordered from the lowest to the highest tier
+func All() []Plan {
+	all := make([]Plan, 0, len(catalog))
+	for _, plan := range catalog {
+		all = append(all, plan)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Rank < all[j].Rank })
+	return all
+}
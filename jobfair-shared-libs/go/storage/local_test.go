@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocalStore(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir(), "/uploads/", "test-secret")
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+
+	testStore(t, store)
+}
+
+func TestLocalStoreKeepsKeysInsideDir(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "uploads")
+	store, err := NewLocalStore(dir, "/uploads", "test-secret")
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+
+	if _, err := store.Put(context.Background(), "../escaped.txt", strings.NewReader("x"), 1, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "escaped.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("object written outside the store directory (stat err = %v)", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escaped.txt")); err != nil {
+		t.Errorf("object not written inside the store directory: %v", err)
+	}
+}
+
+func TestLocalStoreSignedURL(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir(), "/uploads", "test-secret")
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+
+	signed, err := store.SignedURL(context.Background(), "cv/1.pdf", time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("parse signed URL: %v", err)
+	}
+	if u.Path != "/uploads/cv/1.pdf" {
+		t.Errorf("signed URL path = %q, want /uploads/cv/1.pdf", u.Path)
+	}
+
+	if err := store.Signer().Verify("cv/1.pdf", u.Query()); err != nil {
+		t.Errorf("Verify of a fresh signature: %v", err)
+	}
+	if err := store.Signer().Verify("cv/2.pdf", u.Query()); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("Verify for another key: err = %v, want ErrSignatureInvalid", err)
+	}
+
+	other := NewSigner("other-secret")
+	if err := other.Verify("cv/1.pdf", u.Query()); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("Verify with another secret: err = %v, want ErrSignatureInvalid", err)
+	}
+
+	expired := store.Signer().Sign("cv/1.pdf", -time.Minute)
+	if err := store.Signer().Verify("cv/1.pdf", expired); !errors.Is(err, ErrSignatureExpired) {
+		t.Errorf("Verify of an expired signature: err = %v, want ErrSignatureExpired", err)
+	}
+}
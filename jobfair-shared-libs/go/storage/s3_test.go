@@ -0,0 +1,229 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestS3Store runs the store against an in-process S3 stand-in
+func TestS3Store(t *testing.T) {
+	fake := newFakeS3()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := NewS3Store(Config{
+		Backend:     BackendS3,
+		S3Endpoint:  strings.TrimPrefix(server.URL, "http://"),
+		S3Region:    "us-east-1",
+		S3Bucket:    "jobfair-uploads",
+		S3AccessKey: "test",
+		S3SecretKey: "test-secret",
+		S3PublicURL: "http://cdn.example.com/jobfair-uploads/",
+
+		S3PublicPrefixes: DefaultPublicPrefixes,
+	})
+	if err != nil {
+		t.Fatalf("NewS3Store: %v", err)
+	}
+	if !fake.hasBucket("jobfair-uploads") {
+		t.Fatal("NewS3Store did not create the missing bucket")
+	}
+
+	policy := fake.policy("jobfair-uploads")
+	for _, public := range []string{"companies/*", "profiles/*", "banners/*"} {
+		if !strings.Contains(policy, `"arn:aws:s3:::jobfair-uploads/`+public+`"`) {
+			t.Errorf("bucket policy %s does not allow reads of %s", policy, public)
+		}
+	}
+	for _, private := range []string{"*", "cv/", "verification/", "quarantine/"} {
+		if strings.Contains(policy, `"arn:aws:s3:::jobfair-uploads/`+private) {
+			t.Errorf("bucket policy %s allows anonymous reads of %s", policy, private)
+		}
+	}
+
+	testStore(t, store)
+
+	if got := store.URL("cv/1.pdf"); got != "http://cdn.example.com/jobfair-uploads/cv/1.pdf" {
+		t.Errorf("URL = %q, want it under S3_PUBLIC_URL", got)
+	}
+}
+
+// TestS3StoreMinIO runs the store against a real MinIO (e.g. the one in
+// docker-compose) when S3_TEST_ENDPOINT is set
+func TestS3StoreMinIO(t *testing.T) {
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT not set")
+	}
+
+	store, err := NewS3Store(Config{
+		Backend:     BackendS3,
+		S3Endpoint:  endpoint,
+		S3Region:    "us-east-1",
+		S3Bucket:    fmt.Sprintf("jobfair-test-%d", time.Now().UnixNano()),
+		S3AccessKey: os.Getenv("S3_TEST_ACCESS_KEY"),
+		S3SecretKey: os.Getenv("S3_TEST_SECRET_KEY"),
+	})
+	if err != nil {
+		t.Fatalf("NewS3Store: %v", err)
+	}
+
+	testStore(t, store)
+}
+
+// fakeS3 serves the path-style S3 requests made by S3Store: bucket
+// HEAD/PUT, bucket policy PUT and object PUT/GET/HEAD/DELETE. Signatures
+// are not checked.
+type fakeS3 struct {
+	mu       sync.Mutex
+	buckets  map[string]map[string]fakeObject
+	policies map[string]string
+}
+
+type fakeObject struct {
+	data         []byte
+	contentType  string
+	lastModified time.Time
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{buckets: make(map[string]map[string]fakeObject), policies: make(map[string]string)}
+}
+
+func (f *fakeS3) policy(bucket string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.policies[bucket]
+}
+
+func (f *fakeS3) hasBucket(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.buckets[name]
+	return ok
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	objects, ok := f.buckets[bucket]
+
+	if key == "" {
+		switch r.Method {
+		case http.MethodHead:
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case http.MethodPut:
+			if _, isPolicy := r.URL.Query()["policy"]; isPolicy {
+				if !ok {
+					writeS3Error(w, http.StatusNotFound, "NoSuchBucket", r.Method)
+					return
+				}
+				body, _ := io.ReadAll(r.Body)
+				f.policies[bucket] = string(body)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			if !ok {
+				f.buckets[bucket] = make(map[string]fakeObject)
+			}
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+		return
+	}
+
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", r.Method)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := readS3Body(r)
+		if err != nil {
+			writeS3Error(w, http.StatusBadRequest, "IncompleteBody", r.Method)
+			return
+		}
+		objects[key] = fakeObject{data: data, contentType: r.Header.Get("Content-Type"), lastModified: time.Now()}
+		w.Header().Set("ETag", `"fake"`)
+
+	case http.MethodGet, http.MethodHead:
+		object, ok := objects[key]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey", r.Method)
+			return
+		}
+		w.Header().Set("Content-Type", object.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(object.data)))
+		w.Header().Set("Last-Modified", object.lastModified.UTC().Format(http.TimeFormat))
+		w.Header().Set("ETag", `"fake"`)
+		if r.Method == http.MethodGet {
+			w.Write(object.data)
+		}
+
+	case http.MethodDelete:
+		delete(objects, key)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+// readS3Body reads an object body, decoding the aws-chunked encoding used for
+// streaming signatures
+func readS3Body(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+
+	var data []byte
+	reader := bufio.NewReader(r.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		sizeHex, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return data, nil
+		}
+
+		chunk := make([]byte, size+2) // Data and its CRLF
+		if _, err := io.ReadFull(reader, chunk); err != nil {
+			return nil, err
+		}
+		data = append(data, chunk[:size]...)
+	}
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code, method string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	if method == http.MethodHead {
+		return
+	}
+	xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+		Message string   `xml:"Message"`
+	}{Code: code, Message: code})
+}
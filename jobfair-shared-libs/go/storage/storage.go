@@ -0,0 +1,159 @@
+// File: jobfair-shared-libs/go/storage/storage.go
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when an object does not exist
+var ErrNotFound = errors.New("storage: object not found")
+
+// ErrInvalidKey is returned for empty keys or keys that escape the store root
+var ErrInvalidKey = errors.New("storage: invalid object key")
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// Store is an object store for uploaded files. Keys are slash-separated paths
+// such as "cv/12_1700000000.pdf"; every service uses the same key layout no
+// matter which backend is configured.
+type Store interface {
+	// Put stores the content under key and returns its public URL
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error)
+
+	// Get opens an object for reading. The caller must close the reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error)
+
+	// Delete removes an object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// SignedURL returns a time-limited URL for reading the object
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+
+	// URL returns the public URL of an object
+	URL(key string) string
+
+	// KeyFromURL extracts the object key from a URL returned by URL or Put.
+	// It reports false for URLs that don't belong to this store.
+	KeyFromURL(url string) (string, bool)
+}
+
+// Backend names
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// Config selects and configures a storage backend
+type Config struct {
+	Backend string // local or s3
+
+	// Local filesystem backend
+	LocalDir  string // Root directory for stored files
+	PublicURL string // URL prefix the files are served under (e.g. /uploads)
+
+	// SigningSecret signs URLs returned by SignedURL on the local backend
+	SigningSecret string
+
+	// S3-compatible backend (AWS S3, MinIO, ...)
+	S3Endpoint  string // host[:port], empty for AWS
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3UseSSL    bool
+	S3PublicURL string // Public base URL of the bucket, defaults to the endpoint URL
+
+	// S3PublicPrefixes are the key prefixes anyone may read (images shown on
+	// profiles and listings). NewS3Store sets a bucket policy allowing
+	// anonymous reads under them only, so CVs, verification documents and
+	// quarantined files stay private. Empty leaves the bucket policy alone.
+	S3PublicPrefixes []string
+}
+
+// DefaultPublicPrefixes are the key prefixes of publicly shown uploads
+var DefaultPublicPrefixes = []string{"companies/", "profiles/", "banners/"}
+
+// ConfigFromEnv builds a storage configuration from the STORAGE_* and S3_*
+// environment variables shared by all services
+func ConfigFromEnv() Config {
+	useSSL, _ := strconv.ParseBool(getEnv("S3_USE_SSL", "true"))
+
+	return Config{
+		Backend:       getEnv("STORAGE_BACKEND", BackendLocal),
+		LocalDir:      getEnv("STORAGE_LOCAL_DIR", "./uploads"),
+		PublicURL:     getEnv("STORAGE_PUBLIC_URL", "/uploads"),
+		SigningSecret: getEnv("STORAGE_SIGNING_SECRET", ""),
+		S3Endpoint:    getEnv("S3_ENDPOINT", ""),
+		S3Region:      getEnv("S3_REGION", getEnv("AWS_REGION", "")),
+		S3Bucket:      getEnv("S3_BUCKET", getEnv("AWS_BUCKET_NAME", "")),
+		S3AccessKey:   getEnv("S3_ACCESS_KEY", getEnv("AWS_ACCESS_KEY_ID", "")),
+		S3SecretKey:   getEnv("S3_SECRET_KEY", getEnv("AWS_SECRET_ACCESS_KEY", "")),
+		S3UseSSL:      useSSL,
+		S3PublicURL:   getEnv("S3_PUBLIC_URL", ""),
+
+		S3PublicPrefixes: publicPrefixesFromEnv(),
+	}
+}
+
+// publicPrefixesFromEnv reads S3_PUBLIC_PREFIXES (comma-separated), defaulting
+// to DefaultPublicPrefixes; "none" leaves the bucket policy to the operator
+func publicPrefixesFromEnv() []string {
+	value := getEnv("S3_PUBLIC_PREFIXES", strings.Join(DefaultPublicPrefixes, ","))
+	if strings.EqualFold(value, "none") {
+		return nil
+	}
+
+	var prefixes []string
+	for _, prefix := range strings.Split(value, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+// New creates the store selected by cfg.Backend
+func New(cfg Config) (Store, error) {
+	switch strings.ToLower(cfg.Backend) {
+	case "", BackendLocal:
+		return NewLocalStore(cfg.LocalDir, cfg.PublicURL, cfg.SigningSecret)
+	case BackendS3:
+		return NewS3Store(cfg)
+	}
+	return nil, fmt.Errorf("storage: unknown backend %q", cfg.Backend)
+}
+
+// Key joins path elements into an object key
+func Key(elem ...string) string {
+	return path.Join(elem...)
+}
+
+// cleanKey normalises a key and rejects keys that could escape the store root
+func cleanKey(key string) (string, error) {
+	key = strings.TrimPrefix(path.Clean("/"+strings.TrimSpace(key)), "/")
+	if key == "" || key == "." {
+		return "", ErrInvalidKey
+	}
+	return key, nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testStore runs the behaviour every Store backend must share
+func testStore(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	t.Run("PutGet", func(t *testing.T) {
+		content := "%PDF-1.4 resume"
+		url, err := store.Put(ctx, "cv/12_1700000000.pdf", strings.NewReader(content), int64(len(content)), "application/pdf")
+		if err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+		if url != store.URL("cv/12_1700000000.pdf") {
+			t.Errorf("Put returned %q, want URL %q", url, store.URL("cv/12_1700000000.pdf"))
+		}
+
+		r, info, err := store.Get(ctx, "cv/12_1700000000.pdf")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		defer r.Close()
+
+		data, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("read object: %v", err)
+		}
+		if string(data) != content {
+			t.Errorf("content = %q, want %q", data, content)
+		}
+		if info.Key != "cv/12_1700000000.pdf" || info.Size != int64(len(content)) {
+			t.Errorf("info = %+v, want key cv/12_1700000000.pdf and size %d", info, len(content))
+		}
+		if info.ContentType != "application/pdf" {
+			t.Errorf("content type = %q, want application/pdf", info.ContentType)
+		}
+	})
+
+	t.Run("PutReplaces", func(t *testing.T) {
+		for _, content := range []string{"first", "second version"} {
+			if _, err := store.Put(ctx, "logos/1.png", strings.NewReader(content), int64(len(content)), "image/png"); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+		}
+		if got := readObject(t, store, "logos/1.png"); got != "second version" {
+			t.Errorf("content = %q, want the second version", got)
+		}
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		if _, _, err := store.Get(ctx, "cv/missing.pdf"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get of a missing key: err = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if _, err := store.Put(ctx, "banners/2.jpg", strings.NewReader("jpg"), 3, "image/jpeg"); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+		if err := store.Delete(ctx, "banners/2.jpg"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, _, err := store.Get(ctx, "banners/2.jpg"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get after Delete: err = %v, want ErrNotFound", err)
+		}
+		if err := store.Delete(ctx, "banners/2.jpg"); err != nil {
+			t.Errorf("Delete of a missing key: %v", err)
+		}
+	})
+
+	t.Run("KeyFromURL", func(t *testing.T) {
+		key, ok := store.KeyFromURL(store.URL("companies/gallery/3_gallery_1.webp"))
+		if !ok || key != "companies/gallery/3_gallery_1.webp" {
+			t.Errorf("KeyFromURL(URL(key)) = %q, %v", key, ok)
+		}
+		if _, ok := store.KeyFromURL("https://elsewhere.example.com/cv/1.pdf"); ok {
+			t.Error("KeyFromURL accepted a URL of another host")
+		}
+	})
+
+	t.Run("SignedURL", func(t *testing.T) {
+		url, err := store.SignedURL(ctx, "cv/12_1700000000.pdf", time.Minute)
+		if err != nil {
+			t.Fatalf("SignedURL: %v", err)
+		}
+		if !strings.Contains(url, "12_1700000000.pdf?") {
+			t.Errorf("SignedURL = %q, want the object URL with a signature query", url)
+		}
+	})
+
+	t.Run("InvalidKeys", func(t *testing.T) {
+		for _, key := range []string{"", "  ", "/", "."} {
+			if _, err := store.Put(ctx, key, strings.NewReader("x"), 1, "text/plain"); !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("Put(%q): err = %v, want ErrInvalidKey", key, err)
+			}
+		}
+	})
+}
+
+func readObject(t *testing.T, store Store, key string) string {
+	t.Helper()
+
+	r, _, err := store.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get(%q): %v", key, err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read %q: %v", key, err)
+	}
+	return string(data)
+}
+
+func TestCleanKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"cv/1.pdf", "cv/1.pdf"},
+		{"/cv/1.pdf", "cv/1.pdf"},
+		{" cv//1.pdf ", "cv/1.pdf"},
+		{"../../etc/passwd", "etc/passwd"},
+		{"cv/../../secret", "secret"},
+	}
+	for _, tt := range tests {
+		got, err := cleanKey(tt.key)
+		if err != nil || got != tt.want {
+			t.Errorf("cleanKey(%q) = %q, %v; want %q", tt.key, got, err, tt.want)
+		}
+	}
+}
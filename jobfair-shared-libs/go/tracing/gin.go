@@ -0,0 +1,57 @@
+// File: jobfair-shared-libs/go/tracing/gin.go
+package tracing
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ContextKeyRequestID is the gin context key holding the request ID
+const ContextKeyRequestID = "request_id"
+
+// untracedPaths are probed too often to be worth a span
+var untracedPaths = map[string]bool{
+	"/health": true,
+	"/livez":  true,
+	"/readyz": true,
+}
+
+// Middleware returns the handlers that give every request a request ID and a
+// server span. A valid X-Request-ID from the caller is kept, otherwise a new
+// one is generated; either way it is echoed in the response, stored in the
+// gin context and in the request context for outbound calls. The span
+// continues the caller's traceparent when there is one.
+//
+//	router.Use(tracing.Middleware("job-service")...)
+func Middleware(service string) gin.HandlersChain {
+	return gin.HandlersChain{
+		requestID,
+		otelgin.Middleware(service, otelgin.WithGinFilter(func(c *gin.Context) bool {
+			return !untracedPaths[c.Request.URL.Path]
+		})),
+		tagSpan,
+	}
+}
+
+func requestID(c *gin.Context) {
+	id := c.GetHeader(HeaderRequestID)
+	if !ValidRequestID(id) {
+		id = NewRequestID()
+		c.Request.Header.Set(HeaderRequestID, id)
+	}
+
+	c.Header(HeaderRequestID, id)
+	c.Set(ContextKeyRequestID, id)
+	c.Request = c.Request.WithContext(WithRequestID(c.Request.Context(), id))
+	c.Next()
+}
+
+func tagSpan(c *gin.Context) {
+	span := trace.SpanFromContext(c.Request.Context())
+	if span.IsRecording() {
+		span.SetAttributes(attribute.String("request.id", RequestIDFromContext(c.Request.Context())))
+	}
+	c.Next()
+}
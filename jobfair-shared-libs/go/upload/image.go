@@ -0,0 +1,151 @@
+// File: jobfair-shared-libs/go/upload/image.go
+package upload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	_ "image/gif" // register the GIF decoder
+	"image/jpeg"
+	"image/png"
+
+	"github.com/HugoSmits86/nativewebp"
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // register the WebP decoder
+)
+
+// maxImagePixels rejects images whose decoded size would exhaust memory
+// (decompression bombs); 40 megapixels covers any real photo
+const maxImagePixels = 40_000_000
+
+const jpegQuality = 88
+
+// decodeImage decodes an uploaded image after checking its dimensions and
+// applies the EXIF orientation of JPEGs
+func decodeImage(data []byte, contentType string) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxImagePixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels is too large", ErrInvalidImage, cfg.Width, cfg.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	if contentType == TypeJPEG {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+	return img, nil
+}
+
+// stripMetadata returns the image without EXIF/XMP metadata. JPEG and PNG are
+// re-encoded from the decoded (upright) image; WebP metadata chunks are
+// dropped without re-encoding; GIF has no EXIF and is kept as is.
+func stripMetadata(data []byte, img image.Image, contentType string) ([]byte, error) {
+	switch contentType {
+	case TypeJPEG, TypePNG:
+		return encodeImage(img, formatOf(contentType))
+	case TypeWebP:
+		return stripWebPMetadata(data)
+	}
+	return data, nil
+}
+
+func formatOf(contentType string) string {
+	switch contentType {
+	case TypeJPEG:
+		return FormatJPEG
+	case TypeWebP:
+		return FormatWebP
+	}
+	return FormatPNG
+}
+
+func encodeImage(img image.Image, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case FormatJPEG:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	case FormatWebP:
+		// Pure-Go encoder; produces lossless WebP
+		err = nativewebp.Encode(&buf, img, nil)
+	default:
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("upload: failed to encode %s: %w", format, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// resize scales img to fit inside width x height, or to cover it and crop the
+// center when crop is set. Images are never scaled up.
+func resize(img image.Image, width, height int, crop bool) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	scaleX := float64(width) / float64(w)
+	scaleY := float64(height) / float64(h)
+	scale := min(scaleX, scaleY)
+	if crop {
+		scale = max(scaleX, scaleY)
+	}
+	if scale > 1 {
+		scale = 1
+	}
+
+	sw, sh := max(1, int(float64(w)*scale+0.5)), max(1, int(float64(h)*scale+0.5))
+	scaled := image.NewNRGBA(image.Rect(0, 0, sw, sh))
+	xdraw.CatmullRom.Scale(scaled, scaled.Bounds(), img, b, xdraw.Src, nil)
+
+	if !crop || (sw <= width && sh <= height) {
+		return scaled
+	}
+
+	cw, ch := min(sw, width), min(sh, height)
+	x0, y0 := (sw-cw)/2, (sh-ch)/2
+	return scaled.SubImage(image.Rect(x0, y0, x0+cw, y0+ch))
+}
+
+// stripWebPMetadata removes the EXIF and XMP chunks from a WebP file
+func stripWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, fmt.Errorf("%w: malformed WebP", ErrInvalidImage)
+	}
+
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+
+	for i := 12; i+8 <= len(data); {
+		fourCC := string(data[i : i+4])
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + size + size%2 // chunks are padded to an even size
+		if size < 0 || end > len(data) {
+			return nil, fmt.Errorf("%w: malformed WebP", ErrInvalidImage)
+		}
+
+		switch fourCC {
+		case "EXIF", "XMP ":
+			// dropped
+		case "VP8X":
+			chunk := append([]byte(nil), data[i:end]...)
+			if len(chunk) > 8 {
+				// Clear the EXIF (0x08) and XMP (0x04) flags
+				chunk[8] &^= 0x08 | 0x04
+			}
+			out = append(out, chunk...)
+		default:
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, nil
+}
@@ -0,0 +1,227 @@
+// File: jobfair-shared-libs/go/upload/pipeline.go
+package upload
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"path"
+	"strings"
+
+	"github.com/jobfair/shared/storage"
+)
+
+// QuarantinePrefix is the key prefix flagged files are moved under. It is
+// never served publicly.
+const QuarantinePrefix = "quarantine"
+
+// Pipeline validates, scans and processes uploads before storing them:
+//
+//  1. enforce the size limit of the kind
+//  2. detect the content type from magic bytes and check it against the kind
+//  3. scan for malware; flagged files are stored under QuarantinePrefix and rejected
+//  4. strip EXIF/XMP metadata from images (applying the EXIF orientation)
+//  5. store the file and its thumbnail/WebP variants
+type Pipeline struct {
+	store   storage.Store
+	scanner Scanner
+}
+
+// NewPipeline creates an upload pipeline. A nil scanner disables scanning.
+func NewPipeline(store storage.Store, scanner Scanner) *Pipeline {
+	return &Pipeline{store: store, scanner: scanner}
+}
+
+// ProcessFile runs an uploaded multipart file through the pipeline
+func (p *Pipeline) ProcessFile(ctx context.Context, file *multipart.FileHeader, kind Kind, key string) (*Result, error) {
+	if file.Size > kind.MaxSize {
+		return nil, tooLarge(kind.MaxSize)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("upload: failed to open uploaded file: %w", err)
+	}
+	defer src.Close()
+
+	return p.Process(ctx, src, kind, key)
+}
+
+// Process runs r through the pipeline and stores it under key plus the
+// extension of the detected content type (key must not have an extension)
+func (p *Pipeline) Process(ctx context.Context, r io.Reader, kind Kind, key string) (*Result, error) {
+	data, err := io.ReadAll(io.LimitReader(r, kind.MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("upload: failed to read file: %w", err)
+	}
+	if int64(len(data)) > kind.MaxSize {
+		return nil, tooLarge(kind.MaxSize)
+	}
+
+	contentType := DetectContentType(data)
+	if !kind.Allows(contentType) {
+		return nil, fmt.Errorf("%w: %s", ErrTypeNotAllowed, contentType)
+	}
+	ext := Extension(contentType)
+
+	if err := p.scan(ctx, data, key+ext, contentType); err != nil {
+		return nil, err
+	}
+
+	var img imageData
+	if isImage(contentType) {
+		if img, err = newImageData(data, contentType); err != nil {
+			return nil, err
+		}
+		data = img.stripped
+	}
+
+	url, err := p.store.Put(ctx, key+ext, bytes.NewReader(data), int64(len(data)), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Key:         key + ext,
+		URL:         url,
+		ContentType: contentType,
+		Ext:         ext,
+		Size:        int64(len(data)),
+		Data:        data,
+		Variants:    make(map[string]string),
+	}
+
+	if img.decoded != nil {
+		for _, v := range kind.Variants {
+			variantURL, err := p.putVariant(ctx, img, key, ext, v)
+			if err != nil {
+				// The original is stored; variants are optional renditions
+				slog.WarnContext(ctx, "Failed to create upload variant", "variant", v.Name, "key", result.Key, "error", err)
+				continue
+			}
+			result.Variants[v.Name] = variantURL
+		}
+	}
+
+	return result, nil
+}
+
+// Remove deletes a stored upload and its variants. Missing objects are ignored.
+func (p *Pipeline) Remove(ctx context.Context, key string, kind Kind) error {
+	ext := path.Ext(key)
+	base := strings.TrimSuffix(key, ext)
+
+	for _, v := range kind.Variants {
+		p.store.Delete(ctx, VariantKey(base, ext, v))
+	}
+	return p.store.Delete(ctx, key)
+}
+
+// RemoveURL deletes an upload by its URL; URLs from other stores are ignored
+func (p *Pipeline) RemoveURL(ctx context.Context, url string, kind Kind) error {
+	key, ok := p.store.KeyFromURL(url)
+	if !ok {
+		return nil
+	}
+	return p.Remove(ctx, key, kind)
+}
+
+// VariantKey returns the object key of a variant of the upload stored under base+ext
+func VariantKey(base, ext string, v Variant) string {
+	format := v.Format
+	if format == FormatOriginal {
+		format = formatOf(contentTypeOfExt(ext))
+	}
+	return fmt.Sprintf("%s_%s%s", base, v.Name, formatExt(format))
+}
+
+func (p *Pipeline) scan(ctx context.Context, data []byte, key, contentType string) error {
+	if p.scanner == nil {
+		return nil
+	}
+
+	verdict, err := p.scanner.Scan(ctx, bytes.NewReader(data))
+	if err != nil {
+		// Fail closed: an unscanned file is never stored
+		return fmt.Errorf("upload: malware scan failed: %w", err)
+	}
+	if !verdict.Infected {
+		return nil
+	}
+
+	quarantineKey := storage.Key(QuarantinePrefix, key)
+	if _, err := p.store.Put(ctx, quarantineKey, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		slog.ErrorContext(ctx, "Failed to quarantine infected upload", "key", key, "signature", verdict.Signature, "error", err)
+	} else {
+		slog.WarnContext(ctx, "Infected upload quarantined", "key", key, "signature", verdict.Signature, "quarantine_key", quarantineKey)
+	}
+	return fmt.Errorf("%w (%s)", ErrInfected, verdict.Signature)
+}
+
+func (p *Pipeline) putVariant(ctx context.Context, img imageData, base, ext string, v Variant) (string, error) {
+	format := v.Format
+	if format == FormatOriginal {
+		format = formatOf(img.contentType)
+	}
+
+	data, err := encodeImage(resize(img.decoded, v.Width, v.Height, v.Crop), format)
+	if err != nil {
+		return "", err
+	}
+	return p.store.Put(ctx, VariantKey(base, ext, v), bytes.NewReader(data), int64(len(data)), formatContentType(format))
+}
+
+type imageData struct {
+	contentType string
+	decoded     image.Image
+	stripped    []byte
+}
+
+func newImageData(data []byte, contentType string) (imageData, error) {
+	decoded, err := decodeImage(data, contentType)
+	if err != nil {
+		return imageData{}, err
+	}
+	stripped, err := stripMetadata(data, decoded, contentType)
+	if err != nil {
+		return imageData{}, err
+	}
+	return imageData{contentType: contentType, decoded: decoded, stripped: stripped}, nil
+}
+
+func isImage(contentType string) bool {
+	return strings.HasPrefix(contentType, "image/")
+}
+
+func contentTypeOfExt(ext string) string {
+	for contentType, e := range extensions {
+		if e == ext {
+			return contentType
+		}
+	}
+	return ""
+}
+
+func formatExt(format string) string {
+	switch format {
+	case FormatJPEG:
+		return ".jpg"
+	case FormatWebP:
+		return ".webp"
+	}
+	return ".png"
+}
+
+func formatContentType(format string) string {
+	switch format {
+	case FormatJPEG:
+		return TypeJPEG
+	case FormatWebP:
+		return TypeWebP
+	}
+	return TypePNG
+}
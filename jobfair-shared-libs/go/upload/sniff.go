@@ -0,0 +1,70 @@
+// File: jobfair-shared-libs/go/upload/sniff.go
+package upload
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+)
+
+var oleSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// DetectContentType determines the content type from the file content
+// (magic bytes), ignoring the file name. Unknown content is reported as
+// "application/octet-stream".
+func DetectContentType(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, oleSignature):
+		// Legacy Office compound file; only Word documents are accepted anywhere
+		return TypeDOC
+	case len(data) >= 12 && string(data[4:8]) == "ftyp" && string(data[8:10]) == "qt":
+		return TypeQuickTime
+	}
+
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "application/zip":
+		if isDOCX(data) {
+			return TypeDOCX
+		}
+	case "video/avi":
+		return TypeAVI
+	}
+	return contentType
+}
+
+// isDOCX reports whether a ZIP archive is a Word document
+func isDOCX(data []byte) bool {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return false
+	}
+	for _, f := range archive.File {
+		if f.Name == "word/document.xml" {
+			return true
+		}
+	}
+	return false
+}
+
+var extensions = map[string]string{
+	TypeJPEG:      ".jpg",
+	TypePNG:       ".png",
+	TypeGIF:       ".gif",
+	TypeWebP:      ".webp",
+	TypePDF:       ".pdf",
+	TypeDOC:       ".doc",
+	TypeDOCX:      ".docx",
+	TypeMP4:       ".mp4",
+	TypeWebM:      ".webm",
+	TypeQuickTime: ".mov",
+	TypeAVI:       ".avi",
+}
+
+// Extension returns the file extension for a content type detected by DetectContentType
+func Extension(contentType string) string {
+	if ext, ok := extensions[contentType]; ok {
+		return ext
+	}
+	return ".bin"
+}
@@ -0,0 +1,147 @@
+// File: jobfair-shared-libs/go/upload/upload.go
+package upload
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrTooLarge is returned when a file exceeds the size limit of its kind
+	ErrTooLarge = errors.New("upload: file is too large")
+	// ErrTypeNotAllowed is returned when the detected content type is not allowed for the kind
+	ErrTypeNotAllowed = errors.New("upload: file type not allowed")
+	// ErrInvalidImage is returned for images that cannot be decoded or are too large to process
+	ErrInvalidImage = errors.New("upload: invalid image")
+	// ErrInfected is returned when the scanner flags a file; the file is quarantined
+	ErrInfected = errors.New("upload: file failed the malware scan")
+)
+
+// Content types recognised by DetectContentType
+const (
+	TypeJPEG      = "image/jpeg"
+	TypePNG       = "image/png"
+	TypeGIF       = "image/gif"
+	TypeWebP      = "image/webp"
+	TypePDF       = "application/pdf"
+	TypeDOC       = "application/msword"
+	TypeDOCX      = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	TypeMP4       = "video/mp4"
+	TypeWebM      = "video/webm"
+	TypeQuickTime = "video/quicktime"
+	TypeAVI       = "video/x-msvideo"
+)
+
+// Variant output formats. FormatOriginal keeps the source format (GIF becomes PNG).
+const (
+	FormatOriginal = ""
+	FormatJPEG     = "jpeg"
+	FormatPNG      = "png"
+	FormatWebP     = "webp"
+)
+
+// Variant is an extra rendition stored next to an uploaded image, e.g. a thumbnail
+type Variant struct {
+	Name   string // suffix of the variant key: "<key>_<name>.<ext>"
+	Width  int
+	Height int
+	Crop   bool   // crop to fill Width x Height instead of fitting inside it
+	Format string // FormatOriginal, FormatJPEG, FormatPNG or FormatWebP
+}
+
+// Kind describes what may be uploaded for one purpose and how it is processed
+type Kind struct {
+	Name         string
+	MaxSize      int64
+	AllowedTypes []string
+	Variants     []Variant
+}
+
+// Allows reports whether contentType may be uploaded as this kind
+func (k Kind) Allows(contentType string) bool {
+	for _, t := range k.AllowedTypes {
+		if t == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+var imageTypes = []string{TypeJPEG, TypePNG, TypeGIF, TypeWebP}
+
+// Upload kinds shared by the services. Copy and adjust a kind to change its
+// limits, e.g. kind := upload.CV; kind.MaxSize = cfg.MaxFileSize.
+var (
+	ProfilePhoto = Kind{
+		Name:         "profile_photo",
+		MaxSize:      5 << 20,
+		AllowedTypes: imageTypes,
+		Variants: []Variant{
+			{Name: "thumb", Width: 200, Height: 200, Crop: true},
+			{Name: "webp", Width: 800, Height: 800, Format: FormatWebP},
+		},
+	}
+	CompanyLogo = Kind{
+		Name:         "company_logo",
+		MaxSize:      5 << 20,
+		AllowedTypes: imageTypes,
+		Variants: []Variant{
+			{Name: "thumb", Width: 128, Height: 128},
+			{Name: "webp", Width: 512, Height: 512, Format: FormatWebP},
+		},
+	}
+	Banner = Kind{
+		Name:         "banner",
+		MaxSize:      10 << 20,
+		AllowedTypes: imageTypes,
+		Variants: []Variant{
+			{Name: "thumb", Width: 640, Height: 200, Crop: true},
+			{Name: "webp", Width: 1920, Height: 1080, Format: FormatWebP},
+		},
+	}
+	GalleryImage = Kind{
+		Name:         "gallery_image",
+		MaxSize:      10 << 20,
+		AllowedTypes: imageTypes,
+		Variants: []Variant{
+			{Name: "thumb", Width: 400, Height: 300, Crop: true},
+		},
+	}
+	Video = Kind{
+		Name:         "video",
+		MaxSize:      50 << 20,
+		AllowedTypes: []string{TypeMP4, TypeWebM, TypeQuickTime, TypeAVI},
+	}
+	CV = Kind{
+		Name:         "cv",
+		MaxSize:      10 << 20,
+		AllowedTypes: []string{TypePDF, TypeDOC, TypeDOCX, TypeJPEG, TypePNG},
+	}
+)
+
+// Result describes a stored upload
+type Result struct {
+	Key         string
+	URL         string
+	ContentType string
+	Ext         string // extension matching ContentType, e.g. ".jpg"
+	Size        int64
+	// Data is the stored content (after metadata stripping), for callers
+	// that post-process the file such as CV text extraction
+	Data []byte
+	// Variants maps variant names to their URLs
+	Variants map[string]string
+}
+
+// IsRejected reports whether err means the uploaded file itself was refused
+// (a client error) rather than a storage or scanner failure
+func IsRejected(err error) bool {
+	return errors.Is(err, ErrTooLarge) ||
+		errors.Is(err, ErrTypeNotAllowed) ||
+		errors.Is(err, ErrInvalidImage) ||
+		errors.Is(err, ErrInfected)
+}
+
+func tooLarge(max int64) error {
+	return fmt.Errorf("%w (max %dMB)", ErrTooLarge, max>>20)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"jobfair-user-profile-service/internal/config"
 	"jobfair-user-profile-service/internal/consumers"
 	"jobfair-user-profile-service/internal/handlers"
@@ -8,24 +9,47 @@ import (
 	"jobfair-user-profile-service/internal/repository"
 	"jobfair-user-profile-service/internal/services"
 	"jobfair-user-profile-service/pkg/database"
-	"log"
+	"log/slog"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jobfair/shared/health"
+	"github.com/jobfair/shared/httpclient"
+	"github.com/jobfair/shared/identity"
+	"github.com/jobfair/shared/logging"
+	"github.com/jobfair/shared/metrics"
+	"github.com/jobfair/shared/storage"
+	"github.com/jobfair/shared/tracing"
+	"github.com/jobfair/shared/upload"
 )
 
 func main() {
 	// Load configuration
 	cfg := config.Load()
 
+	// JSON logs with request, trace and user IDs; LOG_LEVEL and LOG_FORMAT tune them
+	logging.Setup(cfg.Logging)
+
+	if err := cfg.Validate(); err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+
+	// Initialize tracing; OTEL_TRACES_EXPORTER selects where spans go
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		logging.Fatal("Failed to initialize tracing", "error", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	// Connect to database
 	db, err := database.Connect(cfg.DatabaseURL)
 	if err != nil {
-		log.Fatalf("❌ Failed to connect to database: %v", err)
+		logging.Fatal("Failed to connect to database", "error", err)
 	}
-	log.Println("✅ Database connected successfully")
 
 	// Auto-migrate models
 	// if err := db.AutoMigrate(
@@ -51,8 +75,29 @@ func main() {
 	skillRepo := repository.NewSkillRepository(db)
 	preferenceRepo := repository.NewPreferenceRepository(db)
 	cvRepo := repository.NewCVRepository(db)
+	cvAccessLogRepo := repository.NewCVAccessLogRepository(db)
+	cvImportRepo := repository.NewCVImportRepository(db)
 	// badgeRepo := repository.NewBadgeRepository(db)
 
+	// Initialize object storage for CVs and banners
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		logging.Fatal("Failed to initialize storage", "error", err)
+	}
+	slog.Info("Storage initialized", "backend", cfg.Storage.Backend)
+
+	// Uploads are content-checked, scanned and resized before they are stored
+	scanner, err := upload.NewScanner(cfg.Scanner)
+	if err != nil {
+		logging.Fatal("Failed to initialize upload scanner", "error", err)
+	}
+	uploads := upload.NewPipeline(store, scanner)
+	slog.Info("Upload scanner initialized", "scanner", cfg.Scanner.Scanner)
+
+	// Calls to the job service go through a client with retries and a
+	// circuit breaker
+	httpClient := httpclient.New(cfg.HTTPClient)
+
 	// Initialize services
 	profileService := services.NewProfileService(
 		profileRepo,
@@ -62,23 +107,29 @@ func main() {
 		skillRepo,
 		preferenceRepo,
 		cvRepo,
+		uploads,
+		httpClient,
+		cfg.JobServiceURL,
+		cfg.Identity.Secret,
 	)
 	workExpService := services.NewWorkExperienceService(workExpRepo, profileService)
 	educationService := services.NewEducationService(educationRepo, profileService)
 	certificationService := services.NewCertificationService(certificationRepo, profileService)
 	skillService := services.NewSkillService(skillRepo, profileService)
 	preferenceService := services.NewPreferenceService(preferenceRepo, profileService)
-	cvService := services.NewCVService(cvRepo, profileService, cfg)
+	cvService := services.NewCVService(cvRepo, cvAccessLogRepo, profileService, store, uploads, httpClient, cfg)
+	cvImportService := services.NewCVImportService(cvRepo, cvImportRepo, profileService, store, httpClient, cfg)
+	resumeService := services.NewResumeService(profileService, cvService)
 
 	// 🚀 Initialize Event Consumer
 	eventConsumer, err := consumers.NewUserEventConsumer(cfg.RabbitMQURL, profileService)
 	if err != nil {
-		log.Fatalf("❌ Failed to create event consumer: %v", err)
+		logging.Fatal("Failed to create event consumer", "error", err)
 	}
 
 	// Start consuming events
 	if err := eventConsumer.Start(); err != nil {
-		log.Fatalf("❌ Failed to start event consumer: %v", err)
+		logging.Fatal("Failed to start event consumer", "error", err)
 	}
 
 	// Initialize handlers
@@ -89,27 +140,57 @@ func main() {
 	skillHandler := handlers.NewSkillHandler(skillService)
 	preferenceHandler := handlers.NewPreferenceHandler(preferenceService)
 	cvHandler := handlers.NewCVHandler(cvService)
+	cvImportHandler := handlers.NewCVImportHandler(cvImportService)
+	resumeHandler := handlers.NewResumeHandler(resumeService)
 	bannerHandler := handlers.NewBannerHandler(profileService)
 
 	// Initialize Gin router
-	router := gin.Default()
+	// Requests are logged by logging.Middleware
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	// Request IDs and a trace span for every request
+	router.Use(tracing.Middleware(cfg.Tracing.ServiceName)...)
+
+	// Request rate, errors and latency per route
+	router.Use(metrics.Middleware())
+
+	// Access log
+	router.Use(logging.Middleware())
 
 	// Disable automatic trailing slash redirect to prevent 301 loops
 	router.RedirectTrailingSlash = false
 
-	// Health check endpoint (no auth required)
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "ok",
-			"service": cfg.ServiceName,
-		})
-	})
+	// Trust identity headers set by the API gateway
+	router.Use(identity.Middleware(cfg.Identity))
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		logging.Fatal("Failed to get database connection pool", "error", err)
+	}
+
+	// Prometheus metrics: connection pool stats
+	metrics.RegisterDB("jobfair_profiles", sqlDB)
+	metrics.Register(router)
+
+	// Liveness (/livez) and readiness (/readyz, /health) probes
+	// Profiles are served without job counts while job-service is down
+	health.NewChecker(cfg.ServiceName).
+		Add("database", health.Database(sqlDB)).
+		Add("rabbitmq_user_events", eventConsumer.Check).
+		Add("storage", health.Storage(store)).
+		AddOptional("job_service", health.HTTP(strings.TrimRight(cfg.JobServiceURL, "/")+"/livez")).
+		Register(router)
+
+	// Static file serving for banners when stored on local disk
+	// (the S3 backend returns bucket URLs instead). CVs are private and only
+	// available through the authorized /api/v1/cv download endpoints.
+	if local, ok := store.(*storage.LocalStore); ok {
+		router.Static(cfg.Storage.PublicURL+"/banners", filepath.Join(local.Dir(), "banners"))
+	}
 
-	// Static file serving for uploads (CV files)
-	// Map /uploads/cv to the upload directory
-	router.Static("/uploads/cv", cfg.UploadDir)
-	// Map /uploads/banners for banner images
-	router.Static("/uploads/banners", "./uploads/banners")
+	// Signed CV share links carry their own authorization (no JWT)
+	router.GET("/api/v1/cv/shared/:user_id", cvHandler.DownloadShared)
 
 	// API v1 routes with JWT authentication
 	v1 := router.Group("/api/v1")
@@ -123,6 +204,8 @@ func main() {
 			profiles.GET("/full", profileHandler.GetProfileWithRelations)
 			profiles.PUT("", profileHandler.UpdateProfile)
 			profiles.GET("/completion", profileHandler.GetCompletionStatus)
+			profiles.GET("/resume.pdf", resumeHandler.Download)
+			profiles.GET("/resume/templates", resumeHandler.GetTemplates)
 		}
 
 		// Work Experience routes
@@ -188,6 +271,15 @@ func main() {
 		v1.GET("/cv/", cvHandler.Get)
 		v1.DELETE("/cv", cvHandler.Delete)
 		v1.DELETE("/cv/", cvHandler.Delete)
+		v1.GET("/cv/download", cvHandler.Download)
+		v1.GET("/cv/access-logs", cvHandler.GetAccessLogs)
+		v1.GET("/cv/users/:user_id/download", cvHandler.DownloadForUser)
+		v1.POST("/cv/users/:user_id/share-link", cvHandler.CreateShareLink)
+
+		// CV import (autofill profile from the uploaded CV) and recruiter search
+		v1.GET("/cv/import/preview", cvImportHandler.Preview)
+		v1.POST("/cv/import/confirm", cvImportHandler.Confirm)
+		v1.GET("/cv/search", cvImportHandler.Search)
 
 		// Banner routes - handle both with and without trailing slash
 		v1.POST("/banner", bannerHandler.UploadBanner)
@@ -212,16 +304,20 @@ func main() {
 
 	go func() {
 		<-quit
-		log.Println("🛑 Shutting down server...")
+		slog.Info("Service shutting down")
 		if err := eventConsumer.Close(); err != nil {
-			log.Printf("⚠️ Error closing event consumer: %v", err)
+			slog.Error("Failed to close event consumer", "error", err)
+		}
+		// Flush buffered spans; os.Exit skips deferred calls
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("Failed to flush traces", "error", err)
 		}
 		os.Exit(0)
 	}()
 
 	// Start server
-	log.Printf("🚀 %s started on port %s\n", cfg.ServiceName, cfg.Port)
+	slog.Info("Service starting", "port", cfg.Port)
 	if err := router.Run(":" + cfg.Port); err != nil {
-		log.Fatalf("❌ Failed to start server: %v", err)
+		logging.Fatal("Failed to start service", "error", err)
 	}
 }
@@ -5,7 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 
 	"jobfair-user-profile-service/internal/models"
 	"jobfair-user-profile-service/internal/services"
@@ -35,7 +35,7 @@ func NewUserEventConsumer(
 
 // Start begins consuming user events
 func (c *UserEventConsumer) Start() error {
-	log.Println("🚀 Starting user event consumer...")
+	slog.Info("Starting user event consumer")
 
 	return c.consumer.Subscribe(
 		"profile-service.user-events", // queue name
@@ -52,14 +52,14 @@ func (c *UserEventConsumer) handleEvent(ctx context.Context, body []byte) error
 		return fmt.Errorf("failed to unmarshal base event: %w", err)
 	}
 
-	log.Printf("📨 Processing event: %s", baseEvent.EventType)
+	slog.DebugContext(ctx, "Processing event", "event_type", baseEvent.EventType)
 
 	// Route to appropriate handler based on event type
 	switch baseEvent.EventType {
 	case events.EventTypeUserRegistered:
 		return c.handleUserRegistered(ctx, body)
 	default:
-		log.Printf("⚠️ Unknown event type: %s", baseEvent.EventType)
+		slog.WarnContext(ctx, "Unknown event type", "event_type", baseEvent.EventType)
 		return nil // Don't fail on unknown events
 	}
 }
@@ -72,24 +72,24 @@ func (c *UserEventConsumer) handleUserRegistered(ctx context.Context, body []byt
 	}
 
 	data := event.Data
-	log.Printf("👤 Processing user registered event for user_id: %d, name: %s", data.UserID, data.FullName)
+	slog.InfoContext(ctx, "Processing user registered event", "user_id", data.UserID)
 
 	// Check if profile already exists (idempotency)
 	existingProfile, _ := c.profileService.GetProfile(data.UserID)
 	if existingProfile != nil {
-		log.Printf("ℹ️ Profile already exists for user_id: %d", data.UserID)
+		slog.InfoContext(ctx, "Profile already exists", "user_id", data.UserID)
 		
 		// Update profile photo if provided in event
 		if data.ProfilePhotoURL != "" && existingProfile.ProfilePictureURL != data.ProfilePhotoURL {
-			log.Printf("📸 Updating profile photo for user_id: %d", data.UserID)
+			slog.DebugContext(ctx, "Updating profile photo", "user_id", data.UserID)
 			photoURL := data.ProfilePhotoURL
 			req := &models.ProfileUpdateRequest{
 				ProfilePictureURL: &photoURL,
 			}
 			if _, err := c.profileService.UpdateProfile(data.UserID, req); err != nil {
-				log.Printf("⚠️ Warning: Failed to update profile photo: %v", err)
+				slog.WarnContext(ctx, "Failed to update profile photo", "user_id", data.UserID, "error", err)
 			} else {
-				log.Printf("✅ Profile photo updated for user_id: %d", data.UserID)
+				slog.InfoContext(ctx, "Profile photo updated", "user_id", data.UserID)
 			}
 		}
 		return nil // Not an error, just idempotency
@@ -103,22 +103,26 @@ func (c *UserEventConsumer) handleUserRegistered(ctx context.Context, body []byt
 
 	// Update profile photo if provided
 	if data.ProfilePhotoURL != "" {
-		log.Printf("📸 Setting profile photo for newly created profile: user_id=%d", data.UserID)
+		slog.DebugContext(ctx, "Setting profile photo", "user_id", data.UserID)
 		photoURL := data.ProfilePhotoURL
 		req := &models.ProfileUpdateRequest{
 			ProfilePictureURL: &photoURL,
 		}
 		if _, err := c.profileService.UpdateProfile(data.UserID, req); err != nil {
-			log.Printf("⚠️ Warning: Failed to set profile photo: %v", err)
+			slog.WarnContext(ctx, "Failed to set profile photo", "user_id", data.UserID, "error", err)
 		}
 	}
 
-	log.Printf("✅ Profile created successfully: ID=%d, UserID=%d, Name=%s",
-		profile.ID, profile.UserID, profile.FullName)
+	slog.InfoContext(ctx, "Profile created", "profile_id", profile.ID, "user_id", profile.UserID)
 
 	return nil
 }
 
+// Check reports whether the consumer is still connected to RabbitMQ
+func (c *UserEventConsumer) Check(ctx context.Context) error {
+	return c.consumer.Check(ctx)
+}
+
 // Close closes the consumer
 func (c *UserEventConsumer) Close() error {
 	if c.consumer != nil {
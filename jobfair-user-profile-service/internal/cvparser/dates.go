@@ -0,0 +1,96 @@
+package cvparser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Month names and abbreviations in English and Indonesian
+var months = map[string]time.Month{
+	"jan": time.January, "january": time.January, "januari": time.January,
+	"feb": time.February, "february": time.February, "februari": time.February,
+	"mar": time.March, "march": time.March, "maret": time.March,
+	"apr": time.April, "april": time.April,
+	"may": time.May, "mei": time.May,
+	"jun": time.June, "june": time.June, "juni": time.June,
+	"jul": time.July, "july": time.July, "juli": time.July,
+	"aug": time.August, "august": time.August, "agu": time.August, "agt": time.August, "ags": time.August, "agustus": time.August,
+	"sep": time.September, "sept": time.September, "september": time.September,
+	"oct": time.October, "october": time.October, "okt": time.October, "oktober": time.October,
+	"nov": time.November, "november": time.November, "nopember": time.November,
+	"dec": time.December, "december": time.December, "des": time.December, "desember": time.December,
+}
+
+const (
+	monthPattern = `(?:january|januari|february|februari|march|maret|april|may|mei|june|juni|july|juli|august|agustus|september|october|oktober|november|nopember|december|desember|jan|feb|mar|apr|jun|jul|aug|agu|agt|ags|sept|sep|oct|okt|nov|dec|des)`
+	datePattern  = `(?:` + monthPattern + `\.?\s+\d{4}|\d{1,2}\s*/\s*\d{4}|(?:19|20)\d{2})`
+)
+
+var (
+	dateRe      = regexp.MustCompile(`(?i)\b` + datePattern + `\b`)
+	dateRangeRe = regexp.MustCompile(`(?i)\(?\b(` + datePattern + `)\s*(?:-|–|—|to|until|s/d|sampai)\s*(` + datePattern + `|present|current|now|sekarang|saat ini)\b\)?`)
+	monthYearRe = regexp.MustCompile(`(?i)^(` + monthPattern + `)\.?\s+(\d{4})$`)
+	slashDateRe = regexp.MustCompile(`^(\d{1,2})\s*/\s*(\d{4})$`)
+)
+
+// findDateRange finds a "start - end" period in line and returns the
+// parsed dates and the line with the period removed
+func findDateRange(line string) (start, end *time.Time, current bool, rest string, ok bool) {
+	m := dateRangeRe.FindStringSubmatchIndex(line)
+	if m == nil {
+		return nil, nil, false, line, false
+	}
+
+	start = parseDate(line[m[2]:m[3]])
+	endText := strings.ToLower(line[m[4]:m[5]])
+	switch endText {
+	case "present", "current", "now", "sekarang", "saat ini":
+		current = true
+	default:
+		end = parseDate(endText)
+	}
+
+	rest = strings.TrimSpace(line[:m[0]] + " " + line[m[1]:])
+	rest = strings.Trim(rest, " ,|-–—()")
+	return start, end, current, rest, start != nil
+}
+
+// lastDate returns the position of the last date in text
+func lastDate(text string) []int {
+	all := dateRe.FindAllStringIndex(text, -1)
+	if len(all) == 0 {
+		return nil
+	}
+	return all[len(all)-1]
+}
+
+// parseDate parses "Jan 2020", "01/2020" or "2020" as the first day of that month
+func parseDate(text string) *time.Time {
+	text = strings.TrimSpace(text)
+
+	var year int
+	month := time.January
+
+	if m := monthYearRe.FindStringSubmatch(text); m != nil {
+		month = months[strings.ToLower(m[1])]
+		year, _ = strconv.Atoi(m[2])
+	} else if m := slashDateRe.FindStringSubmatch(text); m != nil {
+		mm, _ := strconv.Atoi(m[1])
+		if mm < 1 || mm > 12 {
+			return nil
+		}
+		month = time.Month(mm)
+		year, _ = strconv.Atoi(m[2])
+	} else if y, err := strconv.Atoi(text); err == nil {
+		year = y
+	}
+
+	if year < 1950 || year > time.Now().Year()+10 {
+		return nil
+	}
+
+	t := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return &t
+}
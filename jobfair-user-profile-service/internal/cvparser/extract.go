@@ -0,0 +1,176 @@
+package cvparser
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// ErrUnsupportedFormat is returned for file types text cannot be extracted from
+var ErrUnsupportedFormat = errors.New("text extraction is only supported for PDF and DOCX files")
+
+// maxDocumentXMLSize caps the decompressed size of word/document.xml to
+// protect against zip bombs
+const maxDocumentXMLSize = 20 << 20
+
+// ExtractText extracts plain text from a PDF or DOCX file. ext is the file
+// extension including the dot (".pdf", ".docx"). Lines are separated by "\n".
+func ExtractText(r io.ReaderAt, size int64, ext string) (string, error) {
+	switch strings.ToLower(ext) {
+	case ".pdf":
+		return extractPDF(r, size)
+	case ".docx":
+		return extractDOCX(r, size)
+	}
+	return "", ErrUnsupportedFormat
+}
+
+func extractPDF(r io.ReaderAt, size int64) (text string, err error) {
+	// The PDF library panics on some malformed files
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("failed to read PDF: %v", rec)
+		}
+	}()
+
+	reader, err := pdf.NewReader(r, size)
+	if err != nil {
+		return "", fmt.Errorf("failed to read PDF: %w", err)
+	}
+
+	var b strings.Builder
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+
+		writePageText(&b, page.Content().Text)
+		b.WriteByte('\n')
+	}
+
+	return normalizeText(b.String()), nil
+}
+
+// writePageText lays out the glyphs of a page as lines. A change of baseline
+// starts a new line and a horizontal gap between glyphs becomes a space.
+// (Page.GetTextByRow merges lines for PDFs that don't set glyph widths.)
+func writePageText(b *strings.Builder, glyphs []pdf.Text) {
+	for i, t := range glyphs {
+		if i > 0 {
+			prev := glyphs[i-1]
+			switch {
+			case math.Abs(t.Y-prev.Y) > prev.FontSize/2:
+				b.WriteByte('\n')
+			case t.X-(prev.X+prev.W) > prev.FontSize/5 && t.S != " " && prev.S != " ":
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(t.S)
+	}
+	b.WriteByte('\n')
+}
+
+func extractDOCX(r io.ReaderAt, size int64) (string, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return "", fmt.Errorf("failed to read DOCX: %w", err)
+	}
+
+	for _, f := range archive.File {
+		if f.Name != "word/document.xml" {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return "", fmt.Errorf("failed to read DOCX: %w", err)
+		}
+		defer rc.Close()
+
+		data, err := io.ReadAll(io.LimitReader(rc, maxDocumentXMLSize+1))
+		if err != nil {
+			return "", fmt.Errorf("failed to read DOCX: %w", err)
+		}
+		if len(data) > maxDocumentXMLSize {
+			return "", errors.New("DOCX document is too large")
+		}
+
+		return parseDocumentXML(data)
+	}
+
+	return "", errors.New("invalid DOCX: word/document.xml not found")
+}
+
+// parseDocumentXML collects the text runs (w:t) of a WordprocessingML body,
+// starting a new line for every paragraph and line break
+func parseDocumentXML(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var b strings.Builder
+	inText := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse DOCX: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				b.WriteByte('\t')
+			case "br", "cr":
+				b.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				b.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				b.Write(t)
+			}
+		}
+	}
+
+	return normalizeText(b.String()), nil
+}
+
+// normalizeText trims trailing whitespace on every line and collapses runs
+// of blank lines
+func normalizeText(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	out := make([]string, 0, len(lines))
+	blank := false
+	for _, line := range lines {
+		line = strings.TrimRight(strings.ReplaceAll(line, "\u00a0", " "), " \t")
+		if strings.TrimSpace(line) == "" {
+			if !blank && len(out) > 0 {
+				out = append(out, "")
+			}
+			blank = true
+			continue
+		}
+		out = append(out, line)
+		blank = false
+	}
+
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
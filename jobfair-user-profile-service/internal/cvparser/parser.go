@@ -0,0 +1,572 @@
+package cvparser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Result holds the profile data proposed from a CV's text. Everything is a
+// best-effort guess: the user reviews the proposals before they are saved.
+type Result struct {
+	Contact        Contact
+	Summary        string
+	Experiences    []Experience
+	Educations     []Education
+	Skills         []Skill
+	Certifications []Certification
+}
+
+type Contact struct {
+	FullName     string
+	Email        string
+	Phone        string
+	LinkedInURL  string
+	GitHubURL    string
+	PortfolioURL string
+}
+
+type Experience struct {
+	Position    string
+	Company     string
+	StartDate   *time.Time
+	EndDate     *time.Time
+	IsCurrent   bool
+	Description string
+}
+
+type Education struct {
+	Institution string
+	Degree      string
+	Major       string
+	StartDate   *time.Time
+	EndDate     *time.Time
+	IsCurrent   bool
+	GPA         *float64
+}
+
+type Skill struct {
+	Name string
+	Type string // technical, soft
+}
+
+type Certification struct {
+	Name          string
+	Organizer     string
+	IssueDate     *time.Time
+	CredentialURL string
+}
+
+const (
+	maxSkills         = 50
+	maxSkillLength    = 50
+	maxHeaderLookback = 2
+)
+
+type section int
+
+const (
+	sectionNone section = iota
+	sectionSummary
+	sectionExperience
+	sectionEducation
+	sectionSkills
+	sectionCertifications
+	sectionOther
+)
+
+// Section headings in English and Indonesian, compared after normalizeHeading
+var sectionHeadings = map[string]section{
+	"summary":                     sectionSummary,
+	"professional summary":        sectionSummary,
+	"profile":                     sectionSummary,
+	"about me":                    sectionSummary,
+	"objective":                   sectionSummary,
+	"career objective":            sectionSummary,
+	"ringkasan":                   sectionSummary,
+	"tentang saya":                sectionSummary,
+	"profil":                      sectionSummary,
+	"experience":                  sectionExperience,
+	"work experience":             sectionExperience,
+	"professional experience":     sectionExperience,
+	"employment history":          sectionExperience,
+	"employment":                  sectionExperience,
+	"work history":                sectionExperience,
+	"pengalaman":                  sectionExperience,
+	"pengalaman kerja":            sectionExperience,
+	"riwayat pekerjaan":           sectionExperience,
+	"education":                   sectionEducation,
+	"academic background":         sectionEducation,
+	"educational background":      sectionEducation,
+	"pendidikan":                  sectionEducation,
+	"riwayat pendidikan":          sectionEducation,
+	"skills":                      sectionSkills,
+	"technical skills":            sectionSkills,
+	"skills & tools":              sectionSkills,
+	"core competencies":           sectionSkills,
+	"keahlian":                    sectionSkills,
+	"kemampuan":                   sectionSkills,
+	"keterampilan":                sectionSkills,
+	"certifications":              sectionCertifications,
+	"certificates":                sectionCertifications,
+	"licenses & certifications":   sectionCertifications,
+	"licenses and certifications": sectionCertifications,
+	"sertifikasi":                 sectionCertifications,
+	"sertifikat":                  sectionCertifications,
+	"projects":                    sectionOther,
+	"languages":                   sectionOther,
+	"awards":                      sectionOther,
+	"interests":                   sectionOther,
+	"references":                  sectionOther,
+	"career preferences":          sectionOther,
+	"organizations":               sectionOther,
+	"volunteer experience":        sectionOther,
+	"publications":                sectionOther,
+	"proyek":                      sectionOther,
+	"bahasa":                      sectionOther,
+	"penghargaan":                 sectionOther,
+	"organisasi":                  sectionOther,
+}
+
+var (
+	emailRe       = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phoneRe       = regexp.MustCompile(`\+?[\d(][\d\s\-().]{7,}\d`)
+	linkedInRe    = regexp.MustCompile(`(?i)(?:https?://)?(?:[a-z]{2,3}\.)?linkedin\.com/in/[\w\-%]+/?`)
+	gitHubRe      = regexp.MustCompile(`(?i)(?:https?://)?(?:www\.)?github\.com/[\w\-]+/?`)
+	urlRe         = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s,;|]+`)
+	bulletRe      = regexp.MustCompile(`^\s*(?:[-*•●▪◦‣∙·>]|\d+[.)])\s*`)
+	gpaRe         = regexp.MustCompile(`(?i)\b(?:gpa|ipk)\s*[:\-]?\s*(\d(?:[.,]\d{1,2})?)`)
+	degreeRe      = regexp.MustCompile(`(?i)\b(bachelor(?:'s)?|master(?:'s)?|doctor(?:ate)?|ph\.?\s?d|diploma|associate|sarjana|magister|s1|s2|s3|d3|d4|b\.?\s?sc|m\.?\s?sc|b\.?\s?eng|m\.?\s?eng|b\.?\s?a|m\.?\s?a|mba|bs|ms)\b\.?`)
+	institution   = regexp.MustCompile(`(?i)\b(university|universitas|institute|institut|college|politeknik|polytechnic|academy|akademi|school|sekolah|sma|smk|stmik|stie)\b`)
+	companyHint   = regexp.MustCompile(`(?i)(^|\s)(pt\.?|cv\.?|tbk\.?|inc\.?|ltd\.?|llc|corp\.?|corporation|company|group|technologies|technology|labs?|studio|solutions|indonesia)(\s|$|,)`)
+	gpaScaleRe    = regexp.MustCompile(`^/\s*\d(?:[.,]\d{1,2})?`)
+	majorPrefixRe = regexp.MustCompile(`(?i)^(?:degree\s+)?(?:of|in|degree|,|-)\s*`)
+	skillSepRe    = regexp.MustCompile(`[,;|•●▪\t]`)
+	certSepRe     = regexp.MustCompile(`\s+(?:-|–|—|by|oleh)\s+|\s*[|,(]\s*`)
+	atRe          = regexp.MustCompile(`\s+(?:at|@|di)\s+`)
+	fragmentSepRe = regexp.MustCompile(`\s+[-–—]\s+|\s*[|,•·]\s*|\s{3,}|\t`)
+	titleHint     = regexp.MustCompile(`(?i)\b(engineer|developer|programmer|manager|intern|analyst|designer|lead|officer|staff|consultant|specialist|head|director|architect|scientist|administrator|assistant|coordinator|supervisor|executive|associate|trainee|magang)\b`)
+)
+
+var softSkills = map[string]bool{
+	"communication": true, "teamwork": true, "leadership": true, "problem solving": true,
+	"critical thinking": true, "time management": true, "adaptability": true, "creativity": true,
+	"collaboration": true, "negotiation": true, "public speaking": true, "presentation": true,
+	"attention to detail": true, "work ethic": true, "interpersonal skills": true,
+	"komunikasi": true, "kerja sama tim": true, "kepemimpinan": true, "pemecahan masalah": true,
+	"manajemen waktu": true, "kreativitas": true,
+}
+
+// Parse proposes profile data from plain CV text
+func Parse(text string) *Result {
+	lines := strings.Split(normalizeText(text), "\n")
+
+	result := &Result{}
+	result.Contact = parseContact(lines)
+
+	sections := splitSections(lines)
+	result.Summary = strings.Join(cleanLines(sections[sectionSummary]), " ")
+	result.Experiences = parseExperiences(sections[sectionExperience])
+	result.Educations = parseEducations(sections[sectionEducation])
+	result.Skills = parseSkills(sections[sectionSkills])
+	result.Certifications = parseCertifications(sections[sectionCertifications])
+
+	return result
+}
+
+// LooksLikeCV reports whether the parse found enough structure (contact
+// details and at least one known section) for the document to be a CV
+func (r *Result) LooksLikeCV() bool {
+	hasContact := r.Contact.Email != "" || r.Contact.Phone != ""
+	hasSection := len(r.Experiences) > 0 || len(r.Educations) > 0 || len(r.Skills) > 0
+	return hasContact && hasSection
+}
+
+func splitSections(lines []string) map[section][]string {
+	sections := make(map[section][]string)
+	current := sectionNone
+	for _, line := range lines {
+		if s, ok := sectionHeadings[normalizeHeading(line)]; ok {
+			current = s
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		sections[current] = append(sections[current], line)
+	}
+	return sections
+}
+
+func normalizeHeading(line string) string {
+	line = strings.ToLower(strings.TrimSpace(line))
+	line = strings.Trim(line, ":-–—|#*= ")
+	return strings.Join(strings.Fields(line), " ")
+}
+
+func parseContact(lines []string) Contact {
+	var contact Contact
+	text := strings.Join(lines, "\n")
+
+	contact.Email = emailRe.FindString(text)
+	contact.LinkedInURL = withScheme(strings.TrimSuffix(linkedInRe.FindString(text), "/"))
+	contact.GitHubURL = withScheme(strings.TrimSuffix(gitHubRe.FindString(text), "/"))
+
+	// Other links in the CV header (before the first section) are taken as
+	// the portfolio; links further down usually belong to certificates or projects
+	header := lines
+	for i, line := range lines {
+		if _, ok := sectionHeadings[normalizeHeading(line)]; ok {
+			header = lines[:i]
+			break
+		}
+	}
+
+	for _, u := range urlRe.FindAllString(strings.Join(header, "\n"), -1) {
+		lower := strings.ToLower(u)
+		if strings.Contains(lower, "linkedin.com") || strings.Contains(lower, "github.com") {
+			continue
+		}
+		contact.PortfolioURL = withScheme(strings.TrimRight(u, ".)"))
+		break
+	}
+
+	for _, candidate := range phoneRe.FindAllString(text, -1) {
+		candidate = strings.TrimSpace(candidate)
+		digits := countDigits(candidate)
+		if digits < 9 || digits > 15 {
+			continue
+		}
+		if !strings.HasPrefix(candidate, "+") && !strings.HasPrefix(candidate, "0") && !strings.HasPrefix(candidate, "(") {
+			continue
+		}
+		contact.Phone = candidate
+		break
+	}
+
+	// The name is usually one of the first lines: a few words, letters only
+	for i, line := range lines {
+		if i >= 5 {
+			break
+		}
+		if isName(line) {
+			contact.FullName = titleCase(strings.TrimSpace(line))
+			break
+		}
+	}
+
+	return contact
+}
+
+func isName(line string) bool {
+	line = strings.TrimSpace(line)
+	if _, ok := sectionHeadings[normalizeHeading(line)]; ok {
+		return false
+	}
+
+	words := strings.Fields(line)
+	if len(words) < 2 || len(words) > 5 {
+		return false
+	}
+	for _, r := range line {
+		if !unicode.IsLetter(r) && r != ' ' && r != '.' && r != '\'' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func parseExperiences(lines []string) []Experience {
+	var experiences []Experience
+	var pending []string
+
+	for _, line := range lines {
+		start, end, current, rest, ok := findDateRange(line)
+		if !ok {
+			pending = append(pending, line)
+			continue
+		}
+
+		// Trailing non-bullet lines belong to the header of the new entry,
+		// everything before them describes the previous entry
+		header, description := splitHeader(pending)
+		if len(experiences) > 0 {
+			experiences[len(experiences)-1].Description = joinDescription(description)
+		}
+		pending = nil
+
+		if rest != "" {
+			header = append(header, rest)
+		}
+		position, company := splitTitleCompany(header)
+
+		experiences = append(experiences, Experience{
+			Position:  position,
+			Company:   company,
+			StartDate: start,
+			EndDate:   end,
+			IsCurrent: current,
+		})
+	}
+
+	if len(experiences) > 0 {
+		experiences[len(experiences)-1].Description = joinDescription(pending)
+	}
+
+	return experiences
+}
+
+func parseEducations(lines []string) []Education {
+	var educations []Education
+
+	for _, line := range lines {
+		text := strings.TrimSpace(bulletRe.ReplaceAllString(line, ""))
+
+		if institution.MatchString(text) {
+			educations = append(educations, Education{})
+		} else if len(educations) == 0 {
+			// Details before the first institution (e.g. degree first) start an entry
+			if !degreeRe.MatchString(text) {
+				continue
+			}
+			educations = append(educations, Education{})
+		}
+		edu := &educations[len(educations)-1]
+
+		if start, end, current, rest, ok := findDateRange(text); ok {
+			edu.StartDate, edu.EndDate, edu.IsCurrent = start, end, current
+			text = rest
+		}
+
+		if m := gpaRe.FindStringSubmatch(text); m != nil {
+			if gpa, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", "."), 64); err == nil && gpa <= 4 {
+				edu.GPA = &gpa
+			}
+			text = strings.TrimSpace(gpaRe.ReplaceAllString(text, ""))
+			text = strings.TrimSpace(gpaScaleRe.ReplaceAllString(text, ""))
+			text = strings.TrimRight(text, " ,-–|")
+		}
+
+		for _, part := range splitFragments(text) {
+			switch {
+			case institution.MatchString(part) && edu.Institution == "":
+				edu.Institution = part
+			case degreeRe.MatchString(part) && edu.Degree == "":
+				edu.Degree, edu.Major = parseDegree(part)
+			case edu.Major == "" && edu.Institution != "" && !titleHint.MatchString(part):
+				edu.Major = part
+			}
+		}
+	}
+
+	return educations
+}
+
+func parseDegree(text string) (degree, major string) {
+	loc := degreeRe.FindStringIndex(text)
+	token := strings.ToLower(strings.NewReplacer(".", "", " ", "", "'s", "").Replace(text[loc[0]:loc[1]]))
+
+	switch token {
+	case "bachelor", "sarjana", "s1", "bsc", "beng", "ba", "bs":
+		degree = "Bachelor"
+	case "master", "magister", "s2", "msc", "meng", "ma", "ms", "mba":
+		degree = "Master"
+	case "doctor", "doctorate", "phd", "s3":
+		degree = "PhD"
+	case "diploma", "d3", "d4":
+		degree = "Diploma"
+	case "associate":
+		degree = "Associate"
+	default:
+		degree = strings.TrimSpace(text[loc[0]:loc[1]])
+	}
+
+	rest := strings.TrimSpace(text[loc[1]:])
+	rest = majorPrefixRe.ReplaceAllString(rest, "")
+	major = strings.Trim(rest, " ,-–()")
+	return degree, major
+}
+
+func parseSkills(lines []string) []Skill {
+	var skills []Skill
+	seen := make(map[string]bool)
+
+	for _, line := range lines {
+		line = bulletRe.ReplaceAllString(line, "")
+		// "Languages: Go, Python" -> drop the category label
+		if i := strings.Index(line, ":"); i >= 0 && i < 40 {
+			line = line[i+1:]
+		}
+
+		for _, token := range skillSepRe.Split(line, -1) {
+			name := strings.Trim(strings.TrimSpace(token), ".-–")
+			if name == "" || len(name) > maxSkillLength {
+				continue
+			}
+
+			key := strings.ToLower(name)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			skillType := "technical"
+			if softSkills[key] {
+				skillType = "soft"
+			}
+			skills = append(skills, Skill{Name: name, Type: skillType})
+
+			if len(skills) >= maxSkills {
+				return skills
+			}
+		}
+	}
+
+	return skills
+}
+
+func parseCertifications(lines []string) []Certification {
+	var certifications []Certification
+
+	for _, line := range lines {
+		text := strings.TrimSpace(bulletRe.ReplaceAllString(line, ""))
+		if text == "" {
+			continue
+		}
+
+		var cert Certification
+		if u := urlRe.FindString(text); u != "" {
+			cert.CredentialURL = withScheme(u)
+			text = strings.TrimSpace(strings.Replace(text, u, "", 1))
+		}
+
+		if loc := lastDate(text); loc != nil {
+			cert.IssueDate = parseDate(text[loc[0]:loc[1]])
+			text = strings.TrimSpace(text[:loc[0]] + text[loc[1]:])
+		}
+
+		parts := certSepRe.Split(text, -1)
+		var fragments []string
+		for _, p := range parts {
+			if p = strings.Trim(strings.TrimSpace(p), "()-–,"); p != "" {
+				fragments = append(fragments, p)
+			}
+		}
+		if len(fragments) == 0 {
+			continue
+		}
+
+		cert.Name = fragments[0]
+		if len(fragments) > 1 {
+			cert.Organizer = fragments[1]
+		}
+		certifications = append(certifications, cert)
+	}
+
+	return certifications
+}
+
+// splitHeader separates up to maxHeaderLookback trailing non-bullet lines
+// (the header of the next entry) from the preceding description lines
+func splitHeader(lines []string) (header, description []string) {
+	i := len(lines)
+	for i > 0 && len(lines)-i < maxHeaderLookback && !bulletRe.MatchString(lines[i-1]) {
+		i--
+	}
+	return lines[i:], lines[:i]
+}
+
+func splitTitleCompany(header []string) (position, company string) {
+	var fragments []string
+	for _, line := range header {
+		line = strings.TrimSpace(bulletRe.ReplaceAllString(line, ""))
+		// "Backend Engineer at Acme" / "Backend Engineer di PT Maju"
+		if parts := atRe.Split(line, 2); len(parts) == 2 {
+			fragments = append(fragments, strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+			continue
+		}
+		fragments = append(fragments, splitFragments(line)...)
+	}
+
+	switch len(fragments) {
+	case 0:
+		return "", ""
+	case 1:
+		if companyHint.MatchString(fragments[0]) && !titleHint.MatchString(fragments[0]) {
+			return "", fragments[0]
+		}
+		return fragments[0], ""
+	}
+
+	first, second := fragments[0], fragments[1]
+	if (companyHint.MatchString(first) && !titleHint.MatchString(first)) || (titleHint.MatchString(second) && !titleHint.MatchString(first)) {
+		return second, first
+	}
+	return first, second
+}
+
+// splitFragments splits a line on the separators commonly used between
+// title, company and location
+func splitFragments(line string) []string {
+	parts := fragmentSepRe.Split(line, -1)
+
+	var fragments []string
+	for _, p := range parts {
+		if p = strings.Trim(strings.TrimSpace(p), "()"); p != "" {
+			fragments = append(fragments, p)
+		}
+	}
+	return fragments
+}
+
+func cleanLines(lines []string) []string {
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line = strings.TrimSpace(bulletRe.ReplaceAllString(line, "")); line != "" {
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+func joinDescription(lines []string) string {
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+func withScheme(u string) string {
+	if u == "" || strings.HasPrefix(strings.ToLower(u), "http") {
+		return u
+	}
+	return "https://" + u
+}
+
+func countDigits(s string) int {
+	n := 0
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			n++
+		}
+	}
+	return n
+}
+
+func titleCase(s string) string {
+	if strings.ToUpper(s) != s {
+		return s
+	}
+	words := strings.Fields(strings.ToLower(s))
+	for i, w := range words {
+		runes := []rune(w)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
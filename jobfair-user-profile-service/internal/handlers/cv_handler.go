@@ -1,8 +1,11 @@
 package handlers
 
 import (
-	"net/http"
+	"errors"
 	"jobfair-user-profile-service/internal/models"
+	"mime"
+	"net/http"
+	"strconv"
 	"jobfair-user-profile-service/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -71,3 +74,120 @@ func (h *CVHandler) Delete(c *gin.Context) {
 
 	c.JSON(http.StatusOK, models.SuccessResponse("CV deleted successfully", nil))
 }
+
+// Download streams the authenticated user's own CV
+func (h *CVHandler) Download(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+		return
+	}
+
+	file, err := h.service.Download(userID.(uint), cvRequester(c))
+	h.serveFile(c, file, err)
+}
+
+// DownloadForUser streams another user's CV; companies may download CVs of their applicants
+func (h *CVHandler) DownloadForUser(c *gin.Context) {
+	if _, exists := c.Get("user_id"); !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+		return
+	}
+
+	ownerID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid user ID", "INVALID_ID", nil))
+		return
+	}
+
+	file, err := h.service.Download(uint(ownerID), cvRequester(c))
+	h.serveFile(c, file, err)
+}
+
+// CreateShareLink creates a time-limited signed download link for a CV
+func (h *CVHandler) CreateShareLink(c *gin.Context) {
+	if _, exists := c.Get("user_id"); !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+		return
+	}
+
+	ownerID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid user ID", "INVALID_ID", nil))
+		return
+	}
+
+	link, err := h.service.CreateShareLink(uint(ownerID), cvRequester(c))
+	if err != nil {
+		h.handleAccessError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse("CV share link created successfully", link))
+}
+
+// DownloadShared streams a CV using a signed share link (no authentication)
+func (h *CVHandler) DownloadShared(c *gin.Context) {
+	ownerID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid user ID", "INVALID_ID", nil))
+		return
+	}
+
+	file, err := h.service.DownloadShared(uint(ownerID), c.Request.URL.Query(), cvRequester(c))
+	h.serveFile(c, file, err)
+}
+
+// GetAccessLogs returns who downloaded the authenticated user's CV
+func (h *CVHandler) GetAccessLogs(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	logs, err := h.service.GetAccessLogs(userID.(uint), limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "FETCH_FAILED", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("CV access logs retrieved successfully", logs))
+}
+
+func (h *CVHandler) serveFile(c *gin.Context, file *services.CVFile, err error) {
+	if err != nil {
+		h.handleAccessError(c, err)
+		return
+	}
+	defer file.Reader.Close()
+
+	c.Header("Cache-Control", "private, no-store")
+	c.DataFromReader(http.StatusOK, file.Size, file.ContentType, file.Reader, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}),
+	})
+}
+
+func (h *CVHandler) handleAccessError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrCVNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse(err.Error(), "CV_NOT_FOUND", nil))
+	case errors.Is(err, services.ErrCVAccessDenied):
+		c.JSON(http.StatusForbidden, models.ErrorResponse(err.Error(), "FORBIDDEN", nil))
+	case errors.Is(err, services.ErrCVLinkInvalid):
+		c.JSON(http.StatusForbidden, models.ErrorResponse(err.Error(), "INVALID_LINK", nil))
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve CV", "DOWNLOAD_FAILED", nil))
+	}
+}
+
+func cvRequester(c *gin.Context) services.CVRequester {
+	return services.CVRequester{
+		UserID:    c.GetUint("user_id"),
+		UserType:  c.GetString("user_type"),
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
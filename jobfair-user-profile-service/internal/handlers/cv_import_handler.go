@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"jobfair-user-profile-service/internal/models"
+	"jobfair-user-profile-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CVImportHandler struct {
+	service services.CVImportService
+}
+
+func NewCVImportHandler(service services.CVImportService) *CVImportHandler {
+	return &CVImportHandler{service: service}
+}
+
+// Preview returns profile entries proposed from the user's CV for review
+func (h *CVImportHandler) Preview(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+		return
+	}
+
+	preview, err := h.service.Preview(userID.(uint))
+	if err != nil {
+		if errors.Is(err, services.ErrCVNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(err.Error(), "CV_NOT_FOUND", nil))
+			return
+		}
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "PREVIEW_FAILED", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("CV import preview generated successfully", preview))
+}
+
+// Confirm saves the reviewed CV import proposals to the user's profile
+func (h *CVImportHandler) Confirm(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+		return
+	}
+
+	var req models.CVImportConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	result, err := h.service.Confirm(userID.(uint), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "IMPORT_FAILED", nil))
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse("CV import saved successfully", result))
+}
+
+// Search finds candidates by CV content (companies only) among those who
+// applied to the company's jobs or opted in to search
+func (h *CVImportHandler) Search(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+		return
+	}
+
+	if c.GetString("user_type") != "company" {
+		c.JSON(http.StatusForbidden, models.ErrorResponse("Only companies can search CVs", "FORBIDDEN", nil))
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	results, meta, err := h.service.Search(userID.(uint), c.Query("q"), page, limit)
+	if err != nil {
+		if errors.Is(err, services.ErrSearchQueryRequired) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "SEARCH_FAILED", nil))
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "Failed to search CVs", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to search CVs", "SEARCH_FAILED", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedSuccessResponse("CVs retrieved successfully", results, *meta))
+}
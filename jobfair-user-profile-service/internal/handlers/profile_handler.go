@@ -75,7 +75,7 @@ func (h *ProfileHandler) GetProfile(c *gin.Context) {
 		return
 	}
 
-	profileWithCounts, err := h.service.GetProfileWithCounts(userID.(uint))
+	profileWithCounts, err := h.service.GetProfileWithCounts(c.Request.Context(), userID.(uint))
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.ErrorResponse("Profile not found", "NOT_FOUND", nil))
 		return
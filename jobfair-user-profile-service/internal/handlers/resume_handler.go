@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"jobfair-user-profile-service/internal/models"
+	"jobfair-user-profile-service/internal/resume"
+	"jobfair-user-profile-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ResumeHandler struct {
+	service services.ResumeService
+}
+
+func NewResumeHandler(service services.ResumeService) *ResumeHandler {
+	return &ResumeHandler{service: service}
+}
+
+// Download renders the user's profile as a PDF résumé.
+// Query: template (classic|modern|compact), save=true to also store it as the user's CV.
+func (h *ResumeHandler) Download(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+		return
+	}
+
+	save, _ := strconv.ParseBool(c.DefaultQuery("save", "false"))
+
+	result, err := h.service.Generate(userID.(uint), c.Query("template"), save)
+	if err != nil {
+		switch {
+		case errors.Is(err, resume.ErrUnknownTemplate):
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "INVALID_TEMPLATE", nil))
+		case errors.Is(err, services.ErrProfileNotFound):
+			c.JSON(http.StatusNotFound, models.ErrorResponse(err.Error(), "NOT_FOUND", nil))
+		default:
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(err.Error(), "RESUME_FAILED", nil))
+		}
+		return
+	}
+
+	// Let the client know where the saved CV can be referenced from (e.g. cv_url when applying)
+	if result.CV != nil {
+		c.Header("X-CV-Document-ID", strconv.FormatUint(uint64(result.CV.ID), 10))
+		c.Header("X-CV-URL", result.CV.FileURL)
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": result.FileName}))
+	c.Data(http.StatusOK, "application/pdf", result.Data)
+}
+
+// GetTemplates lists the available résumé templates
+func (h *ResumeHandler) GetTemplates(c *gin.Context) {
+	c.JSON(http.StatusOK, models.SuccessResponse("Resume templates retrieved successfully", h.service.Templates()))
+}
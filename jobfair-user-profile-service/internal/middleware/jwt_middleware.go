@@ -6,10 +6,17 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/jobfair/shared/identity"
 )
 
 func JWTAuthMiddleware(jwtSecret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Already authenticated by the API gateway
+		if identity.Verified(c) {
+			c.Next()
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -61,6 +68,11 @@ func JWTAuthMiddleware(jwtSecret string) gin.HandlerFunc {
 				c.Abort()
 				return
 			}
+
+			// user_type is used for role checks (e.g. company access to applicant CVs)
+			if userType, ok := claims["user_type"].(string); ok {
+				c.Set("user_type", userType)
+			}
 		}
 
 		c.Next()
@@ -5,16 +5,19 @@ import (
 )
 
 type CVDocument struct {
-	ID         uint      `gorm:"primaryKey;autoIncrement" json:"id"`
-	ProfileID  uint      `gorm:"not null;uniqueIndex" json:"profile_id"`
-	FileName   string    `gorm:"type:varchar(255);not null" json:"file_name"`
-	FileURL    string    `gorm:"type:varchar(500);not null" json:"file_url"`
-	FileSize   int64     `gorm:"type:bigint;not null" json:"file_size"`          // in bytes
-	FileType   string    `gorm:"type:varchar(50);not null" json:"file_type"`     // pdf, doc, docx
-	IsVerified bool      `gorm:"type:boolean;default:false" json:"is_verified"`
-	UploadedAt time.Time `gorm:"type:timestamp;not null" json:"uploaded_at"`
-	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID            uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProfileID     uint       `gorm:"not null;uniqueIndex" json:"profile_id"`
+	FileName      string     `gorm:"type:varchar(255);not null" json:"file_name"`
+	FileURL       string     `gorm:"type:varchar(500);not null" json:"file_url"`    // authorized download path
+	StorageKey    string     `gorm:"type:varchar(500)" json:"-"`                    // object storage key, never exposed
+	FileSize      int64      `gorm:"type:bigint;not null" json:"file_size"`         // in bytes
+	FileType      string     `gorm:"type:varchar(50);not null" json:"file_type"`    // pdf, doc, docx
+	IsVerified    bool       `gorm:"type:boolean;default:false" json:"is_verified"` // readable and looks like a CV
+	UploadedAt    time.Time  `gorm:"type:timestamp;not null" json:"uploaded_at"`
+	ParsedAt      *time.Time `gorm:"type:timestamp" json:"parsed_at"`
+	ExtractedText string     `gorm:"type:text" json:"-"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
 type CVUploadResponse struct {
@@ -26,3 +29,34 @@ type CVUploadResponse struct {
 	IsVerified bool      `json:"is_verified"`
 	UploadedAt time.Time `json:"uploaded_at"`
 }
+
+// CV access types recorded in the audit log
+const (
+	CVAccessOwner      = "owner"
+	CVAccessCompany    = "company"
+	CVAccessSharedLink = "shared_link"
+)
+
+// CVAccessLog is an audit entry for a CV download attempt
+type CVAccessLog struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	CVID           *uint     `gorm:"column:cv_id" json:"cv_id"`
+	ProfileID      uint      `gorm:"not null;index" json:"profile_id"`
+	AccessorUserID *uint     `json:"accessor_user_id"` // nil for shared links
+	AccessType     string    `gorm:"type:varchar(20);not null" json:"access_type"`
+	Granted        bool      `gorm:"not null" json:"granted"`
+	Reason         string    `gorm:"type:varchar(255)" json:"reason,omitempty"`
+	IPAddress      string    `gorm:"type:varchar(45)" json:"ip_address"`
+	UserAgent      string    `gorm:"type:varchar(500)" json:"user_agent"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (CVAccessLog) TableName() string {
+	return "cv_access_logs"
+}
+
+// CVShareLink is a time-limited signed URL for downloading a CV without authentication
+type CVShareLink struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,46 @@
+package models
+
+// CVImportPreview holds the profile data proposed from the user's CV. Nothing
+// is saved until the user confirms (a possibly edited copy of) the proposals.
+type CVImportPreview struct {
+	CVID            uint                    `json:"cv_id"`
+	Profile         ProfileUpdateRequest    `json:"profile"`
+	WorkExperiences []WorkExperienceRequest `json:"work_experiences"`
+	Educations      []EducationRequest      `json:"educations"`
+	Skills          []SkillRequest          `json:"skills"`
+	Certifications  []CertificationRequest  `json:"certifications"`
+	Warnings        []string                `json:"warnings"`
+}
+
+// CVImportConfirmRequest contains the proposals the user accepted
+type CVImportConfirmRequest struct {
+	Profile         *ProfileUpdateRequest   `json:"profile"`
+	WorkExperiences []WorkExperienceRequest `json:"work_experiences" binding:"dive"`
+	Educations      []EducationRequest      `json:"educations" binding:"dive"`
+	Skills          []SkillRequest          `json:"skills" binding:"dive"`
+	Certifications  []CertificationRequest  `json:"certifications" binding:"dive"`
+}
+
+// CVImportResult reports how many entries were created by a confirmed import
+type CVImportResult struct {
+	WorkExperiences int  `json:"work_experiences"`
+	Educations      int  `json:"educations"`
+	Skills          int  `json:"skills"`
+	SkippedSkills   int  `json:"skipped_skills"` // already on the profile
+	Certifications  int  `json:"certifications"`
+	ProfileUpdated  bool `json:"profile_updated"`
+}
+
+// CVSearchResult is a candidate matching a recruiter's CV full-text search.
+// The CV itself is only reachable through the signed CVLink.
+type CVSearchResult struct {
+	UserID     uint         `json:"user_id"`
+	ProfileID  uint         `json:"profile_id"`
+	FullName   string       `json:"full_name"`
+	Headline   string       `json:"headline"`
+	Location   string       `json:"location"`
+	Applied    bool         `json:"applied"` // Applied to one of the searching company's jobs
+	Rank       float64      `json:"rank"`
+	CVLink     *CVShareLink `json:"cv_link,omitempty"`
+	StorageKey string       `json:"-"`
+}
@@ -21,6 +21,10 @@ func (d *DateOnly) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+func (d DateOnly) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + time.Time(d).Format("2006-01-02") + `"`), nil
+}
+
 func (d *DateOnly) ToTime() *time.Time {
 	if d == nil {
 		return nil // biar aman kalau EndDate memang kosong
@@ -28,3 +32,12 @@ func (d *DateOnly) ToTime() *time.Time {
 	t := time.Time(*d)
 	return &t
 }
+
+// NewDateOnly converts an optional time to a DateOnly
+func NewDateOnly(t *time.Time) *DateOnly {
+	if t == nil {
+		return nil
+	}
+	d := DateOnly(*t)
+	return &d
+}
@@ -28,6 +28,7 @@ type Profile struct {
 	GitHubURL         string     `gorm:"column:github_url;type:varchar(255)" json:"github_url"`
 	PortfolioURL      string     `gorm:"type:varchar(255)" json:"portfolio_url"`
 	CompletionStatus  int        `gorm:"type:int;default:0" json:"completion_status"` // 0-100%
+	CVSearchable      bool       `gorm:"not null;default:false" json:"cv_searchable"`  // Any company may find the CV through search
 	CreatedAt         time.Time  `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt         time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 
@@ -63,6 +64,7 @@ type ProfileUpdateRequest struct {
 	PortfolioURL      *string    `json:"portfolio_url"`
 	ProfilePictureURL *string    `json:"profile_picture_url"`
 	BannerImageURL    *string    `json:"banner_image_url"`
+	CVSearchable      *bool      `json:"cv_searchable"`
 }
 
 // ProfileResponse extends Profile with additional computed fields
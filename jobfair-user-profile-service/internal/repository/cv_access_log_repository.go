@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"jobfair-user-profile-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type CVAccessLogRepository interface {
+	Create(log *models.CVAccessLog) error
+	GetByProfileID(profileID uint, limit int) ([]models.CVAccessLog, error)
+}
+
+type cvAccessLogRepository struct {
+	db *gorm.DB
+}
+
+func NewCVAccessLogRepository(db *gorm.DB) CVAccessLogRepository {
+	return &cvAccessLogRepository{db: db}
+}
+
+func (r *cvAccessLogRepository) Create(log *models.CVAccessLog) error {
+	return r.db.Create(log).Error
+}
+
+func (r *cvAccessLogRepository) GetByProfileID(profileID uint, limit int) ([]models.CVAccessLog, error) {
+	var logs []models.CVAccessLog
+	err := r.db.Where("profile_id = ?", profileID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&logs).Error
+	return logs, err
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"strings"
+
+	"jobfair-user-profile-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type CVImportRepository interface {
+	// SaveImport creates all confirmed entries in one transaction. Skills the
+	// profile already has (case-insensitive) are skipped; it returns how many.
+	SaveImport(profileID uint, workExps []models.WorkExperience, educations []models.Education, skills []models.Skill, certifications []models.Certification) (int, error)
+}
+
+type cvImportRepository struct {
+	db *gorm.DB
+}
+
+func NewCVImportRepository(db *gorm.DB) CVImportRepository {
+	return &cvImportRepository{db: db}
+}
+
+func (r *cvImportRepository) SaveImport(profileID uint, workExps []models.WorkExperience, educations []models.Education, skills []models.Skill, certifications []models.Certification) (int, error) {
+	skipped := 0
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if len(workExps) > 0 {
+			if err := tx.Create(&workExps).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(educations) > 0 {
+			if err := tx.Create(&educations).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(skills) > 0 {
+			var existing []string
+			if err := tx.Model(&models.Skill{}).Where("profile_id = ?", profileID).Pluck("skill_name", &existing).Error; err != nil {
+				return err
+			}
+
+			seen := make(map[string]bool, len(existing))
+			for _, name := range existing {
+				seen[strings.ToLower(name)] = true
+			}
+
+			newSkills := make([]models.Skill, 0, len(skills))
+			for _, skill := range skills {
+				key := strings.ToLower(skill.SkillName)
+				if seen[key] {
+					skipped++
+					continue
+				}
+				seen[key] = true
+				newSkills = append(newSkills, skill)
+			}
+
+			if len(newSkills) > 0 {
+				if err := tx.Create(&newSkills).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(certifications) > 0 {
+			if err := tx.Create(&certifications).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return skipped, err
+}
@@ -12,6 +12,7 @@ type CVRepository interface {
 	GetByProfileID(profileID uint) (*models.CVDocument, error)
 	Update(cv *models.CVDocument) error
 	Delete(id uint) error
+	Search(query string, applicantUserIDs []uint, page, limit int) ([]models.CVSearchResult, int64, error)
 }
 
 type cvRepository struct {
@@ -45,3 +46,39 @@ func (r *cvRepository) Update(cv *models.CVDocument) error {
 func (r *cvRepository) Delete(id uint) error {
 	return r.db.Delete(&models.CVDocument{}, "id = ?", id).Error
 }
+
+// Search runs a full-text search over extracted CV text, best matches first.
+// Only candidates who opted in to search or are among applicantUserIDs match.
+func (r *cvRepository) Search(query string, applicantUserIDs []uint, page, limit int) ([]models.CVSearchResult, int64, error) {
+	// IN () is invalid SQL; no user has ID 0
+	if len(applicantUserIDs) == 0 {
+		applicantUserIDs = []uint{0}
+	}
+
+	var total int64
+	err := r.db.Model(&models.CVDocument{}).
+		Joins("JOIN profiles p ON p.id = cv_documents.profile_id").
+		Where("cv_documents.search_vector @@ websearch_to_tsquery('simple', ?)", query).
+		Where("p.cv_searchable OR p.user_id IN ?", applicantUserIDs).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var results []models.CVSearchResult
+	err = r.db.Raw(`
+		SELECT p.user_id, p.id AS profile_id, p.full_name, p.headline, p.location,
+			p.user_id IN ? AS applied,
+			c.storage_key,
+			ts_rank(c.search_vector, q) AS rank
+		FROM cv_documents c
+		JOIN profiles p ON p.id = c.profile_id,
+			websearch_to_tsquery('simple', ?) q
+		WHERE c.search_vector @@ q
+			AND (p.cv_searchable OR p.user_id IN ?)
+		ORDER BY rank DESC, c.uploaded_at DESC
+		LIMIT ? OFFSET ?`, applicantUserIDs, query, applicantUserIDs, limit, (page-1)*limit).
+		Scan(&results).Error
+
+	return results, total, err
+}
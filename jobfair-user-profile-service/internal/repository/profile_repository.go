@@ -1,7 +1,6 @@
 package repository
 
 import (
-	"log"
 	"jobfair-user-profile-service/internal/models"
 
 	"gorm.io/gorm"
@@ -41,8 +40,6 @@ func (r *profileRepository) GetByUserID(userID uint) (*models.Profile, error) {
 	if err != nil {
 		return nil, err
 	}
-	// Debug log
-	log.Printf("[ProfileRepo] GetByUserID(%d) found profile: ID=%d", userID, profile.ID)
 	return &profile, nil
 }
 
@@ -0,0 +1,333 @@
+// Package resume renders a structured profile into a PDF résumé.
+package resume
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"jobfair-user-profile-service/internal/models"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// ErrUnknownTemplate is returned for a template name that does not exist
+var ErrUnknownTemplate = errors.New("unknown resume template")
+
+// Render writes the profile as a PDF résumé to w using the named template.
+// The profile should be loaded with its relations.
+func Render(w io.Writer, profile *models.Profile, templateName string) error {
+	t, err := Lookup(templateName)
+	if err != nil {
+		return err
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(t.margin, t.margin, t.margin)
+	pdf.SetAutoPageBreak(true, t.margin)
+	pdf.SetTitle(displayName(profile)+" - Resume", true)
+	pdf.SetCreator("JobFair", true)
+	pdf.AddPage()
+
+	r := &renderer{
+		pdf: pdf,
+		t:   t,
+		// Core PDF fonts are cp1252; translate UTF-8 input so names with
+		// accents render instead of turning into mojibake
+		tr: pdf.UnicodeTranslatorFromDescriptor(""),
+	}
+
+	r.header(profile)
+	r.summary(profile)
+	r.experiences(profile.WorkExperiences)
+	r.educations(profile.Educations)
+	r.skills(profile.Skills)
+	r.certifications(profile.Certifications)
+	r.preferences(profile)
+
+	if err := pdf.Error(); err != nil {
+		return fmt.Errorf("failed to render resume: %w", err)
+	}
+	return pdf.Output(w)
+}
+
+type renderer struct {
+	pdf *fpdf.Fpdf
+	t   Template
+	tr  func(string) string
+}
+
+func (r *renderer) header(p *models.Profile) {
+	pdf, t := r.pdf, r.t
+	width, _ := pdf.GetPageSize()
+	contentWidth := width - 2*t.margin
+
+	if t.headerBand {
+		pdf.SetFillColor(t.accent[0], t.accent[1], t.accent[2])
+		pdf.Rect(0, 0, width, t.margin+t.nameSize*0.8, "F")
+		pdf.SetTextColor(255, 255, 255)
+	} else {
+		pdf.SetTextColor(0, 0, 0)
+	}
+
+	pdf.SetFont(t.fontFamily, "B", t.nameSize)
+	pdf.CellFormat(contentWidth, t.nameSize*0.5, r.tr(displayName(p)), "", 1, "L", false, 0, "")
+
+	if p.Headline != "" {
+		pdf.SetFont(t.fontFamily, "", t.bodySize+1)
+		pdf.CellFormat(contentWidth, t.lineHeight+1, r.tr(p.Headline), "", 1, "L", false, 0, "")
+	}
+
+	if t.headerBand {
+		pdf.SetY(t.margin + t.nameSize*0.8 + 3)
+	}
+	pdf.SetTextColor(60, 60, 60)
+	pdf.SetFont(t.fontFamily, "", t.bodySize)
+
+	contact := joinNonEmpty(" | ", p.PhoneNumber, location(p), p.LinkedInURL, p.GitHubURL, p.PortfolioURL)
+	if contact != "" {
+		pdf.MultiCell(contentWidth, t.lineHeight, r.tr(contact), "", "L", false)
+	}
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(2)
+}
+
+func (r *renderer) summary(p *models.Profile) {
+	text := strings.TrimSpace(p.Summary)
+	if text == "" {
+		text = strings.TrimSpace(p.Bio)
+	}
+	if text == "" {
+		return
+	}
+	r.heading("Summary")
+	r.paragraph(text)
+}
+
+func (r *renderer) experiences(items []models.WorkExperience) {
+	if len(items) == 0 {
+		return
+	}
+	sorted := append([]models.WorkExperience(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].StartDate.After(sorted[j].StartDate) })
+
+	r.heading("Work Experience")
+	for _, e := range sorted {
+		r.entry(joinNonEmpty(" - ", e.JobPosition, e.CompanyName), dateRange(e.StartDate, e.EndDate, e.IsCurrentJob), "")
+		r.bullets(e.JobDescription)
+	}
+}
+
+func (r *renderer) educations(items []models.Education) {
+	if len(items) == 0 {
+		return
+	}
+	sorted := append([]models.Education(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].StartDate.After(sorted[j].StartDate) })
+
+	r.heading("Education")
+	for _, e := range sorted {
+		subtitle := joinNonEmpty(", ", e.Degree, e.Major)
+		if e.GPA != nil {
+			subtitle = joinNonEmpty(" - ", subtitle, fmt.Sprintf("GPA %.2f", *e.GPA))
+		}
+		r.entry(e.University, dateRange(e.StartDate, e.EndDate, e.IsCurrent), subtitle)
+		r.bullets(e.Description)
+	}
+}
+
+func (r *renderer) skills(items []models.Skill) {
+	if len(items) == 0 {
+		return
+	}
+
+	var technical, soft []string
+	for _, s := range items {
+		name := s.SkillName
+		if s.ProficiencyLevel != "" {
+			name += " (" + s.ProficiencyLevel + ")"
+		}
+		if s.SkillType == "soft" {
+			soft = append(soft, name)
+		} else {
+			technical = append(technical, name)
+		}
+	}
+
+	r.heading("Skills")
+	r.labeled("Technical", strings.Join(technical, ", "))
+	r.labeled("Soft skills", strings.Join(soft, ", "))
+	r.pdf.Ln(1)
+}
+
+func (r *renderer) certifications(items []models.Certification) {
+	if len(items) == 0 {
+		return
+	}
+	sorted := append([]models.Certification(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].IssueDate.After(sorted[j].IssueDate) })
+
+	r.heading("Certifications")
+	for _, c := range sorted {
+		r.entry(joinNonEmpty(" - ", c.CertificationName, c.Organizer), c.IssueDate.Format("Jan 2006"), c.CredentialURL)
+		r.bullets(c.Description)
+	}
+}
+
+func (r *renderer) preferences(p *models.Profile) {
+	positions := make([]string, 0, len(p.PositionPreferences))
+	prefs := append([]models.PositionPreference(nil), p.PositionPreferences...)
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].Priority < prefs[j].Priority })
+	for _, pp := range prefs {
+		positions = append(positions, pp.PositionName)
+	}
+
+	var workTypes, locations string
+	if cp := p.CareerPreference; cp != nil {
+		workTypes = strings.ReplaceAll(cp.PreferredWorkTypes, ",", ", ")
+		locations = strings.ReplaceAll(cp.PreferredLocations, ",", ", ")
+		if cp.WillingToRelocate {
+			locations = joinNonEmpty(" - ", locations, "willing to relocate")
+		}
+	}
+
+	if len(positions) == 0 && workTypes == "" && locations == "" {
+		return
+	}
+
+	r.heading("Career Preferences")
+	r.labeled("Positions", strings.Join(positions, ", "))
+	r.labeled("Work type", workTypes)
+	r.labeled("Locations", locations)
+}
+
+func (r *renderer) heading(title string) {
+	pdf, t := r.pdf, r.t
+	pdf.Ln(2)
+	pdf.SetFont(t.fontFamily, "B", t.headingSize)
+	pdf.SetTextColor(t.accent[0], t.accent[1], t.accent[2])
+	pdf.CellFormat(0, t.lineHeight+1.5, r.tr(strings.ToUpper(title)), "", 1, "L", false, 0, "")
+
+	left, _, right, _ := pdf.GetMargins()
+	width, _ := pdf.GetPageSize()
+	pdf.SetDrawColor(t.accent[0], t.accent[1], t.accent[2])
+	pdf.Line(left, pdf.GetY(), width-right, pdf.GetY())
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(1.5)
+}
+
+// entry writes a bold title with a right-aligned date and an optional subtitle
+func (r *renderer) entry(title, dates, subtitle string) {
+	pdf, t := r.pdf, r.t
+	left, _, right, _ := pdf.GetMargins()
+	width, _ := pdf.GetPageSize()
+	contentWidth := width - left - right
+
+	pdf.SetFont(t.fontFamily, "", t.bodySize)
+	datesWidth := pdf.GetStringWidth(r.tr(dates)) + 1
+
+	pdf.SetFont(t.fontFamily, "B", t.bodySize)
+	pdf.CellFormat(contentWidth-datesWidth, t.lineHeight, r.tr(title), "", 0, "L", false, 0, "")
+	pdf.SetFont(t.fontFamily, "", t.bodySize)
+	pdf.CellFormat(datesWidth, t.lineHeight, r.tr(dates), "", 1, "R", false, 0, "")
+
+	if subtitle != "" {
+		pdf.SetFont(t.fontFamily, "I", t.bodySize)
+		pdf.MultiCell(contentWidth, t.lineHeight, r.tr(subtitle), "", "L", false)
+		pdf.SetFont(t.fontFamily, "", t.bodySize)
+	}
+}
+
+func (r *renderer) paragraph(text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		r.pdf.Ln(1)
+		return
+	}
+	r.pdf.SetFont(r.t.fontFamily, "", r.t.bodySize)
+	r.pdf.MultiCell(0, r.t.lineHeight, r.tr(text), "", "L", false)
+	r.pdf.Ln(1.5)
+}
+
+// bullets writes each line of text as a bullet point
+func (r *renderer) bullets(text string) {
+	pdf, t := r.pdf, r.t
+	pdf.SetFont(t.fontFamily, "", t.bodySize)
+
+	bullet := r.tr("• ")
+	indent := pdf.GetStringWidth(bullet) + 1
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-*•"))
+		if line == "" {
+			continue
+		}
+		pdf.CellFormat(indent, t.lineHeight, bullet, "", 0, "L", false, 0, "")
+		pdf.MultiCell(0, t.lineHeight, r.tr(line), "", "L", false)
+	}
+	pdf.Ln(1.5)
+}
+
+func (r *renderer) labeled(label, value string) {
+	if value == "" {
+		return
+	}
+	pdf, t := r.pdf, r.t
+	pdf.SetFont(t.fontFamily, "B", t.bodySize)
+	labelText := r.tr(label + ": ")
+	pdf.CellFormat(pdf.GetStringWidth(labelText)+1, t.lineHeight, labelText, "", 0, "L", false, 0, "")
+	pdf.SetFont(t.fontFamily, "", t.bodySize)
+	pdf.MultiCell(0, t.lineHeight, r.tr(value), "", "L", false)
+}
+
+// FileName returns the file name for the profile's résumé, e.g. "Ayu_Pratiwi_Resume.pdf"
+func FileName(profile *models.Profile) string {
+	name := displayName(profile)
+	if name == "Resume" {
+		return "Resume.pdf"
+	}
+	return strings.Join(strings.Fields(name), "_") + "_Resume.pdf"
+}
+
+func displayName(p *models.Profile) string {
+	if name := strings.TrimSpace(p.FullName); name != "" {
+		return name
+	}
+	if name := joinNonEmpty(" ", p.FirstName, p.LastName); name != "" {
+		return name
+	}
+	return "Resume"
+}
+
+func location(p *models.Profile) string {
+	if p.Location != "" {
+		return p.Location
+	}
+	return joinNonEmpty(", ", p.City, p.Province, p.Country)
+}
+
+func dateRange(start time.Time, end *time.Time, current bool) string {
+	if start.IsZero() {
+		return ""
+	}
+	switch {
+	case current:
+		return start.Format("Jan 2006") + " - Present"
+	case end != nil:
+		return start.Format("Jan 2006") + " - " + end.Format("Jan 2006")
+	default:
+		return start.Format("Jan 2006")
+	}
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	kept := parts[:0:0]
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
+}
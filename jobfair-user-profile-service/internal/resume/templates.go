@@ -0,0 +1,80 @@
+package resume
+
+import "sort"
+
+// Template controls the look of a generated résumé
+type Template struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	fontFamily  string
+	accent      [3]int
+	headerBand  bool    // draw the name on a filled accent band
+	margin      float64 // page margin in mm
+	nameSize    float64
+	headingSize float64
+	bodySize    float64
+	lineHeight  float64
+}
+
+// DefaultTemplate is used when no template is requested
+const DefaultTemplate = "classic"
+
+var templates = map[string]Template{
+	"classic": {
+		Name:        "classic",
+		Description: "Serif single column with black headings",
+		fontFamily:  "Times",
+		accent:      [3]int{0, 0, 0},
+		margin:      20,
+		nameSize:    22,
+		headingSize: 13,
+		bodySize:    11,
+		lineHeight:  5.5,
+	},
+	"modern": {
+		Name:        "modern",
+		Description: "Sans-serif with a colored header band and accent headings",
+		fontFamily:  "Helvetica",
+		accent:      [3]int{37, 99, 235},
+		headerBand:  true,
+		margin:      18,
+		nameSize:    24,
+		headingSize: 12,
+		bodySize:    10,
+		lineHeight:  5,
+	},
+	"compact": {
+		Name:        "compact",
+		Description: "Dense sans-serif layout that fits more on one page",
+		fontFamily:  "Helvetica",
+		accent:      [3]int{55, 65, 81},
+		margin:      12,
+		nameSize:    18,
+		headingSize: 10.5,
+		bodySize:    9,
+		lineHeight:  4.2,
+	},
+}
+
+// Templates lists the available templates sorted by name
+func Templates() []Template {
+	list := make([]Template, 0, len(templates))
+	for _, t := range templates {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Lookup returns the named template; an empty name selects DefaultTemplate
+func Lookup(name string) (Template, error) {
+	if name == "" {
+		name = DefaultTemplate
+	}
+	t, ok := templates[name]
+	if !ok {
+		return Template{}, ErrUnknownTemplate
+	}
+	return t, nil
+}
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"jobfair-user-profile-service/internal/config"
+	"jobfair-user-profile-service/internal/cvparser"
+	"jobfair-user-profile-service/internal/models"
+	"jobfair-user-profile-service/internal/repository"
+
+	"github.com/jobfair/shared/storage"
+)
+
+// maxCVImportFileSize bounds how much of a stored CV is read back into
+// memory to extract text from it
+const maxCVImportFileSize = 20 << 20
+
+// ErrSearchQueryRequired is returned for a CV search without search terms
+var ErrSearchQueryRequired = errors.New("search query is required")
+
+type CVImportService interface {
+	// Preview proposes profile entries parsed from the user's CV without saving anything
+	Preview(userID uint) (*models.CVImportPreview, error)
+	// Confirm saves the proposals the user accepted
+	Confirm(userID uint, req *models.CVImportConfirmRequest) (*models.CVImportResult, error)
+	// Search finds candidates by the text of their CVs among those who applied
+	// to the employer's jobs or opted in to search
+	Search(employerUserID uint, query string, page, limit int) ([]models.CVSearchResult, *models.PaginationMeta, error)
+}
+
+type cvImportService struct {
+	cvRepo         repository.CVRepository
+	importRepo     repository.CVImportRepository
+	profileService ProfileService
+	store          storage.Store
+	signer         *storage.Signer
+	config         *config.Config
+	httpClient     *http.Client
+}
+
+func NewCVImportService(cvRepo repository.CVRepository, importRepo repository.CVImportRepository, profileService ProfileService, store storage.Store, httpClient *http.Client, cfg *config.Config) CVImportService {
+	return &cvImportService{
+		cvRepo:         cvRepo,
+		importRepo:     importRepo,
+		profileService: profileService,
+		store:          store,
+		signer:         storage.NewSigner(cfg.CVLinkSecret),
+		config:         cfg,
+		httpClient:     httpClient,
+	}
+}
+
+func (s *cvImportService) Preview(userID uint) (*models.CVImportPreview, error) {
+	profile, err := s.profileService.GetProfile(userID)
+	if err != nil {
+		return nil, errors.New("profile not found")
+	}
+
+	cv, err := s.cvRepo.GetByProfileID(profile.ID)
+	if err != nil {
+		return nil, ErrCVNotFound
+	}
+
+	var result *cvparser.Result
+	if cv.ParsedAt != nil {
+		result = cvparser.Parse(cv.ExtractedText)
+	} else {
+		// CVs uploaded before text extraction existed are parsed on first preview
+		if result, err = s.extractStored(cv); err != nil {
+			return nil, err
+		}
+	}
+
+	preview := buildPreview(cv.ID, result)
+	if strings.TrimSpace(cv.ExtractedText) == "" {
+		preview.Warnings = append(preview.Warnings, "No text could be read from the CV. Scanned documents and .doc files are not supported.")
+	}
+
+	return preview, nil
+}
+
+func (s *cvImportService) Confirm(userID uint, req *models.CVImportConfirmRequest) (*models.CVImportResult, error) {
+	profile, err := s.profileService.GetOrCreateProfile(userID)
+	if err != nil {
+		return nil, errors.New("failed to get or create profile: " + err.Error())
+	}
+
+	workExps := make([]models.WorkExperience, 0, len(req.WorkExperiences))
+	for _, w := range req.WorkExperiences {
+		workExps = append(workExps, models.WorkExperience{
+			ProfileID:      profile.ID,
+			CompanyName:    w.CompanyName,
+			JobPosition:    w.JobPosition,
+			StartDate:      *w.StartDate.ToTime(),
+			EndDate:        w.EndDate.ToTime(),
+			IsCurrentJob:   w.IsCurrentJob,
+			JobDescription: w.JobDescription,
+		})
+	}
+
+	educations := make([]models.Education, 0, len(req.Educations))
+	for _, e := range req.Educations {
+		educations = append(educations, models.Education{
+			ProfileID:   profile.ID,
+			University:  e.University,
+			Major:       e.Major,
+			Degree:      e.Degree,
+			StartDate:   *e.StartDate.ToTime(),
+			EndDate:     e.EndDate.ToTime(),
+			IsCurrent:   e.IsCurrent,
+			GPA:         e.GPA,
+			Description: e.Description,
+		})
+	}
+
+	skills := make([]models.Skill, 0, len(req.Skills))
+	for _, sk := range req.Skills {
+		skills = append(skills, models.Skill{
+			ProfileID:         profile.ID,
+			SkillName:         sk.SkillName,
+			SkillType:         sk.SkillType,
+			ProficiencyLevel:  sk.ProficiencyLevel,
+			YearsOfExperience: sk.YearsOfExperience,
+		})
+	}
+
+	certifications := make([]models.Certification, 0, len(req.Certifications))
+	for _, c := range req.Certifications {
+		certifications = append(certifications, models.Certification{
+			ProfileID:         profile.ID,
+			CertificationName: c.CertificationName,
+			Organizer:         c.Organizer,
+			IssueDate:         *c.IssueDate.ToTime(),
+			ExpiryDate:        c.ExpiryDate.ToTime(),
+			CredentialID:      c.CredentialID,
+			CredentialURL:     c.CredentialURL,
+			Description:       c.Description,
+		})
+	}
+
+	skipped, err := s.importRepo.SaveImport(profile.ID, workExps, educations, skills, certifications)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.CVImportResult{
+		WorkExperiences: len(workExps),
+		Educations:      len(educations),
+		Skills:          len(skills) - skipped,
+		SkippedSkills:   skipped,
+		Certifications:  len(certifications),
+	}
+
+	// Contact fields go through the regular profile update
+	if req.Profile != nil {
+		if _, err := s.profileService.UpdateProfile(userID, req.Profile); err != nil {
+			return nil, fmt.Errorf("entries were imported but the profile update failed: %w", err)
+		}
+		result.ProfileUpdated = true
+	}
+
+	s.profileService.UpdateCompletionStatus(userID)
+	return result, nil
+}
+
+func (s *cvImportService) Search(employerUserID uint, query string, page, limit int) ([]models.CVSearchResult, *models.PaginationMeta, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil, ErrSearchQueryRequired
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	applicants, err := s.applicantUserIDs(employerUserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load the company's applicants: %w", err)
+	}
+
+	results, total, err := s.cvRepo.Search(query, applicants, page, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Recruiters get signed, expiring links instead of the CV's address
+	for i := range results {
+		if results[i].StorageKey != "" {
+			results[i].CVLink = newShareLink(s.signer, s.config.CVLinkTTL, results[i].UserID, results[i].StorageKey)
+		}
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return results, &models.PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// applicantUserIDs asks the job service which users applied to any job posted by the employer
+func (s *cvImportService) applicantUserIDs(employerUserID uint) ([]uint, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/stats/employer/%d/applicants", s.config.JobServiceURL, employerUserID)
+
+	resp, err := getJobService(context.Background(), s.httpClient, s.config.Identity.Secret, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("job service returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Success bool   `json:"success"`
+		Data    []uint `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if !result.Success {
+		return nil, errors.New("job service reported a failure")
+	}
+
+	return result.Data, nil
+}
+
+// extractStored reads the CV back from storage, extracts its text and saves it
+func (s *cvImportService) extractStored(cv *models.CVDocument) (*cvparser.Result, error) {
+	reader, _, err := s.store.Get(context.Background(), cv.StorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CV file: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxCVImportFileSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CV file: %w", err)
+	}
+
+	result := extractCVText(cv, bytes.NewReader(data), int64(len(data)))
+	if result == nil {
+		return cvparser.Parse(""), nil
+	}
+
+	if err := s.cvRepo.Update(cv); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// buildPreview converts parser output into the request shapes the confirm
+// endpoint accepts, so the client can edit and send them back as-is
+func buildPreview(cvID uint, result *cvparser.Result) *models.CVImportPreview {
+	preview := &models.CVImportPreview{
+		CVID:            cvID,
+		WorkExperiences: []models.WorkExperienceRequest{},
+		Educations:      []models.EducationRequest{},
+		Skills:          []models.SkillRequest{},
+		Certifications:  []models.CertificationRequest{},
+		Warnings:        []string{},
+	}
+
+	contact := result.Contact
+	preview.Profile = models.ProfileUpdateRequest{
+		FullName:     optional(contact.FullName),
+		PhoneNumber:  optional(contact.Phone),
+		LinkedInURL:  optional(contact.LinkedInURL),
+		GitHubURL:    optional(contact.GitHubURL),
+		PortfolioURL: optional(contact.PortfolioURL),
+		Summary:      optional(result.Summary),
+	}
+
+	for _, e := range result.Experiences {
+		preview.WorkExperiences = append(preview.WorkExperiences, models.WorkExperienceRequest{
+			CompanyName:    e.Company,
+			JobPosition:    e.Position,
+			StartDate:      models.NewDateOnly(e.StartDate),
+			EndDate:        models.NewDateOnly(e.EndDate),
+			IsCurrentJob:   e.IsCurrent,
+			JobDescription: e.Description,
+		})
+		if e.Company == "" || e.Position == "" || e.StartDate == nil {
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf("Work experience %q is missing the company, position or start date", strings.TrimSpace(e.Position+" "+e.Company)))
+		}
+	}
+
+	for _, e := range result.Educations {
+		preview.Educations = append(preview.Educations, models.EducationRequest{
+			University: e.Institution,
+			Major:      e.Major,
+			Degree:     e.Degree,
+			StartDate:  models.NewDateOnly(e.StartDate),
+			EndDate:    models.NewDateOnly(e.EndDate),
+			IsCurrent:  e.IsCurrent,
+			GPA:        e.GPA,
+		})
+		if e.StartDate == nil || e.Major == "" {
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf("Education %q is missing the start date or major", e.Institution))
+		}
+	}
+
+	for _, sk := range result.Skills {
+		preview.Skills = append(preview.Skills, models.SkillRequest{
+			SkillName: sk.Name,
+			SkillType: sk.Type,
+		})
+	}
+
+	for _, c := range result.Certifications {
+		preview.Certifications = append(preview.Certifications, models.CertificationRequest{
+			CertificationName: c.Name,
+			Organizer:         c.Organizer,
+			IssueDate:         models.NewDateOnly(c.IssueDate),
+			CredentialURL:     c.CredentialURL,
+		})
+		if c.Organizer == "" || c.IssueDate == nil {
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf("Certification %q is missing the organizer or issue date", c.Name))
+		}
+	}
+
+	return preview
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
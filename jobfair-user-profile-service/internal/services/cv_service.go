@@ -1,36 +1,96 @@
 package services
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"jobfair-user-profile-service/internal/config"
+	"jobfair-user-profile-service/internal/cvparser"
 	"jobfair-user-profile-service/internal/models"
 	"jobfair-user-profile-service/internal/repository"
+	"log/slog"
+	"mime"
 	"mime/multipart"
-	"os"
+	"net/http"
+	"net/url"
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/jobfair/shared/storage"
+	"github.com/jobfair/shared/upload"
+)
+
+var (
+	// ErrCVNotFound is returned when the user has no CV
+	ErrCVNotFound = errors.New("CV not found")
+	// ErrCVAccessDenied is returned when the requester may not download the CV
+	ErrCVAccessDenied = errors.New("you are not allowed to access this CV")
+	// ErrCVLinkInvalid is returned for expired or tampered share links
+	ErrCVLinkInvalid = errors.New("CV link is invalid or has expired")
 )
 
+const maxAccessLogLimit = 200
+
 type CVService interface {
 	Upload(userID uint, file *multipart.FileHeader) (*models.CVDocument, error)
 	Get(userID uint) (*models.CVDocument, error)
 	Delete(userID uint) error
+	// SaveGenerated stores a server-generated file (e.g. a résumé PDF) as the user's CV
+	SaveGenerated(userID uint, fileName string, data []byte) (*models.CVDocument, error)
+
+	// Download opens the CV of ownerUserID for an authenticated requester
+	Download(ownerUserID uint, requester CVRequester) (*CVFile, error)
+	// DownloadShared opens the CV of ownerUserID using a signed share link
+	DownloadShared(ownerUserID uint, query url.Values, requester CVRequester) (*CVFile, error)
+	// CreateShareLink creates a time-limited signed download link
+	CreateShareLink(ownerUserID uint, requester CVRequester) (*models.CVShareLink, error)
+	// GetAccessLogs returns the download audit trail of the user's CV
+	GetAccessLogs(userID uint, limit int) ([]models.CVAccessLog, error)
+}
+
+// CVRequester identifies who is requesting a CV, for authorization and auditing
+type CVRequester struct {
+	UserID    uint // zero for unauthenticated shared-link downloads
+	UserType  string
+	IPAddress string
+	UserAgent string
+}
+
+// CVFile is an opened CV ready to be streamed. The caller must close Reader.
+type CVFile struct {
+	Reader      io.ReadCloser
+	FileName    string
+	ContentType string
+	Size        int64
 }
 
 type cvService struct {
 	repo           repository.CVRepository
+	accessLogRepo  repository.CVAccessLogRepository
 	profileService ProfileService
+	store          storage.Store
+	uploads        *upload.Pipeline
+	signer         *storage.Signer
 	config         *config.Config
+	httpClient     *http.Client
 }
 
-func NewCVService(repo repository.CVRepository, profileService ProfileService, cfg *config.Config) CVService {
+func NewCVService(repo repository.CVRepository, accessLogRepo repository.CVAccessLogRepository, profileService ProfileService, store storage.Store, uploads *upload.Pipeline, httpClient *http.Client, cfg *config.Config) CVService {
 	return &cvService{
 		repo:           repo,
+		accessLogRepo:  accessLogRepo,
 		profileService: profileService,
+		store:          store,
+		uploads:        uploads,
+		signer:         storage.NewSigner(cfg.CVLinkSecret),
 		config:         cfg,
+		httpClient:     httpClient,
 	}
 }
 
@@ -41,78 +101,98 @@ func (s *cvService) Upload(userID uint, file *multipart.FileHeader) (*models.CVD
 		return nil, errors.New("failed to get or create profile: " + err.Error())
 	}
 
-	// Validate file size
-	if file.Size > s.config.MaxFileSize {
-		return nil, fmt.Errorf("file size exceeds maximum allowed size of %d bytes", s.config.MaxFileSize)
-	}
-
-	// Validate file type
-	ext := strings.ToLower(filepath.Ext(file.Filename))
-	if !strings.Contains(s.config.AllowedFileTypes, ext) {
-		return nil, errors.New("file type not allowed. Allowed types: " + s.config.AllowedFileTypes)
+	// Validate (content type, size, malware scan) and store the file
+	ctx := context.Background()
+	key, err := cvKey(profile.ID, "")
+	if err != nil {
+		return nil, err
 	}
 
-	// Create upload directory if not exists
-	if err := os.MkdirAll(s.config.UploadDir, 0755); err != nil {
+	result, err := s.uploads.ProcessFile(ctx, file, s.uploadKind(), key)
+	if err != nil {
+		slog.Warn("Failed to store CV", "profile_id", profile.ID, "error", err)
+		if errors.Is(err, upload.ErrTypeNotAllowed) {
+			return nil, errors.New("file type not allowed. Allowed types: " + s.config.AllowedFileTypes)
+		}
 		return nil, err
 	}
 
-	// Generate unique filename
-	filename := fmt.Sprintf("%d_%d%s", profile.ID, time.Now().Unix(), ext)
-	filePath := filepath.Join(s.config.UploadDir, filename)
-
-	fmt.Printf("[CV Upload] Saving file to: %s\n", filePath)
+	return s.save(ctx, userID, profile, file.Filename, result.Ext, result.Key, result.Data)
+}
 
-	// Save file
-	src, err := file.Open()
+func (s *cvService) SaveGenerated(userID uint, fileName string, data []byte) (*models.CVDocument, error) {
+	profile, err := s.profileService.GetOrCreateProfile(userID)
 	if err != nil {
-		return nil, err
+		return nil, errors.New("failed to get or create profile: " + err.Error())
 	}
-	defer src.Close()
 
-	dst, err := os.Create(filePath)
+	ext := strings.ToLower(filepath.Ext(fileName))
+	key, err := cvKey(profile.ID, ext)
 	if err != nil {
 		return nil, err
 	}
-	defer dst.Close()
 
-	if _, err := io.Copy(dst, src); err != nil {
-		fmt.Printf("[CV Upload ERROR] Failed to copy file: %v\n", err)
+	ctx := context.Background()
+	if _, err := s.store.Put(ctx, key, bytes.NewReader(data), int64(len(data)), mime.TypeByExtension(ext)); err != nil {
+		slog.Error("Failed to store generated CV", "profile_id", profile.ID, "error", err)
 		return nil, err
 	}
 
-	fmt.Printf("[CV Upload] File saved successfully: %s\n", filePath)
+	return s.save(ctx, userID, profile, fileName, ext, key, data)
+}
+
+// uploadKind is the CV upload kind limited to MAX_FILE_SIZE and ALLOWED_FILE_TYPES
+func (s *cvService) uploadKind() upload.Kind {
+	kind := upload.CV
+	kind.MaxSize = s.config.MaxFileSize
+	kind.AllowedTypes = nil
+
+	allowed := strings.Split(strings.ToLower(s.config.AllowedFileTypes), ",")
+	for _, contentType := range upload.CV.AllowedTypes {
+		for _, ext := range allowed {
+			if strings.TrimSpace(ext) == upload.Extension(contentType) {
+				kind.AllowedTypes = append(kind.AllowedTypes, contentType)
+				break
+			}
+		}
+	}
+	return kind
+}
 
+// save creates or replaces the profile's CV record for a stored file. The
+// stored object is removed again if the record can't be saved.
+func (s *cvService) save(ctx context.Context, userID uint, profile *models.Profile, fileName, ext, key string, data []byte) (*models.CVDocument, error) {
 	// Check if CV already exists for this profile
 	existing, _ := s.repo.GetByProfileID(profile.ID)
 
-	// Save URL path for HTTP access (relative path with /uploads prefix)
-	fileURL := fmt.Sprintf("/uploads/cv/%s", filename)
+	// CVs are private: expose the authorized download endpoint, not the object URL
+	fileURL := cvDownloadPath(userID)
 
 	cvDoc := &models.CVDocument{
 		ProfileID:  profile.ID,
-		FileName:   file.Filename,
+		FileName:   fileName,
 		FileURL:    fileURL,
-		FileSize:   file.Size,
+		StorageKey: key,
+		FileSize:   int64(len(data)),
 		FileType:   ext,
 		IsVerified: false,
 		UploadedAt: time.Now(),
 	}
 
+	// Extract the text for profile autofill and recruiter search
+	extractCVText(cvDoc, bytes.NewReader(data), int64(len(data)))
+
+	var err error
 	if existing != nil && existing.ID != 0 {
 		// Update existing CV
 		cvDoc.ID = existing.ID
+		err = s.repo.Update(cvDoc)
 
-		// Delete old physical file
-		if existing.FileURL != "" {
-			// Extract filename from old URL
-			oldFilename := filepath.Base(existing.FileURL)
-			oldFilePath := filepath.Join(s.config.UploadDir, oldFilename)
-			os.Remove(oldFilePath)
-			fmt.Printf("[CV Upload] Deleted old file: %s\n", oldFilePath)
+		// Delete old stored file once the record points at the new one
+		if err == nil && existing.StorageKey != "" && existing.StorageKey != key {
+			s.store.Delete(ctx, existing.StorageKey)
+			slog.Debug("Old CV file deleted", "key", existing.StorageKey)
 		}
-
-		err = s.repo.Update(cvDoc)
 	} else {
 		// Create new CV record
 		err = s.repo.Create(cvDoc)
@@ -120,12 +200,12 @@ func (s *cvService) Upload(userID uint, file *multipart.FileHeader) (*models.CVD
 
 	if err != nil {
 		// Cleanup uploaded file if database operation fails
-		os.Remove(filePath)
-		fmt.Printf("[CV Upload ERROR] Database save failed, file removed: %v\n", err)
+		s.store.Delete(ctx, key)
+		slog.Error("Failed to save CV, file removed", "profile_id", profile.ID, "error", err)
 		return nil, err
 	}
 
-	fmt.Printf("[CV Upload] ✅ CV uploaded successfully! URL: %s\n", fileURL)
+	slog.Info("CV uploaded", "profile_id", profile.ID, "url", fileURL)
 
 	s.profileService.UpdateCompletionStatus(userID)
 	return cvDoc, nil
@@ -139,7 +219,7 @@ func (s *cvService) Get(userID uint) (*models.CVDocument, error) {
 
 	cv, err := s.repo.GetByProfileID(profile.ID)
 	if err != nil {
-		return nil, errors.New("CV not found")
+		return nil, ErrCVNotFound
 	}
 	return cv, nil
 }
@@ -152,16 +232,16 @@ func (s *cvService) Delete(userID uint) error {
 
 	cv, err := s.repo.GetByProfileID(profile.ID)
 	if err != nil {
-		return errors.New("CV not found")
+		return ErrCVNotFound
 	}
 
 	// Delete file from storage
-	if cv.FileURL != "" {
-		// Extract filename from URL
-		filename := filepath.Base(cv.FileURL)
-		filePath := filepath.Join(s.config.UploadDir, filename)
-		os.Remove(filePath)
-		fmt.Printf("[CV Delete] File removed: %s\n", filePath)
+	if cv.StorageKey != "" {
+		if err := s.store.Delete(context.Background(), cv.StorageKey); err != nil {
+			slog.Warn("Failed to delete CV file", "key", cv.StorageKey, "error", err)
+		} else {
+			slog.Debug("CV file deleted", "key", cv.StorageKey)
+		}
 	}
 
 	err = s.repo.Delete(cv.ID)
@@ -172,3 +252,224 @@ func (s *cvService) Delete(userID uint) error {
 	s.profileService.UpdateCompletionStatus(userID)
 	return nil
 }
+
+func (s *cvService) Download(ownerUserID uint, requester CVRequester) (*CVFile, error) {
+	profile, cv, err := s.getOwnerCV(ownerUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessType, err := s.authorize(ownerUserID, requester)
+	s.logAccess(profile, cv, requester, accessType, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.open(cv)
+}
+
+func (s *cvService) DownloadShared(ownerUserID uint, query url.Values, requester CVRequester) (*CVFile, error) {
+	profile, cv, err := s.getOwnerCV(ownerUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The signature covers the storage key, so replacing the CV revokes old links
+	if err := s.signer.Verify(shareResource(ownerUserID, cv.StorageKey), query); err != nil {
+		s.logAccess(profile, cv, requester, models.CVAccessSharedLink, ErrCVLinkInvalid)
+		return nil, ErrCVLinkInvalid
+	}
+
+	s.logAccess(profile, cv, requester, models.CVAccessSharedLink, nil)
+	return s.open(cv)
+}
+
+func (s *cvService) CreateShareLink(ownerUserID uint, requester CVRequester) (*models.CVShareLink, error) {
+	_, cv, err := s.getOwnerCV(ownerUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only someone who may download the CV can hand out links to it
+	if _, err := s.authorize(ownerUserID, requester); err != nil {
+		return nil, err
+	}
+
+	return newShareLink(s.signer, s.config.CVLinkTTL, ownerUserID, cv.StorageKey), nil
+}
+
+func (s *cvService) GetAccessLogs(userID uint, limit int) ([]models.CVAccessLog, error) {
+	profile, err := s.profileService.GetProfile(userID)
+	if err != nil {
+		return nil, errors.New("profile not found")
+	}
+
+	if limit <= 0 || limit > maxAccessLogLimit {
+		limit = maxAccessLogLimit
+	}
+	return s.accessLogRepo.GetByProfileID(profile.ID, limit)
+}
+
+func (s *cvService) getOwnerCV(ownerUserID uint) (*models.Profile, *models.CVDocument, error) {
+	profile, err := s.profileService.GetProfile(ownerUserID)
+	if err != nil {
+		return nil, nil, ErrCVNotFound
+	}
+
+	cv, err := s.repo.GetByProfileID(profile.ID)
+	if err != nil || cv.StorageKey == "" {
+		return nil, nil, ErrCVNotFound
+	}
+	return profile, cv, nil
+}
+
+// authorize decides whether the requester may access the owner's CV: the
+// owner always can, a company only if the owner applied to one of its jobs
+func (s *cvService) authorize(ownerUserID uint, requester CVRequester) (string, error) {
+	if requester.UserID == ownerUserID {
+		return models.CVAccessOwner, nil
+	}
+
+	if requester.UserType != "company" {
+		return models.CVAccessCompany, ErrCVAccessDenied
+	}
+
+	applied, err := s.hasAppliedToEmployer(ownerUserID, requester.UserID)
+	if err != nil {
+		slog.Error("Failed to verify application to employer", "user_id", ownerUserID, "employer_user_id", requester.UserID, "error", err)
+		return models.CVAccessCompany, ErrCVAccessDenied
+	}
+	if !applied {
+		return models.CVAccessCompany, ErrCVAccessDenied
+	}
+
+	return models.CVAccessCompany, nil
+}
+
+// hasAppliedToEmployer asks the job service whether the user applied to any job posted by the employer
+func (s *cvService) hasAppliedToEmployer(userID, employerUserID uint) (bool, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/stats/user/%d/applied/%d", s.config.JobServiceURL, userID, employerUserID)
+
+	resp, err := getJobService(context.Background(), s.httpClient, s.config.Identity.Secret, endpoint)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("job service returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+		Data    bool `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, err
+	}
+
+	return result.Success && result.Data, nil
+}
+
+func (s *cvService) open(cv *models.CVDocument) (*CVFile, error) {
+	reader, info, err := s.store.Get(context.Background(), cv.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrCVNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	contentType := info.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(cv.FileType)
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &CVFile{
+		Reader:      reader,
+		FileName:    cv.FileName,
+		ContentType: contentType,
+		Size:        info.Size,
+	}, nil
+}
+
+// logAccess records a download attempt; failures are logged but never block the download
+func (s *cvService) logAccess(profile *models.Profile, cv *models.CVDocument, requester CVRequester, accessType string, accessErr error) {
+	entry := &models.CVAccessLog{
+		CVID:       &cv.ID,
+		ProfileID:  profile.ID,
+		AccessType: accessType,
+		Granted:    accessErr == nil,
+		IPAddress:  requester.IPAddress,
+		UserAgent:  truncate(requester.UserAgent, 500),
+	}
+	if requester.UserID != 0 {
+		userID := requester.UserID
+		entry.AccessorUserID = &userID
+	}
+	if accessErr != nil {
+		entry.Reason = truncate(accessErr.Error(), 255)
+	}
+
+	if err := s.accessLogRepo.Create(entry); err != nil {
+		slog.Error("Failed to write CV access audit log", "profile_id", profile.ID, "error", err)
+	}
+}
+
+// extractCVText stores the CV's plain text on cv and marks it verified when
+// the text looks like a CV. Extraction failures only leave the CV unparsed.
+func extractCVText(cv *models.CVDocument, r io.ReaderAt, size int64) *cvparser.Result {
+	text, err := cvparser.ExtractText(r, size, cv.FileType)
+	if err != nil {
+		slog.Warn("Failed to extract CV text", "file_name", cv.FileName, "error", err)
+		return nil
+	}
+
+	now := time.Now()
+	result := cvparser.Parse(text)
+	cv.ExtractedText = text
+	cv.ParsedAt = &now
+	cv.IsVerified = result.LooksLikeCV()
+
+	slog.Debug("CV text extracted", "file_name", cv.FileName, "characters", len(text), "verified", cv.IsVerified)
+	return result
+}
+
+// cvKey returns a new storage key for a CV of the profile. The random part
+// keeps keys unguessable, CVs are only served through authorized endpoints.
+func cvKey(profileID uint, ext string) (string, error) {
+	suffix := make([]byte, 16)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate CV key: %w", err)
+	}
+	return storage.Key("cv", fmt.Sprintf("%d_%d_%s%s", profileID, time.Now().Unix(), hex.EncodeToString(suffix), ext)), nil
+}
+
+func cvDownloadPath(userID uint) string {
+	return fmt.Sprintf("/api/v1/cv/users/%d/download", userID)
+}
+
+func shareResource(ownerUserID uint, storageKey string) string {
+	return fmt.Sprintf("cv:%d:%s", ownerUserID, storageKey)
+}
+
+// newShareLink signs a download link for the owner's CV stored under storageKey
+func newShareLink(signer *storage.Signer, ttl time.Duration, ownerUserID uint, storageKey string) *models.CVShareLink {
+	expiresAt := time.Now().Add(ttl)
+	link := signer.SignURL(
+		fmt.Sprintf("/api/v1/cv/shared/%d", ownerUserID),
+		shareResource(ownerUserID, storageKey),
+		ttl,
+	)
+
+	return &models.CVShareLink{URL: link, ExpiresAt: expiresAt.Truncate(time.Second)}
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
@@ -2,9 +2,9 @@ package services
 
 import (
 	"errors"
-	"log"
 	"jobfair-user-profile-service/internal/models"
 	"jobfair-user-profile-service/internal/repository"
+	"log/slog"
 )
 
 type PreferenceService interface {
@@ -35,20 +35,17 @@ func (s *preferenceService) CreateOrUpdateCareerPreference(userID uint, req *mod
 	}
 	
 	// CRITICAL: Force reload to ensure ID is populated correctly
-	log.Printf("[CreateCareerPreference] Before reload - Profile: ID=%d, UserID=%d", profile.ID, profile.UserID)
+	slog.Debug("Reloading profile for career preference", "profile_id", profile.ID, "user_id", profile.UserID)
 	profile, err = s.profileService.GetProfile(userID)
 	if err != nil {
 		return nil, errors.New("failed to reload profile: " + err.Error())
 	}
-	log.Printf("[CreateCareerPreference] After reload - Profile: ID=%d, UserID=%d", profile.ID, profile.UserID)
 	
 	if profile.ID == 0 {
-		log.Printf("[CreateCareerPreference] ERROR: Profile ID is 0 after reload for userID=%d", userID)
+		slog.Error("Profile ID is 0 after reload", "user_id", userID)
 		return nil, errors.New("profile ID is invalid (0)")
 	}
 
-	log.Printf("[CreateCareerPreference] Got profile: ID=%d, UserID=%d", profile.ID, profile.UserID)
-
 	// Map the request fields to the database model
 	// job_type and work_location will be combined into PreferredWorkTypes
 	workTypes := req.JobType
@@ -64,7 +61,7 @@ func (s *preferenceService) CreateOrUpdateCareerPreference(userID uint, req *mod
 	existing, _ := s.repo.GetCareerPreferenceByProfileID(profile.ID)
 
 	if existing != nil {
-		log.Printf("[CreateCareerPreference] Updating existing preference ID=%d", existing.ID)
+		slog.Debug("Updating career preference", "preference_id", existing.ID, "profile_id", profile.ID)
 		// Update existing
 		existing.ExpectedSalaryMin = req.ExpectedSalaryMin
 		existing.ExpectedSalaryMax = req.ExpectedSalaryMax
@@ -83,8 +80,8 @@ func (s *preferenceService) CreateOrUpdateCareerPreference(userID uint, req *mod
 		return existing, nil
 	}
 
-	log.Printf("[CreateCareerPreference] Creating new preference for ProfileID=%d", profile.ID)
-	
+	slog.Debug("Creating career preference", "profile_id", profile.ID)
+
 	// Create new
 	preference := &models.CareerPreference{
 		ProfileID:          profile.ID,
@@ -98,23 +95,19 @@ func (s *preferenceService) CreateOrUpdateCareerPreference(userID uint, req *mod
 		AvailableStartDate: *req.AvailableFrom.ToTime(),
 	}
 
-	log.Printf("[CreateCareerPreference] About to insert preference with: ProfileID=%d, Salary=%d-%d", 
-		preference.ProfileID, preference.ExpectedSalaryMin, preference.ExpectedSalaryMax)
-	
 	// Double check ProfileID is not 0
 	if preference.ProfileID == 0 {
-		log.Printf("[CreateCareerPreference] CRITICAL ERROR: ProfileID is 0 in preference object!")
-		log.Printf("[CreateCareerPreference] Original profile.ID was: %d", profile.ID)
+		slog.Error("Career preference has no profile ID", "user_id", userID)
 		return nil, errors.New("cannot create career preference with ProfileID=0")
 	}
 
 	err = s.repo.CreateCareerPreference(preference)
 	if err != nil {
-		log.Printf("[CreateCareerPreference] Insert failed: %v", err)
+		slog.Error("Failed to create career preference", "profile_id", profile.ID, "error", err)
 		return nil, err
 	}
 
-	log.Printf("[CreateCareerPreference] Successfully created preference ID=%d", preference.ID)
+	slog.Info("Career preference created", "preference_id", preference.ID, "profile_id", profile.ID)
 	s.profileService.UpdateCompletionStatus(userID)
 	return preference, nil
 }
@@ -141,7 +134,7 @@ func (s *preferenceService) CreatePositionPreferences(userID uint, positions []s
 	}
 	
 	if profile.ID == 0 {
-		log.Printf("[CreatePositionPreferences] ERROR: Profile ID is 0 after GetOrCreate for userID=%d", userID)
+		slog.Error("Profile ID is 0 after get or create", "user_id", userID)
 		return nil, errors.New("profile creation failed: profile ID is 0")
 	}
 
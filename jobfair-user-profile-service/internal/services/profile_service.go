@@ -1,28 +1,29 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"log"
+	"log/slog"
 	"mime/multipart"
 	"net/http"
-	"os"
-	"path/filepath"
-	"strings"
+	"sync"
 	"time"
 
 	"jobfair-user-profile-service/internal/models"
 	"jobfair-user-profile-service/internal/repository"
 
+	"github.com/jobfair/shared/identity"
+	"github.com/jobfair/shared/storage"
+	"github.com/jobfair/shared/upload"
 	"gorm.io/gorm"
 )
 
 type ProfileService interface {
 	CreateProfile(userID uint, fullName, phoneNumber string) (*models.Profile, error)
 	GetProfile(userID uint) (*models.Profile, error)
-	GetProfileWithCounts(userID uint) (*models.ProfileResponse, error)
+	GetProfileWithCounts(ctx context.Context, userID uint) (*models.ProfileResponse, error)
 	GetOrCreateProfile(userID uint) (*models.Profile, error)
 	GetProfileWithRelations(userID uint) (*models.Profile, error)
 	UpdateProfile(userID uint, req *models.ProfileUpdateRequest) (*models.Profile, error)
@@ -40,6 +41,10 @@ type profileService struct {
 	skillRepo         repository.SkillRepository
 	preferenceRepo    repository.PreferenceRepository
 	cvRepo            repository.CVRepository
+	uploads           *upload.Pipeline
+	httpClient        *http.Client
+	jobServiceURL     string
+	identitySecret    string
 }
 
 func NewProfileService(
@@ -50,6 +55,10 @@ func NewProfileService(
 	skillRepo repository.SkillRepository,
 	preferenceRepo repository.PreferenceRepository,
 	cvRepo repository.CVRepository,
+	uploads *upload.Pipeline,
+	httpClient *http.Client,
+	jobServiceURL string,
+	identitySecret string,
 ) ProfileService {
 	return &profileService{
 		profileRepo:       profileRepo,
@@ -59,6 +68,10 @@ func NewProfileService(
 		skillRepo:         skillRepo,
 		preferenceRepo:    preferenceRepo,
 		cvRepo:            cvRepo,
+		uploads:           uploads,
+		httpClient:        httpClient,
+		jobServiceURL:     jobServiceURL,
+		identitySecret:    identitySecret,
 	}
 }
 
@@ -90,20 +103,18 @@ func (s *profileService) GetProfile(userID uint) (*models.Profile, error) {
 }
 
 func (s *profileService) GetOrCreateProfile(userID uint) (*models.Profile, error) {
-	log.Printf("[GetOrCreateProfile] Starting for userID: %d", userID)
-	
+	slog.Debug("Getting or creating profile", "user_id", userID)
+
 	// Try to get existing profile
 	profile, err := s.profileRepo.GetByUserID(userID)
 	if err == nil {
-		log.Printf("[GetOrCreateProfile] Found existing profile: ID=%d, UserID=%d", profile.ID, profile.UserID)
+		slog.Debug("Found existing profile", "profile_id", profile.ID, "user_id", profile.UserID)
 		return profile, nil
 	}
 
-	log.Printf("[GetOrCreateProfile] Profile not found, error: %v", err)
-
 	// If profile not found, create a new one
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		log.Printf("[GetOrCreateProfile] Creating new profile for userID: %d", userID)
+		slog.Info("Creating profile", "user_id", userID)
 		
 		newProfile := &models.Profile{
 			UserID:           userID,
@@ -114,30 +125,30 @@ func (s *profileService) GetOrCreateProfile(userID uint) (*models.Profile, error
 
 		err = s.profileRepo.Create(newProfile)
 		if err != nil {
-			log.Printf("[GetOrCreateProfile] Failed to create profile: %v", err)
+			slog.Error("Failed to create profile", "user_id", userID, "error", err)
 			return nil, err
 		}
 		
 		// GORM should auto-populate the ID after Create
-		log.Printf("[GetOrCreateProfile] Profile created with ID=%d", newProfile.ID)
+		slog.Info("Profile created", "profile_id", newProfile.ID, "user_id", userID)
 		
 		// Verify ID is set
 		if newProfile.ID == 0 {
-			log.Printf("[GetOrCreateProfile] WARNING: ID is 0 after create, reloading from DB")
+			slog.Warn("Profile ID is 0 after create, reloading", "user_id", userID)
 			// Reload from DB to ensure ID is populated
 			profile, err = s.profileRepo.GetByUserID(userID)
 			if err != nil {
-				log.Printf("[GetOrCreateProfile] Failed to reload profile: %v", err)
+				slog.Error("Failed to reload profile", "user_id", userID, "error", err)
 				return nil, err
 			}
-			log.Printf("[GetOrCreateProfile] Profile reloaded: ID=%d", profile.ID)
+			slog.Debug("Profile reloaded", "profile_id", profile.ID, "user_id", userID)
 			return profile, nil
 		}
 		
 		return newProfile, nil
 	}
 
-	log.Printf("[GetOrCreateProfile] Unexpected error: %v", err)
+	slog.Error("Failed to get profile", "user_id", userID, "error", err)
 	return nil, err
 }
 
@@ -229,6 +240,9 @@ func (s *profileService) UpdateProfile(userID uint, req *models.ProfileUpdateReq
 	if req.PortfolioURL != nil {
 		profile.PortfolioURL = *req.PortfolioURL
 	}
+	if req.CVSearchable != nil {
+		profile.CVSearchable = *req.CVSearchable
+	}
 	if req.ProfilePictureURL != nil {
 		profile.ProfilePictureURL = *req.ProfilePictureURL
 	}
@@ -346,80 +360,36 @@ func (s *profileService) UploadBanner(userID uint, file *multipart.FileHeader) (
 		return "", errors.New("failed to get or create profile: " + err.Error())
 	}
 
-	// Validate file size (max 10MB for banner)
-	maxSize := int64(10 * 1024 * 1024) // 10MB
-	if file.Size > maxSize {
-		return "", fmt.Errorf("file size exceeds maximum allowed size of %d bytes", maxSize)
-	}
-
-	// Validate file type (only images)
-	ext := strings.ToLower(filepath.Ext(file.Filename))
-	allowedTypes := []string{".jpg", ".jpeg", ".png", ".webp", ".gif"}
-	validType := false
-	for _, allowedType := range allowedTypes {
-		if ext == allowedType {
-			validType = true
-			break
-		}
-	}
-	if !validType {
-		return "", errors.New("file type not allowed. Allowed types: .jpg, .jpeg, .png, .webp, .gif")
-	}
-
-	// Create upload directory if not exists
-	uploadDir := "./uploads/banners"
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		return "", err
-	}
-
-	// Generate unique filename
-	filename := fmt.Sprintf("%d_%d%s", profile.ID, time.Now().Unix(), ext)
-	filePath := filepath.Join(uploadDir, filename)
+	// Generate unique object key; the extension is derived from the file content
+	key := storage.Key("banners", fmt.Sprintf("%d_%d", profile.ID, time.Now().Unix()))
 
-	fmt.Printf("[Banner Upload] Saving file to: %s\n", filePath)
-
-	// Save file
-	src, err := file.Open()
-	if err != nil {
-		return "", err
-	}
-	defer src.Close()
-
-	dst, err := os.Create(filePath)
+	// Validate (content type, size, malware scan), strip metadata and store with thumbnails
+	ctx := context.Background()
+	result, err := s.uploads.ProcessFile(ctx, file, upload.Banner, key)
 	if err != nil {
+		slog.Warn("Failed to store banner", "profile_id", profile.ID, "error", err)
 		return "", err
 	}
-	defer dst.Close()
-
-	if _, err := io.Copy(dst, src); err != nil {
-		fmt.Printf("[Banner Upload ERROR] Failed to copy file: %v\n", err)
-		return "", err
-	}
-
-	fmt.Printf("[Banner Upload] File saved successfully: %s\n", filePath)
-
-	// Delete old banner file if exists
-	if profile.BannerImageURL != "" {
-		oldFilename := filepath.Base(profile.BannerImageURL)
-		oldFilePath := filepath.Join(uploadDir, oldFilename)
-		os.Remove(oldFilePath)
-		fmt.Printf("[Banner Upload] Deleted old banner: %s\n", oldFilePath)
-	}
-
-	// Save URL path for HTTP access
-	bannerURL := fmt.Sprintf("/uploads/banners/%s", filename)
+	bannerURL := result.URL
 
 	// Update profile with new banner URL
+	oldBannerURL := profile.BannerImageURL
 	profile.BannerImageURL = bannerURL
 	err = s.profileRepo.Update(profile)
 	if err != nil {
 		// Cleanup uploaded file if database update fails
-		os.Remove(filePath)
-		fmt.Printf("[Banner Upload ERROR] Database update failed, file removed: %v\n", err)
+		s.uploads.Remove(ctx, result.Key, upload.Banner)
+		slog.Error("Failed to save banner, file removed", "profile_id", profile.ID, "error", err)
 		return "", err
 	}
 
-	fmt.Printf("[Banner Upload] ✅ Banner uploaded successfully! URL: %s\n", bannerURL)
+	// Delete old banner file once the profile points at the new one
+	if oldBannerURL != "" && oldBannerURL != bannerURL {
+		s.uploads.RemoveURL(ctx, oldBannerURL, upload.Banner)
+		slog.Debug("Old banner deleted", "url", oldBannerURL)
+	}
+
+	slog.Info("Banner uploaded", "profile_id", profile.ID, "url", bannerURL)
 
 	s.UpdateCompletionStatus(userID)
 	return bannerURL, nil
@@ -435,11 +405,12 @@ func (s *profileService) DeleteBanner(userID uint) error {
 		return errors.New("no banner to delete")
 	}
 
-	// Delete physical file
-	filename := filepath.Base(profile.BannerImageURL)
-	filePath := filepath.Join("./uploads/banners", filename)
-	os.Remove(filePath)
-	fmt.Printf("[Banner Delete] File removed: %s\n", filePath)
+	// Delete stored file
+	if err := s.uploads.RemoveURL(context.Background(), profile.BannerImageURL, upload.Banner); err != nil {
+		slog.Warn("Failed to delete banner file", "url", profile.BannerImageURL, "error", err)
+	} else {
+		slog.Debug("Banner file deleted", "url", profile.BannerImageURL)
+	}
 
 	// Update profile - remove banner URL
 	profile.BannerImageURL = ""
@@ -452,67 +423,75 @@ func (s *profileService) DeleteBanner(userID uint) error {
 	return nil
 }
 
-// fetchJobCounts fetches application and saved job counts from job service
-func (s *profileService) fetchJobCounts(userID uint) (applicationsCount int64, savedCount int64, err error) {
-	// Get job service URL from environment
-	jobServiceURL := os.Getenv("JOB_SERVICE_URL")
-	if jobServiceURL == "" {
-		jobServiceURL = "http://localhost:8082" // default
-	}
+// fetchJobCounts fetches application and saved job counts from job service.
+// Both counts are fetched concurrently; counts that can't be fetched fall back
+// to 0. While the job service's circuit breaker is open they fail fast instead
+// of waiting for timeouts.
+func (s *profileService) fetchJobCounts(ctx context.Context, userID uint) (applicationsCount int64, savedCount int64, err error) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		applicationsURL := fmt.Sprintf("%s/api/v1/stats/user/%d/applications", s.jobServiceURL, userID)
+		var applicationsErr error
+		if applicationsCount, applicationsErr = s.fetchCount(ctx, applicationsURL); applicationsErr != nil {
+			slog.WarnContext(ctx, "Failed to fetch applications count", "user_id", userID, "error", applicationsErr)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		savedURL := fmt.Sprintf("%s/api/v1/stats/user/%d/saved", s.jobServiceURL, userID)
+		var savedErr error
+		if savedCount, savedErr = s.fetchCount(ctx, savedURL); savedErr != nil {
+			slog.WarnContext(ctx, "Failed to fetch saved jobs count", "user_id", userID, "error", savedErr)
+		}
+	}()
+	wg.Wait()
 
-	client := &http.Client{Timeout: 5 * time.Second}
+	return applicationsCount, savedCount, nil
+}
 
-	// Fetch applications count
-	applicationsURL := fmt.Sprintf("%s/api/v1/stats/user/%d/applications", jobServiceURL, userID)
-	resp, err := client.Get(applicationsURL)
+// fetchCount fetches a count from one of job service's stats endpoints
+func (s *profileService) fetchCount(ctx context.Context, url string) (int64, error) {
+	resp, err := getJobService(ctx, s.httpClient, s.identitySecret, url)
 	if err != nil {
-		log.Printf("[WARNING] Failed to fetch applications count: %v", err)
-		// Continue with savedCount check even if applications fails
-		applicationsCount = 0
-	} else {
-		defer resp.Body.Close()
-		if resp.StatusCode == http.StatusOK {
-			var result struct {
-				Success bool  `json:"success"`
-				Data    int64 `json:"data"`
-			}
-			if err := json.NewDecoder(resp.Body).Decode(&result); err == nil && result.Success {
-				applicationsCount = result.Data
-			}
-		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, nil
 	}
 
-	// Fetch saved jobs count
-	savedURL := fmt.Sprintf("%s/api/v1/stats/user/%d/saved", jobServiceURL, userID)
-	resp, err = client.Get(savedURL)
-	if err != nil {
-		log.Printf("[WARNING] Failed to fetch saved count: %v", err)
-		savedCount = 0
-	} else {
-		defer resp.Body.Close()
-		if resp.StatusCode == http.StatusOK {
-			var result struct {
-				Success bool  `json:"success"`
-				Data    int64 `json:"data"`
-			}
-			if err := json.NewDecoder(resp.Body).Decode(&result); err == nil && result.Success {
-				savedCount = result.Data
-			}
-		}
+	var result struct {
+		Success bool  `json:"success"`
+		Data    int64 `json:"data"`
 	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil || !result.Success {
+		return 0, nil
+	}
+	return result.Data, nil
+}
 
-	return applicationsCount, savedCount, nil
+// getJobService sends a GET to an internal job-service endpoint as part of
+// ctx's trace, signed as this service so job-service accepts it
+func getJobService(ctx context.Context, client *http.Client, identitySecret, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	identity.SetService(req, identitySecret, "user-profile-service", time.Now())
+	return client.Do(req)
 }
 
 // GetProfileWithCounts returns profile with job counts
-func (s *profileService) GetProfileWithCounts(userID uint) (*models.ProfileResponse, error) {
+func (s *profileService) GetProfileWithCounts(ctx context.Context, userID uint) (*models.ProfileResponse, error) {
 	profile, err := s.GetProfile(userID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Fetch counts from job service
-	applicationsCount, savedCount, _ := s.fetchJobCounts(userID)
+	applicationsCount, savedCount, _ := s.fetchJobCounts(ctx, userID)
 
 	return &models.ProfileResponse{
 		Profile:           profile,
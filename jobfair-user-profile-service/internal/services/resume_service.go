@@ -0,0 +1,70 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+
+	"jobfair-user-profile-service/internal/models"
+	"jobfair-user-profile-service/internal/resume"
+)
+
+// ErrProfileNotFound is returned when the user has no profile to render
+var ErrProfileNotFound = errors.New("profile not found")
+
+type ResumeService interface {
+	// Generate renders the user's profile as a PDF résumé. When save is true
+	// the PDF also replaces the user's CV so it can be attached to applications.
+	Generate(userID uint, template string, save bool) (*Resume, error)
+	Templates() []resume.Template
+}
+
+// Resume is a rendered résumé PDF
+type Resume struct {
+	FileName string
+	Data     []byte
+	// CV is the saved CV document, nil unless the résumé was saved
+	CV *models.CVDocument
+}
+
+type resumeService struct {
+	profileService ProfileService
+	cvService      CVService
+}
+
+func NewResumeService(profileService ProfileService, cvService CVService) ResumeService {
+	return &resumeService{
+		profileService: profileService,
+		cvService:      cvService,
+	}
+}
+
+func (s *resumeService) Generate(userID uint, template string, save bool) (*Resume, error) {
+	profile, err := s.profileService.GetProfileWithRelations(userID)
+	if err != nil {
+		return nil, ErrProfileNotFound
+	}
+
+	var buf bytes.Buffer
+	if err := resume.Render(&buf, profile, template); err != nil {
+		return nil, err
+	}
+
+	result := &Resume{
+		FileName: resume.FileName(profile),
+		Data:     buf.Bytes(),
+	}
+
+	if save {
+		cv, err := s.cvService.SaveGenerated(userID, result.FileName, result.Data)
+		if err != nil {
+			return nil, errors.New("failed to save resume as CV: " + err.Error())
+		}
+		result.CV = cv
+	}
+
+	return result, nil
+}
+
+func (s *resumeService) Templates() []resume.Template {
+	return resume.Templates()
+}
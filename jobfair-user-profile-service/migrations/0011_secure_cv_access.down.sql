@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS cv_access_logs;
+
+UPDATE cv_documents
+SET file_url = '/uploads/' || storage_key
+WHERE storage_key IS NOT NULL;
+
+ALTER TABLE cv_documents DROP COLUMN IF EXISTS storage_key;
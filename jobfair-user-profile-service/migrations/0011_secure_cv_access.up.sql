@@ -0,0 +1,38 @@
+-- CV files are private: they are no longer served from /uploads/cv but
+-- streamed through the authorized download endpoint
+
+-- Object storage key of the CV file (file_url becomes the download endpoint)
+ALTER TABLE cv_documents ADD COLUMN IF NOT EXISTS storage_key VARCHAR(500);
+
+UPDATE cv_documents
+SET storage_key = substring(file_url FROM '(cv/[^/]+)$')
+WHERE storage_key IS NULL AND file_url ~ 'cv/[^/]+$';
+
+UPDATE cv_documents c
+SET file_url = '/api/v1/cv/users/' || p.user_id || '/download'
+FROM profiles p
+WHERE p.id = c.profile_id;
+
+COMMENT ON COLUMN cv_documents.storage_key IS 'Object storage key of the CV file; never exposed publicly';
+COMMENT ON COLUMN cv_documents.file_url IS 'Authorized download path of the CV';
+
+-- Audit log of every CV download attempt
+CREATE TABLE IF NOT EXISTS cv_access_logs (
+    id SERIAL PRIMARY KEY,
+    cv_id INTEGER REFERENCES cv_documents(id) ON DELETE SET NULL,
+    profile_id INTEGER NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    accessor_user_id INTEGER,
+    access_type VARCHAR(20) NOT NULL CHECK (access_type IN ('owner', 'company', 'shared_link')),
+    granted BOOLEAN NOT NULL,
+    reason VARCHAR(255),
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(500),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_cv_access_logs_profile_id ON cv_access_logs(profile_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_cv_access_logs_accessor_user_id ON cv_access_logs(accessor_user_id);
+
+COMMENT ON TABLE cv_access_logs IS 'Audit trail of CV downloads, including denied attempts';
+COMMENT ON COLUMN cv_access_logs.accessor_user_id IS 'Authenticated user who requested the CV; NULL for shared links';
+COMMENT ON COLUMN cv_access_logs.access_type IS 'owner, company (applicant to one of its jobs) or shared_link (signed URL)';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_cv_documents_search_vector;
+
+ALTER TABLE cv_documents DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE cv_documents DROP COLUMN IF EXISTS parsed_at;
+ALTER TABLE cv_documents DROP COLUMN IF EXISTS extracted_text;
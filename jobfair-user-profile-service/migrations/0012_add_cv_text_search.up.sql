@@ -0,0 +1,15 @@
+-- Text extracted from uploaded CVs (PDF/DOCX), used for profile autofill
+-- and indexed for recruiter search
+ALTER TABLE cv_documents ADD COLUMN IF NOT EXISTS extracted_text TEXT;
+ALTER TABLE cv_documents ADD COLUMN IF NOT EXISTS parsed_at TIMESTAMP;
+
+-- 'simple' configuration: CVs mix Indonesian and English, so no stemming
+ALTER TABLE cv_documents ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector('simple', COALESCE(extracted_text, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_cv_documents_search_vector ON cv_documents USING GIN(search_vector);
+
+COMMENT ON COLUMN cv_documents.extracted_text IS 'Plain text extracted from the CV file';
+COMMENT ON COLUMN cv_documents.parsed_at IS 'When text was last extracted from the CV';
+COMMENT ON COLUMN cv_documents.search_vector IS 'Full-text search index over extracted_text';
+COMMENT ON COLUMN cv_documents.is_verified IS 'True when the file was readable and looks like a CV (contact details and CV sections found)';
@@ -0,0 +1,5 @@
+-- Candidates opt in to appear in recruiter CV search; otherwise only companies
+-- they applied to can find them
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS cv_searchable BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN profiles.cv_searchable IS 'True when any company may find the candidate through CV search';